@@ -26,6 +26,8 @@ const (
 	QueryObjectID    = "object_id"
 	QueryUploaderID  = "uploader"
 	QueryStorageRoot = "storage_root"
+	QueryExpires     = "expires"   // signed download URL expiration (unix time)
+	QuerySignature   = "signature" // signed download URL signature
 )
 
 type Client struct {
@@ -33,6 +35,7 @@ type Client struct {
 	baseURL string
 	access  chapv1connect.AccessServiceClient
 	commit  chapv1connect.CommitServiceClient
+	admin   chapv1connect.AdminServiceClient
 }
 
 func NewClient(c *http.Client, baseurl string) *Client {
@@ -41,6 +44,7 @@ func NewClient(c *http.Client, baseurl string) *Client {
 		baseURL: baseurl,
 		access:  chapv1connect.NewAccessServiceClient(c, baseurl),
 		commit:  chapv1connect.NewCommitServiceClient(c, baseurl),
+		admin:   chapv1connect.NewAdminServiceClient(c, baseurl),
 	}
 }
 
@@ -52,6 +56,8 @@ type Commit struct {
 	State          map[string]string
 	Alg            string
 	ContentSources []any
+	// Metadata, if set, is saved as the new version's metadata file.
+	Metadata *ObjectMetadata
 }
 
 func commitAsProto(c *Commit) *chapv1.CommitRequest {
@@ -64,6 +70,7 @@ func commitAsProto(c *Commit) *chapv1.CommitRequest {
 		State:           c.State,
 		DigestAlgorithm: c.Alg,
 		ContentSources:  []*chapv1.CommitRequest_ContentSourceItem{},
+		Metadata:        c.Metadata.asProto(),
 	}
 	for i := range c.ContentSources {
 		newSrc := &chapv1.CommitRequest_ContentSourceItem{}
@@ -176,8 +183,15 @@ func (m Manifest) PathMap() ocfl.PathMap {
 	return pm
 }
 
+// ManifestItem is a digest and the FileInfo for its content in an object's
+// manifest.
+type ManifestItem struct {
+	Digest string
+	FileInfo
+}
+
 type FileInfo struct {
-	Size   int64          // number of bytes
+	Size   int64          // number of bytes, or -1 if it isn't known
 	Paths  []string       // sorted slice of path names
 	Fixity ocfl.DigestSet // other digests associated witht the content
 }
@@ -196,13 +210,41 @@ func (cli Client) GetObjectVersion(ctx context.Context, storeID string, objectID
 	return state, nil
 }
 
+// GetTaggedVersion is like GetObjectVersion except the version is identified
+// by a version tag.
+func (cli Client) GetTaggedVersion(ctx context.Context, storeID string, objectID string, tag string) (*ObjectVersion, error) {
+	req := &chapv1.GetObjectVersionRequest{
+		StorageRootId: storeID,
+		ObjectId:      objectID,
+		Tag:           tag,
+	}
+	resp, err := cli.access.GetObjectVersion(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return objectVersionFromProto(resp.Msg), nil
+}
+
 // ObjectManifest corresponds to GetObjectManifestResponse proto
 type ObjectManifest struct {
 	ObjectRef
 	Path            string
 	Spec            string
 	DigestAlgorithm string
+	InventoryDigest string
 	Manifest        Manifest
+	// Metadata from the object's most recent version. It may be nil.
+	Metadata *ObjectMetadata
+	// HeadPaths are the sorted logical paths in the object's most recent
+	// version. They are used to index objects for search and aren't returned
+	// by caches or included in GetObjectManifest responses.
+	HeadPaths []string
+	// Head is the object's most recent version number and VersionStates are
+	// the logical states of its versions (VersionStates[0] is v1). They are
+	// used to cache version states and aren't included in GetObjectManifest
+	// responses. Caches don't return VersionStates with the manifest.
+	Head          int
+	VersionStates []ocfl.DigestMap
 }
 
 func objectManifestFromProto(proto *chapv1.GetObjectManifestResponse) *ObjectManifest {
@@ -211,7 +253,9 @@ func objectManifestFromProto(proto *chapv1.GetObjectManifestResponse) *ObjectMan
 		Path:            proto.Path,
 		Spec:            proto.Spec,
 		DigestAlgorithm: proto.DigestAlgorithm,
+		InventoryDigest: proto.InventoryDigest,
 		Manifest:        manifestFromProto(proto.Manifest),
+		Metadata:        metadataFromProto(proto.Metadata),
 	}
 	return obj
 }
@@ -228,9 +272,395 @@ func (cli Client) GetObjectManifest(ctx context.Context, storeID string, objectI
 	return objectManifestFromProto(resp.Msg), nil
 }
 
+// ListObjectManifest calls fn with each item in the object's manifest, sorted
+// by digest. If prefix is set, only items for content with a logical path that
+// has the prefix, in any version, are included. Items are requested in pages
+// of pageSize items; if pageSize is 0, the server's default is used. Unlike
+// GetObjectManifest, it can be used with objects that have too many files to
+// return in one message. If fn returns an error, listing stops and the error
+// is returned.
+func (cli Client) ListObjectManifest(ctx context.Context, storeID string, objectID string, prefix string, pageSize int, fn func(ManifestItem) error) error {
+	req := &chapv1.ListObjectManifestRequest{
+		StorageRootId: storeID,
+		ObjectId:      objectID,
+		PathPrefix:    prefix,
+		PageSize:      int32(pageSize),
+	}
+	for {
+		resp, err := cli.access.ListObjectManifest(ctx, connect.NewRequest(req))
+		if err != nil {
+			return err
+		}
+		for _, item := range resp.Msg.Items {
+			manItem := ManifestItem{Digest: item.Digest}
+			if item.Info != nil {
+				manItem.FileInfo = FileInfo{
+					Size:   item.Info.Size,
+					Paths:  item.Info.Paths,
+					Fixity: item.Info.Fixity,
+				}
+			}
+			if err := fn(manItem); err != nil {
+				return err
+			}
+		}
+		if resp.Msg.NextPageToken == "" {
+			return nil
+		}
+		req.PageToken = resp.Msg.NextPageToken
+	}
+}
+
+// StateItem is a logical path and the digest and size of its content in an
+// object version's state.
+type StateItem struct {
+	Path   string
+	Digest string
+	Size   int64
+}
+
+// ListVersionState calls fn with each logical path in the object version's
+// state that has the prefix, sorted by path. If ver is 0, the most recent
+// version is used. Items are requested in pages of pageSize items; if pageSize
+// is 0, the server's default is used. Unlike GetObjectVersion, it can be used
+// with objects that have too many files to return in one message. If fn
+// returns an error, listing stops and the error is returned.
+func (cli Client) ListVersionState(ctx context.Context, storeID string, objectID string, ver int, prefix string, pageSize int, fn func(StateItem) error) error {
+	req := &chapv1.ListVersionStateRequest{
+		StorageRootId: storeID,
+		ObjectId:      objectID,
+		Version:       int32(ver),
+		PathPrefix:    prefix,
+		PageSize:      int32(pageSize),
+	}
+	return cli.listVersionState(ctx, req, fn)
+}
+
+// ListTaggedVersionState is like ListVersionState except the version is
+// identified by a version tag.
+func (cli Client) ListTaggedVersionState(ctx context.Context, storeID string, objectID string, tag string, prefix string, pageSize int, fn func(StateItem) error) error {
+	req := &chapv1.ListVersionStateRequest{
+		StorageRootId: storeID,
+		ObjectId:      objectID,
+		Tag:           tag,
+		PathPrefix:    prefix,
+		PageSize:      int32(pageSize),
+	}
+	return cli.listVersionState(ctx, req, fn)
+}
+
+func (cli Client) listVersionState(ctx context.Context, req *chapv1.ListVersionStateRequest, fn func(StateItem) error) error {
+	for {
+		resp, err := cli.access.ListVersionState(ctx, connect.NewRequest(req))
+		if err != nil {
+			return err
+		}
+		for _, item := range resp.Msg.Items {
+			if err := fn(StateItem{Path: item.Path, Digest: item.Digest, Size: item.Size}); err != nil {
+				return err
+			}
+		}
+		if resp.Msg.NextPageToken == "" {
+			return nil
+		}
+		// later pages are from the same version, even if the object has
+		// been updated or the tag has moved.
+		req.Version = resp.Msg.Version
+		req.Tag = ""
+		req.PageToken = resp.Msg.NextPageToken
+	}
+}
+
+// VersionPath corresponds to ListVersionPathResponse proto
+type VersionPath struct {
+	Version         int
+	DigestAlgorithm string
+	Path            string             // empty for the top-level directory
+	Entries         []VersionPathEntry // sorted by name
+	Size            int64              // total size of files in the directory
+	FileCount       int                // number of files in the directory
+}
+
+// VersionPathEntry is a file or subdirectory in a VersionPath. For
+// subdirectories, Size and FileCount include files in all subdirectories.
+type VersionPathEntry struct {
+	Name      string
+	IsDir     bool
+	Digest    string // empty for subdirectories
+	Size      int64
+	FileCount int
+}
+
+// ListVersionPath returns the files and subdirectories in the directory of the
+// object version's logical state. If ver is 0, the most recent version is
+// used. If dir is empty or ".", the top-level directory is listed. Sizes are
+// the sizes of the content with each file's digest. Directories with more than
+// 10000 entries can't be listed; use ListVersionState with a prefix instead.
+func (cli Client) ListVersionPath(ctx context.Context, storeID string, objectID string, ver int, dir string) (*VersionPath, error) {
+	req := &chapv1.ListVersionPathRequest{
+		StorageRootId: storeID,
+		ObjectId:      objectID,
+		Version:       int32(ver),
+		Path:          dir,
+	}
+	return cli.listVersionPath(ctx, req)
+}
+
+// ListTaggedVersionPath is like ListVersionPath except the version is
+// identified by a version tag.
+func (cli Client) ListTaggedVersionPath(ctx context.Context, storeID string, objectID string, tag string, dir string) (*VersionPath, error) {
+	req := &chapv1.ListVersionPathRequest{
+		StorageRootId: storeID,
+		ObjectId:      objectID,
+		Tag:           tag,
+		Path:          dir,
+	}
+	return cli.listVersionPath(ctx, req)
+}
+
+func (cli Client) listVersionPath(ctx context.Context, req *chapv1.ListVersionPathRequest) (*VersionPath, error) {
+	resp, err := cli.access.ListVersionPath(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	vp := &VersionPath{
+		Version:         int(resp.Msg.Version),
+		DigestAlgorithm: resp.Msg.DigestAlgorithm,
+		Path:            resp.Msg.Path,
+		Entries:         make([]VersionPathEntry, len(resp.Msg.Entries)),
+		Size:            resp.Msg.Size,
+		FileCount:       int(resp.Msg.FileCount),
+	}
+	for i, entry := range resp.Msg.Entries {
+		vp.Entries[i] = VersionPathEntry{
+			Name:      entry.Name,
+			IsDir:     entry.IsDir,
+			Digest:    entry.Digest,
+			Size:      entry.Size,
+			FileCount: int(entry.FileCount),
+		}
+	}
+	return vp, nil
+}
+
+// MetadataPath is the logical path of the file in an object's version state
+// where the object's metadata is saved.
+const MetadataPath = ".chaparral/metadata.json"
+
+// ObjectMetadata is descriptive metadata for an object. It is saved in the
+// object as JSON at MetadataPath.
+type ObjectMetadata struct {
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Creators    []string `json:"creators,omitempty"`
+	License     string   `json:"license,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+}
+
+func metadataFromProto(proto *chapv1.ObjectMetadata) *ObjectMetadata {
+	if proto == nil {
+		return nil
+	}
+	return &ObjectMetadata{
+		Title:       proto.Title,
+		Description: proto.Description,
+		Creators:    proto.Creators,
+		License:     proto.License,
+		Keywords:    proto.Keywords,
+	}
+}
+
+func (meta *ObjectMetadata) asProto() *chapv1.ObjectMetadata {
+	if meta == nil {
+		return nil
+	}
+	return &chapv1.ObjectMetadata{
+		Title:       meta.Title,
+		Description: meta.Description,
+		Creators:    meta.Creators,
+		License:     meta.License,
+		Keywords:    meta.Keywords,
+	}
+}
+
+// GetObjectMetadata returns metadata from the object's most recent version.
+// It returns nil if the object doesn't have metadata.
+func (cli Client) GetObjectMetadata(ctx context.Context, storeID string, objectID string) (*ObjectMetadata, error) {
+	req := &chapv1.GetObjectMetadataRequest{
+		StorageRootId: storeID,
+		ObjectId:      objectID,
+	}
+	resp, err := cli.access.GetObjectMetadata(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return metadataFromProto(resp.Msg.Metadata), nil
+}
+
+// VersionTag is a named reference to an object version.
+type VersionTag struct {
+	ObjectRef
+	Name    string
+	Version int       // tagged version number
+	Created time.Time // when the tag was set
+}
+
+// TagVersion sets the tag name on the object version. If ver is 0, the most
+// recent version is tagged. If the tag already exists for the object, it is
+// moved to the version.
+func (cli Client) TagVersion(ctx context.Context, storeID string, objectID string, name string, ver int) (*VersionTag, error) {
+	req := &chapv1.TagVersionRequest{
+		StorageRootId: storeID,
+		ObjectId:      objectID,
+		Name:          name,
+		Version:       int32(ver),
+	}
+	resp, err := cli.commit.TagVersion(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return versionTagFromProto(storeID, objectID, resp.Msg.Tag), nil
+}
+
+// ListTags returns the object's version tags, sorted by name.
+func (cli Client) ListTags(ctx context.Context, storeID string, objectID string) ([]VersionTag, error) {
+	req := &chapv1.ListTagsRequest{
+		StorageRootId: storeID,
+		ObjectId:      objectID,
+	}
+	resp, err := cli.access.ListTags(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]VersionTag, len(resp.Msg.Tags))
+	for i, tag := range resp.Msg.Tags {
+		tags[i] = *versionTagFromProto(storeID, objectID, tag)
+	}
+	return tags, nil
+}
+
+// DeleteTag removes the version tag name from the object.
+func (cli Client) DeleteTag(ctx context.Context, storeID string, objectID string, name string) error {
+	req := &chapv1.DeleteTagRequest{
+		StorageRootId: storeID,
+		ObjectId:      objectID,
+		Name:          name,
+	}
+	_, err := cli.commit.DeleteTag(ctx, connect.NewRequest(req))
+	return err
+}
+
+func versionTagFromProto(storeID string, objectID string, proto *chapv1.VersionTag) *VersionTag {
+	return &VersionTag{
+		ObjectRef: ObjectRef{StorageRootID: storeID, ID: objectID},
+		Name:      proto.GetName(),
+		Version:   int(proto.GetVersion()),
+		Created:   proto.GetCreated().AsTime(),
+	}
+}
+
+// ObjectListItem is an object returned by ListObjects
+type ObjectListItem struct {
+	ObjectRef
+	Path     string
+	Metadata *ObjectMetadata
+}
+
+// ListObjects returns indexed objects in the storage root, sorted by id. It
+// also returns the offset for the next page, which is 0 if there are no more
+// objects.
+func (cli Client) ListObjects(ctx context.Context, storeID string, limit int, offset int) ([]ObjectListItem, int, error) {
+	req := &chapv1.ListObjectsRequest{
+		StorageRootId: storeID,
+		Limit:         int32(limit),
+		Offset:        int32(offset),
+	}
+	resp, err := cli.access.ListObjects(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, 0, err
+	}
+	return objectListItemsFromProto(storeID, resp.Msg.Objects), int(resp.Msg.NextOffset), nil
+}
+
+// SearchObjects returns indexed objects in the storage root with metadata or
+// logical paths that include all terms in query. If digest is not empty, only
+// objects with the content digest are included. It also returns the offset for
+// the next page, which is 0 if there are no more objects.
+func (cli Client) SearchObjects(ctx context.Context, storeID string, query string, digest string, limit int, offset int) ([]ObjectListItem, int, error) {
+	req := &chapv1.SearchObjectsRequest{
+		StorageRootId: storeID,
+		Query:         query,
+		Digest:        digest,
+		Limit:         int32(limit),
+		Offset:        int32(offset),
+	}
+	resp, err := cli.access.SearchObjects(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, 0, err
+	}
+	return objectListItemsFromProto(storeID, resp.Msg.Objects), int(resp.Msg.NextOffset), nil
+}
+
+func objectListItemsFromProto(storeID string, proto []*chapv1.ListObjectsResponse_Item) []ObjectListItem {
+	items := make([]ObjectListItem, len(proto))
+	for i, item := range proto {
+		items[i] = ObjectListItem{
+			ObjectRef: ObjectRef{StorageRootID: storeID, ID: item.ObjectId},
+			Path:      item.Path,
+			Metadata:  metadataFromProto(item.Metadata),
+		}
+	}
+	return items
+}
+
+// ContentLocation describes where content with a given digest is found in an
+// object.
+type ContentLocation struct {
+	ObjectRef
+	ContentPaths []string       // content paths relative to the object root
+	Versions     []VersionPaths // versions with the content, sorted by number
+}
+
+// VersionPaths are logical paths in an object version
+type VersionPaths struct {
+	Version int      // version number
+	Paths   []string // sorted logical paths
+}
+
+// FindContentByDigest returns all objects in the storage root that include
+// content with the digest, sorted by object id.
+func (cli Client) FindContentByDigest(ctx context.Context, storeID string, digest string) ([]ContentLocation, error) {
+	req := &chapv1.FindContentByDigestRequest{
+		StorageRootId: storeID,
+		Digest:        digest,
+	}
+	resp, err := cli.access.FindContentByDigest(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	locs := make([]ContentLocation, len(resp.Msg.Objects))
+	for i, item := range resp.Msg.Objects {
+		locs[i] = ContentLocation{
+			ObjectRef:    ObjectRef{StorageRootID: storeID, ID: item.ObjectId},
+			ContentPaths: item.ContentPaths,
+			Versions:     make([]VersionPaths, len(item.Versions)),
+		}
+		for j, v := range item.Versions {
+			locs[i].Versions[j] = VersionPaths{
+				Version: int(v.Version),
+				Paths:   v.Paths,
+			}
+		}
+	}
+	return locs, nil
+}
+
 type Content struct {
 	io.ReadCloser
 	Size int64
+	// DigestAlgorithm and Digest are only set by GetContentStream.
+	DigestAlgorithm string
+	Digest          string
 }
 
 // Download
@@ -276,6 +706,194 @@ func (cli Client) GetContentPath(ctx context.Context, storeID, objectID, content
 	}, nil
 }
 
+// ContentOptions identify content for GetContentStream. One of Digest,
+// ContentPath, or LogicalPath is required.
+type ContentOptions struct {
+	Digest      string
+	ContentPath string
+	LogicalPath string
+	// Version is used with LogicalPath. If 0, the object's most recent
+	// version is used.
+	Version int
+	// Tag is used with LogicalPath. If set, the tagged version is used
+	// instead of Version.
+	Tag string
+}
+
+// GetContentStream returns object content using the GetContent RPC rather
+// than the download route. The returned Content must be closed.
+func (cli Client) GetContentStream(ctx context.Context, storeID, objectID string, opts ContentOptions) (*Content, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	req := &chapv1.GetContentRequest{
+		StorageRootId: storeID,
+		ObjectId:      objectID,
+		Digest:        opts.Digest,
+		ContentPath:   opts.ContentPath,
+		LogicalPath:   opts.LogicalPath,
+		Version:       int32(opts.Version),
+		Tag:           opts.Tag,
+	}
+	stream, err := cli.access.GetContent(ctx, connect.NewRequest(req))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	body := &contentStreamReader{stream: stream, cancel: cancel}
+	if !stream.Receive() {
+		err := stream.Err()
+		if err == nil {
+			err = errors.New("server response is missing the content header")
+		}
+		body.Close()
+		return nil, err
+	}
+	header := stream.Msg().GetHeader()
+	if header == nil {
+		body.Close()
+		return nil, errors.New("server response is missing the content header")
+	}
+	body.remaining = header.Size
+	return &Content{
+		ReadCloser:      body,
+		Size:            header.Size,
+		DigestAlgorithm: header.DigestAlgorithm,
+		Digest:          header.Digest,
+	}, nil
+}
+
+// contentStreamReader reads content from a GetContent response stream.
+type contentStreamReader struct {
+	stream    *connect.ServerStreamForClient[chapv1.GetContentResponse]
+	cancel    context.CancelFunc
+	buf       []byte
+	remaining int64 // expected bytes not yet read
+}
+
+func (r *contentStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if !r.stream.Receive() {
+			if err := r.stream.Err(); err != nil {
+				return 0, err
+			}
+			if r.remaining != 0 {
+				return 0, io.ErrUnexpectedEOF
+			}
+			return 0, io.EOF
+		}
+		r.buf = r.stream.Msg().Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	r.remaining -= int64(n)
+	return n, nil
+}
+
+func (r *contentStreamReader) Close() error {
+	// cancel first: Close reads the rest of the stream, and errors from
+	// closing before the end aren't meaningful.
+	r.cancel()
+	r.stream.Close()
+	return nil
+}
+
+// DownloadURL is a time-limited URL for downloading content without an
+// authorization token.
+type DownloadURL struct {
+	URL     string
+	Expires time.Time
+	// Direct is true if URL is a presigned URL for the storage backend
+	// rather than the chaparral server.
+	Direct bool
+}
+
+// CreateDownloadURL returns a signed URL for downloading the object content
+// with the digest or, if contentPath is not empty, the content path. The URL
+// expires after expiresIn; if expiresIn is 0, the server's default is used.
+// If direct is true and the storage root's backend supports it, the URL is for
+// downloading directly from the backend.
+func (cli Client) CreateDownloadURL(ctx context.Context, storeID, objectID, digest, contentPath string, expiresIn time.Duration, direct bool) (*DownloadURL, error) {
+	req := &chapv1.CreateDownloadURLRequest{
+		StorageRootId: storeID,
+		ObjectId:      objectID,
+		Digest:        digest,
+		ContentPath:   contentPath,
+		ExpiresIn:     int64(expiresIn / time.Second),
+		Direct:        direct,
+	}
+	resp, err := cli.access.CreateDownloadURL(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	dlURL := &DownloadURL{
+		URL:     resp.Msg.Url,
+		Expires: resp.Msg.Expires.AsTime(),
+		Direct:  resp.Msg.Direct,
+	}
+	if !dlURL.Direct {
+		dlURL.URL = cli.baseURL + dlURL.URL
+	}
+	return dlURL, nil
+}
+
+// ObjectEvent corresponds to WatchEventsResponse proto
+type ObjectEvent struct {
+	Cursor        string // used to resume watching after the event
+	ID            string
+	Type          string // "object.committed" or "object.deleted"
+	StorageRootID string
+	ObjectID      string
+	UserID        string
+	Created       time.Time
+}
+
+// WatchOptions are used with WatchEvents.
+type WatchOptions struct {
+	// storage root ids to watch; if empty, all storage roots are watched.
+	StorageRootIDs []string
+	// object id prefixes to watch; if empty, all objects are watched.
+	ObjectIDPrefixes []string
+	// Cursor from a previous event. If empty, only new events are sent.
+	Cursor string
+}
+
+// WatchEvents calls fn with events for objects that are committed or deleted
+// until ctx is canceled, fn returns an error, or the connection fails. To
+// resume watching, call WatchEvents again with the Cursor from the last event
+// received.
+func (cli Client) WatchEvents(ctx context.Context, opts WatchOptions, fn func(ObjectEvent) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	req := &chapv1.WatchEventsRequest{
+		StorageRootIds:   opts.StorageRootIDs,
+		ObjectIdPrefixes: opts.ObjectIDPrefixes,
+		Cursor:           opts.Cursor,
+	}
+	stream, err := cli.access.WatchEvents(ctx, connect.NewRequest(req))
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	// the stream doesn't end on its own: cancel before Close, which reads
+	// the rest of the response.
+	defer cancel()
+	for stream.Receive() {
+		msg := stream.Msg()
+		event := ObjectEvent{
+			Cursor:        msg.Cursor,
+			ID:            msg.EventId,
+			Type:          msg.EventType,
+			StorageRootID: msg.StorageRootId,
+			ObjectID:      msg.ObjectId,
+			UserID:        msg.UserId,
+			Created:       msg.Created.AsTime(),
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return stream.Err()
+}
+
 func (cli Client) DeleteObject(ctx context.Context, storeID string, objectID string) error {
 	req := &chapv1.DeleteObjectRequest{
 		StorageRootId: storeID,
@@ -285,6 +903,270 @@ func (cli Client) DeleteObject(ctx context.Context, storeID string, objectID str
 	return err
 }
 
+// CopyObject copies the object with all its versions from the storage root
+// srcStoreID to the storage root dstStoreID.
+func (cli Client) CopyObject(ctx context.Context, srcStoreID string, objectID string, dstStoreID string) error {
+	req := &chapv1.CopyObjectRequest{
+		SrcStorageRootId: srcStoreID,
+		ObjectId:         objectID,
+		DstStorageRootId: dstStoreID,
+	}
+	_, err := cli.commit.CopyObject(ctx, connect.NewRequest(req))
+	return err
+}
+
+// MoveObject moves the object with all its versions from the storage root
+// srcStoreID to the storage root dstStoreID.
+func (cli Client) MoveObject(ctx context.Context, srcStoreID string, objectID string, dstStoreID string) error {
+	req := &chapv1.MoveObjectRequest{
+		SrcStorageRootId: srcStoreID,
+		ObjectId:         objectID,
+		DstStorageRootId: dstStoreID,
+	}
+	_, err := cli.commit.MoveObject(ctx, connect.NewRequest(req))
+	return err
+}
+
+// StorageRootInfo corresponds to ListStorageRootsResponse_Item proto
+type StorageRootInfo struct {
+	ID          string
+	Path        string
+	Description string
+	Spec        string
+	Layout      string
+	ObjectCount int64
+	TotalBytes  int64
+}
+
+// ListStorageRoots returns information about storage roots for which the
+// user has the admin permission. ObjectCount and TotalBytes are only set if
+// includeStats is true; they are computed by walking the storage roots.
+func (cli Client) ListStorageRoots(ctx context.Context, includeStats bool) ([]StorageRootInfo, error) {
+	req := &chapv1.ListStorageRootsRequest{IncludeStats: includeStats}
+	resp, err := cli.admin.ListStorageRoots(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	roots := make([]StorageRootInfo, len(resp.Msg.StorageRoots))
+	for i, item := range resp.Msg.StorageRoots {
+		roots[i] = StorageRootInfo{
+			ID:          item.Id,
+			Path:        item.Path,
+			Description: item.Description,
+			Spec:        item.Spec,
+			Layout:      item.Layout,
+			ObjectCount: item.ObjectCount,
+			TotalBytes:  item.TotalBytes,
+		}
+	}
+	return roots, nil
+}
+
+// ValidationFinding corresponds to ValidateStorageRootResponse proto
+type ValidationFinding struct {
+	Fatal      bool
+	Message    string
+	OCFLCode   string
+	ObjectPath string
+}
+
+// ValidateStorageRoot validates the storage root, calling fn for each error or
+// warning as it is found. If fn returns an error, validation is canceled and
+// the error is returned.
+func (cli Client) ValidateStorageRoot(ctx context.Context, storeID string, skipDigests bool, fn func(ValidationFinding) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	req := &chapv1.ValidateStorageRootRequest{
+		StorageRootId: storeID,
+		SkipDigests:   skipDigests,
+	}
+	stream, err := cli.admin.ValidateStorageRoot(ctx, connect.NewRequest(req))
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	for stream.Receive() {
+		msg := stream.Msg()
+		finding := ValidationFinding{
+			Fatal:      msg.Fatal,
+			Message:    msg.Message,
+			OCFLCode:   msg.OcflCode,
+			ObjectPath: msg.ObjectPath,
+		}
+		if err := fn(finding); err != nil {
+			return err
+		}
+	}
+	return stream.Err()
+}
+
+// ReindexResult corresponds to ReindexStorageRootResponse proto
+type ReindexResult struct {
+	Indexed int // number of objects indexed
+	Failed  int // number of objects that couldn't be read
+	Removed int // number of cache entries removed
+}
+
+// ReindexStorageRoot rebuilds the server's object cache for the storage root.
+func (cli Client) ReindexStorageRoot(ctx context.Context, storeID string) (*ReindexResult, error) {
+	req := &chapv1.ReindexStorageRootRequest{StorageRootId: storeID}
+	resp, err := cli.admin.ReindexStorageRoot(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return &ReindexResult{
+		Indexed: int(resp.Msg.Objects),
+		Failed:  int(resp.Msg.Failed),
+		Removed: int(resp.Msg.Removed),
+	}, nil
+}
+
+// AuditResult corresponds to ListAuditResultsResponse_Item proto
+type AuditResult struct {
+	StorageRootID string
+	Path          string
+	ObjectID      string
+	Checked       time.Time
+	Valid         bool
+	Errors        []string
+	Warnings      []string
+}
+
+// ListAuditResults returns fixity audit results for objects in the storage
+// root. If failedOnly is true, only results for invalid objects are included.
+func (cli Client) ListAuditResults(ctx context.Context, storeID string, failedOnly bool, limit int, offset int) ([]AuditResult, error) {
+	req := &chapv1.ListAuditResultsRequest{
+		StorageRootId: storeID,
+		FailedOnly:    failedOnly,
+		Limit:         int32(limit),
+		Offset:        int32(offset),
+	}
+	resp, err := cli.admin.ListAuditResults(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]AuditResult, len(resp.Msg.Results))
+	for i, item := range resp.Msg.Results {
+		results[i] = AuditResult{
+			StorageRootID: item.StorageRootId,
+			Path:          item.Path,
+			ObjectID:      item.ObjectId,
+			Checked:       item.Checked.AsTime(),
+			Valid:         item.Valid,
+			Errors:        item.Errors,
+			Warnings:      item.Warnings,
+		}
+	}
+	return results, nil
+}
+
+// WebhookDelivery corresponds to ListWebhookDeliveriesResponse_Item proto
+type WebhookDelivery struct {
+	ID            string
+	EndpointID    string
+	EventID       string
+	EventType     string
+	StorageRootID string
+	ObjectID      string
+	UploaderID    string
+	UserID        string
+	Status        string
+	Attempts      int
+	LastStatus    int
+	LastError     string
+	NextAttempt   time.Time // zero unless the delivery is pending
+	Created       time.Time
+	Updated       time.Time
+}
+
+// ListWebhookDeliveries returns the server's webhook delivery log, most recent
+// events first. If endpointID is not empty, only deliveries to the endpoint
+// are included.
+func (cli Client) ListWebhookDeliveries(ctx context.Context, endpointID string, limit int, offset int) ([]WebhookDelivery, error) {
+	req := &chapv1.ListWebhookDeliveriesRequest{
+		EndpointId: endpointID,
+		Limit:      int32(limit),
+		Offset:     int32(offset),
+	}
+	resp, err := cli.admin.ListWebhookDeliveries(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	deliveries := make([]WebhookDelivery, len(resp.Msg.Deliveries))
+	for i, item := range resp.Msg.Deliveries {
+		deliveries[i] = WebhookDelivery{
+			ID:            item.Id,
+			EndpointID:    item.EndpointId,
+			EventID:       item.EventId,
+			EventType:     item.EventType,
+			StorageRootID: item.StorageRootId,
+			ObjectID:      item.ObjectId,
+			UploaderID:    item.UploaderId,
+			UserID:        item.UserId,
+			Status:        item.Status,
+			Attempts:      int(item.Attempts),
+			LastStatus:    int(item.LastStatus),
+			LastError:     item.LastError,
+			Created:       item.Created.AsTime(),
+			Updated:       item.Updated.AsTime(),
+		}
+		if item.NextAttempt != nil {
+			deliveries[i].NextAttempt = item.NextAttempt.AsTime()
+		}
+	}
+	return deliveries, nil
+}
+
+// DedupReport summarizes content stored in more than one object in a storage
+// root.
+type DedupReport struct {
+	DuplicateDigests int                // number of digests in more than one object
+	DuplicateBytes   int64              // bytes used by redundant copies
+	Items            []DuplicateContent // sorted by DuplicateBytes, largest first
+}
+
+// DuplicateContent is content with the same digest in multiple objects.
+type DuplicateContent struct {
+	Digest    string
+	Size      int64    // size of one copy
+	ObjectIDs []string // sorted ids of objects with the content
+}
+
+// DuplicateBytes returns the number of bytes used by redundant copies of the
+// content.
+func (dup DuplicateContent) DuplicateBytes() int64 {
+	if len(dup.ObjectIDs) < 2 {
+		return 0
+	}
+	return dup.Size * int64(len(dup.ObjectIDs)-1)
+}
+
+// GetDedupReport returns a report of content stored in more than one object in
+// the storage root. The report includes at most limit items.
+func (cli Client) GetDedupReport(ctx context.Context, storeID string, limit int) (*DedupReport, error) {
+	req := &chapv1.GetDedupReportRequest{
+		StorageRootId: storeID,
+		Limit:         int32(limit),
+	}
+	resp, err := cli.admin.GetDedupReport(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	report := &DedupReport{
+		DuplicateDigests: int(resp.Msg.DuplicateDigests),
+		DuplicateBytes:   resp.Msg.DuplicateBytes,
+		Items:            make([]DuplicateContent, len(resp.Msg.Items)),
+	}
+	for i, item := range resp.Msg.Items {
+		report.Items[i] = DuplicateContent{
+			Digest:    item.Digest,
+			Size:      item.Size,
+			ObjectIDs: item.ObjectIds,
+		}
+	}
+	return report, nil
+}
+
 type Uploader struct {
 	UploaderRef
 	UploadPath       string    `json:"upload_path"`
@@ -371,8 +1253,31 @@ type Upload struct {
 	Digests ocfl.DigestSet `json:"digests"`
 }
 
+// Upload uploads the contents of r to the uploader with the upload path. The
+// content's sha256 and sha512 digests are sent in a Repr-Digest trailer so the
+// server can verify the upload.
 func (cli Client) Upload(ctx context.Context, uploadPath string, r io.Reader) (result Upload, err error) {
-	resp, err := cli.Post(cli.baseURL+uploadPath, "application/octet-stream", r)
+	digester := ocfl.NewMultiDigester(ocfl.SHA256, ocfl.SHA512)
+	trailer := http.Header{HeaderReprDigest: nil}
+	body := &trailerReader{
+		Reader: io.TeeReader(r, digester),
+		onEOF: func() error {
+			val, err := FormatReprDigest(digester.Sums())
+			if err != nil {
+				return err
+			}
+			trailer.Set(HeaderReprDigest, val)
+			return nil
+		},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cli.baseURL+uploadPath, body)
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.ContentLength = -1
+	req.Trailer = trailer
+	resp, err := cli.Do(req)
 	if err != nil {
 		return result, fmt.Errorf("during upload: %w", err)
 	}
@@ -386,6 +1291,12 @@ func (cli Client) Upload(ctx context.Context, uploadPath string, r io.Reader) (r
 		return result, fmt.Errorf("reading upload response: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Err string `json:"error"`
+		}
+		if jsonErr := json.Unmarshal(byt, &errResp); jsonErr == nil && errResp.Err != "" {
+			return result, fmt.Errorf("unexpected upload response status: %q: %s", resp.Status, errResp.Err)
+		}
 		err = fmt.Errorf("unexpected upload response status: %q", resp.Status)
 	}
 	uploadResp := Upload{}
@@ -397,6 +1308,263 @@ func (cli Client) Upload(ctx context.Context, uploadPath string, r io.Reader) (r
 	return
 }
 
+// uploadChunkSize is the size of content chunks sent with UploadStream.
+const uploadChunkSize = 256 * 1024
+
+// UploadStream uploads the contents of r to the uploader using the Upload
+// RPC rather than the uploader's upload path. The content's sha256 and sha512
+// digests are sent with the last message so the server can verify the upload.
+func (cli Client) UploadStream(ctx context.Context, uploaderID string, r io.Reader) (Upload, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	digester := ocfl.NewMultiDigester(ocfl.SHA256, ocfl.SHA512)
+	stream := cli.commit.Upload(ctx)
+	buf := make([]byte, uploadChunkSize)
+	// the uploader id is only needed in the first message
+	id := uploaderID
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			digester.Write(buf[:n])
+			msg := &chapv1.UploadRequest{UploaderId: id, Data: buf[:n]}
+			id = ""
+			if err := stream.Send(msg); err != nil {
+				if errors.Is(err, io.EOF) {
+					// the server ended the stream: the error is in the
+					// response.
+					break
+				}
+				return Upload{}, err
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return Upload{}, fmt.Errorf("reading upload content: %w", readErr)
+		}
+	}
+	// the last message has the digests and, for empty content, may be the
+	// only message.
+	if err := stream.Send(&chapv1.UploadRequest{
+		UploaderId: id,
+		Digests:    digester.Sums(),
+	}); err != nil && !errors.Is(err, io.EOF) {
+		return Upload{}, err
+	}
+	resp, err := stream.CloseAndReceive()
+	if err != nil {
+		return Upload{}, err
+	}
+	return Upload{Digests: resp.Msg.Digests, Size: resp.Msg.Size}, nil
+}
+
+// HasContent returns the digests, computed with alg, that are already
+// available in the uploader or the object, so they don't need to be uploaded
+// before a commit. uploaderID or object may be empty.
+func (cli Client) HasContent(ctx context.Context, uploaderID string, object ObjectRef, alg string, digests ...string) ([]string, error) {
+	req := &chapv1.HasContentRequest{
+		DigestAlgorithm: alg,
+		Digests:         digests,
+		UploaderId:      uploaderID,
+		StorageRootId:   object.StorageRootID,
+		ObjectId:        object.ID,
+	}
+	resp, err := cli.commit.HasContent(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg.Digests, nil
+}
+
+// UploadUsage is a user's upload usage and quota. Quota values of zero are
+// unlimited.
+type UploadUsage struct {
+	UserID           string `json:"user_id"`
+	Bytes            int64  `json:"bytes"`
+	Files            int64  `json:"files"`
+	MaxBytes         int64  `json:"max_bytes"`
+	MaxFiles         int64  `json:"max_files"`
+	MaxUploaderBytes int64  `json:"max_uploader_bytes"`
+	MaxUploaderFiles int64  `json:"max_uploader_files"`
+}
+
+// GetUsage returns the total size and number of files in the user's uploaders
+// and the user's upload quota. Pending multipart uploads are included in the
+// usage. If userID is empty, usage for the client's user is returned.
+func (cli Client) GetUsage(ctx context.Context, userID string) (*UploadUsage, error) {
+	req := &chapv1.GetUsageRequest{UserId: userID}
+	resp, err := cli.commit.GetUsage(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return &UploadUsage{
+		UserID:           resp.Msg.UserId,
+		Bytes:            resp.Msg.Bytes,
+		Files:            resp.Msg.Files,
+		MaxBytes:         resp.Msg.MaxBytes,
+		MaxFiles:         resp.Msg.MaxFiles,
+		MaxUploaderBytes: resp.Msg.MaxUploaderBytes,
+		MaxUploaderFiles: resp.Msg.MaxUploaderFiles,
+	}, nil
+}
+
+// trailerReader calls onEOF before returning io.EOF from the underlying
+// reader. It's used to set request trailers after the body has been read.
+type trailerReader struct {
+	io.Reader
+	onEOF func() error
+	done  bool
+}
+
+func (tr *trailerReader) Read(p []byte) (int, error) {
+	n, err := tr.Reader.Read(p)
+	if errors.Is(err, io.EOF) && !tr.done {
+		tr.done = true
+		if eofErr := tr.onEOF(); eofErr != nil {
+			return n, eofErr
+		}
+	}
+	return n, err
+}
+
+// MultipartUpload is a multipart upload to an uploader, started with
+// NewMultipartUpload.
+type MultipartUpload struct {
+	UploaderID string
+	// Name of the file being uploaded, relative to the uploader
+	Name string
+	// UploadID is the storage backend's id for the upload
+	UploadID string
+	// PartURLs are presigned URLs for uploading each part, in part number
+	// order.
+	PartURLs []string
+	Expires  time.Time
+}
+
+// MultipartPart is an uploaded part of a multipart upload.
+type MultipartPart struct {
+	Number int32
+	ETag   string
+}
+
+// NewMultipartUpload starts a multipart upload of a file with the given size
+// and number of parts to the uploader. Parts are uploaded directly to the
+// storage backend with UploadPart. If expiresIn is 0, the server's default
+// expiration is used for the part URLs.
+func (cli Client) NewMultipartUpload(ctx context.Context, uploaderID string, size int64, parts int, expiresIn time.Duration) (*MultipartUpload, error) {
+	req := &chapv1.NewMultipartUploadRequest{
+		UploaderId: uploaderID,
+		PartCount:  int32(parts),
+		ExpiresIn:  int64(expiresIn / time.Second),
+		Size:       size,
+	}
+	resp, err := cli.commit.NewMultipartUpload(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return &MultipartUpload{
+		UploaderID: uploaderID,
+		Name:       resp.Msg.Name,
+		UploadID:   resp.Msg.UploadId,
+		PartURLs:   resp.Msg.PartUrls,
+		Expires:    resp.Msg.Expires.AsTime(),
+	}, nil
+}
+
+// UploadPart uploads size bytes from r as the part number (starting at 1) of
+// the multipart upload. The part is sent to its presigned URL using
+// http.DefaultClient, without the client's credentials.
+func (cli Client) UploadPart(ctx context.Context, mp *MultipartUpload, number int32, r io.Reader, size int64) (MultipartPart, error) {
+	part := MultipartPart{Number: number}
+	if number < 1 || int(number) > len(mp.PartURLs) {
+		return part, fmt.Errorf("invalid part number: %d", number)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, mp.PartURLs[number-1], io.LimitReader(r, size))
+	if err != nil {
+		return part, err
+	}
+	req.ContentLength = size
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return part, fmt.Errorf("uploading part %d: %w", number, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return part, fmt.Errorf("uploading part %d: server response (%s): %s", number, resp.Status, msg)
+	}
+	part.ETag = resp.Header.Get("ETag")
+	return part, nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts and adds the file to
+// the uploader. If digests is not empty, the server verifies that the file
+// matches them.
+func (cli Client) CompleteMultipartUpload(ctx context.Context, mp *MultipartUpload, parts []MultipartPart, digests ocfl.DigestSet) (Upload, error) {
+	req := &chapv1.CompleteMultipartUploadRequest{
+		UploaderId: mp.UploaderID,
+		Name:       mp.Name,
+		UploadId:   mp.UploadID,
+		Parts:      make([]*chapv1.CompleteMultipartUploadRequest_Part, len(parts)),
+		Digests:    digests,
+	}
+	for i, p := range parts {
+		req.Parts[i] = &chapv1.CompleteMultipartUploadRequest_Part{
+			Number: p.Number,
+			Etag:   p.ETag,
+		}
+	}
+	resp, err := cli.commit.CompleteMultipartUpload(ctx, connect.NewRequest(req))
+	if err != nil {
+		return Upload{}, err
+	}
+	return Upload{
+		Size:    resp.Msg.Size,
+		Digests: resp.Msg.Digests,
+	}, nil
+}
+
+// AbortMultipartUpload cancels the multipart upload.
+func (cli Client) AbortMultipartUpload(ctx context.Context, mp *MultipartUpload) error {
+	req := &chapv1.AbortMultipartUploadRequest{
+		UploaderId: mp.UploaderID,
+		Name:       mp.Name,
+		UploadId:   mp.UploadID,
+	}
+	_, err := cli.commit.AbortMultipartUpload(ctx, connect.NewRequest(req))
+	return err
+}
+
+// UploadMultipart uploads size bytes from r to the uploader using a multipart
+// upload with parts of partSize bytes. If a part can't be uploaded, the
+// multipart upload is aborted.
+func (cli Client) UploadMultipart(ctx context.Context, uploaderID string, r io.Reader, size int64, partSize int64, digests ocfl.DigestSet) (result Upload, err error) {
+	if partSize < 1 {
+		return result, errors.New("part size must be positive")
+	}
+	count := int((size + partSize - 1) / partSize)
+	if count == 0 {
+		count = 1
+	}
+	mp, err := cli.NewMultipartUpload(ctx, uploaderID, size, count, 0)
+	if err != nil {
+		return result, err
+	}
+	parts := make([]MultipartPart, count)
+	for i := range parts {
+		n := min(partSize, size-int64(i)*partSize)
+		parts[i], err = cli.UploadPart(ctx, mp, int32(i+1), r, n)
+		if err != nil {
+			if abortErr := cli.AbortMultipartUpload(context.WithoutCancel(ctx), mp); abortErr != nil {
+				err = errors.Join(err, abortErr)
+			}
+			return result, err
+		}
+	}
+	return cli.CompleteMultipartUpload(ctx, mp, parts, digests)
+}
+
 // // UploadStage uploads content files in stage that are used in the stage's state. Content for digests
 // // already present in the uploader's Digests list are not uploaded
 // func (cli Client) UploadStage(ctx context.Context, up *Uploader, stage *Stage, excludeDigests ...string) error {
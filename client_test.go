@@ -2,6 +2,7 @@ package chaparral_test
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"path/filepath"
@@ -178,3 +179,27 @@ func TestClientCommit(t *testing.T) {
 	}
 	testutil.RunServiceTest(t, testFn)
 }
+
+func TestReprDigest(t *testing.T) {
+	digests := ocfl.DigestSet{
+		ocfl.SHA256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		ocfl.MD5:    "098f6bcd4621d373cade4e832627b4f6",
+		ocfl.BLAKE2B: "a71079d42853dea26e453004338670a53814b78137ffbed07603a41d76a483aa" +
+			"9bc33b582f77d30a65e6f29a896c0411f38312e1d66e0bf16386c86a89bea572",
+	}
+	val, err := chap.FormatReprDigest(digests)
+	be.NilErr(t, err)
+	be.Equal(t, "md5=:CY9rzUYh03PK3k6DJie09g==:, sha-256=:n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=:", val)
+	parsed, err := chap.ParseReprDigest(val + ", unknown=:AAAA:;param=1")
+	be.NilErr(t, err)
+	be.DeepEqual(t, ocfl.DigestSet{ocfl.SHA256: digests[ocfl.SHA256], ocfl.MD5: digests[ocfl.MD5]}, parsed)
+	parsed, err = chap.ParseDigest("SHA-256=n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=, UNIXsum=30637")
+	be.NilErr(t, err)
+	be.DeepEqual(t, ocfl.DigestSet{ocfl.SHA256: digests[ocfl.SHA256]}, parsed)
+	for _, invalid := range []string{"sha-256=n4bQ", "sha-256=:not base64:"} {
+		_, err = chap.ParseReprDigest(invalid)
+		be.True(t, errors.Is(err, chap.ErrDigestField))
+	}
+	_, err = chap.ParseDigest("sha-256")
+	be.True(t, errors.Is(err, chap.ErrDigestField))
+}
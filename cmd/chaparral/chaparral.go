@@ -13,6 +13,7 @@ import (
 
 var (
 	configFile = flag.String("c", "", "config file")
+	reindex    = flag.Bool("reindex", false, "rebuild the object cache for all storage roots before starting the server")
 )
 
 func main() {
@@ -32,6 +33,9 @@ func main() {
 		fmt.Fprintf(os.Stderr, "config error: %v\n", err)
 		os.Exit(1)
 	}
+	if *reindex {
+		conf.Reindex = true
+	}
 	if err := run.Run(ctx, &conf); err != nil {
 		fmt.Fprintf(os.Stderr, "server error: %v\n", err)
 		os.Exit(1)
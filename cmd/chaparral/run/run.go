@@ -8,6 +8,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
+	"expvar"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -15,16 +16,20 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/httplog/v2"
 	"github.com/srerickson/chaparral"
 	"github.com/srerickson/chaparral/server"
+	"github.com/srerickson/chaparral/server/audit"
 	"github.com/srerickson/chaparral/server/backend"
 	"github.com/srerickson/chaparral/server/chapdb"
+	"github.com/srerickson/chaparral/server/eventlog"
 	"github.com/srerickson/chaparral/server/store"
 	"github.com/srerickson/chaparral/server/uploader"
+	"github.com/srerickson/chaparral/server/webhook"
 	"github.com/srerickson/ocfl-go"
 	"golang.org/x/crypto/acme/autocert"
 	"golang.org/x/net/http2"
@@ -34,18 +39,29 @@ import (
 const healthCheck = "/alive"
 
 type Config struct {
-	Backend     string                 `fig:"backend" default:"file://."`
-	Roots       []Root                 `fig:"roots"`
-	Uploads     string                 `fig:"uploads"`
-	Listen      string                 `fig:"listen" default:":8080"`
-	DB          string                 `fig:"db" default:"/tmp/chaparral.sqlite3"`
-	PubkeyFile  string                 `fig:"pubkey_file"`
-	Pubkey      string                 `fig:"pubkey"`
-	AutoCert    *AutoCertConfig        `fix:"autocert"`
-	TLSCert     string                 `fig:"tls_cert"`
-	TLSKey      string                 `fig:"tls_key"`
-	Debug       bool                   `fig:"debug"`
-	Permissions server.RolePermissions `fig:"permissions"`
+	Backend       string                 `fig:"backend" default:"file://."`
+	Roots         []Root                 `fig:"roots"`
+	Uploads       string                 `fig:"uploads"`
+	DirectUploads bool                   `fig:"direct_uploads"` // multipart uploads to S3 with presigned URLs
+	Listen        string                 `fig:"listen" default:":8080"`
+	DB            string                 `fig:"db" default:"/tmp/chaparral.sqlite3"` // sqlite3 file, postgres URL, or "memory"
+	PubkeyFile    string                 `fig:"pubkey_file"`
+	Pubkey        string                 `fig:"pubkey"`
+	AutoCert      *AutoCertConfig        `fix:"autocert"`
+	TLSCert       string                 `fig:"tls_cert"`
+	TLSKey        string                 `fig:"tls_key"`
+	Debug         bool                   `fig:"debug"`
+	DebugListen   string                 `fig:"debug_listen"` // address:port for metrics; not served if empty
+	Permissions   server.RolePermissions `fig:"permissions"`
+	Audit         *AuditConfig           `fig:"audit"`
+	Reindex       bool                   `fig:"reindex"`
+	DownloadKey   string                 `fig:"download_key"`
+	Quotas        *QuotasConfig          `fig:"quotas"`
+	RateLimits    *RateLimitsConfig      `fig:"rate_limits"`
+	Webhooks      *WebhooksConfig        `fig:"webhooks"`
+	// EventRetention is how long events are kept for WatchEvents. Zero keeps
+	// events forever.
+	EventRetention time.Duration `fig:"event_retention" default:"720h"`
 }
 
 func (c *Config) tlsConfig() (*tls.Config, error) {
@@ -78,15 +94,193 @@ type AutoCertConfig struct {
 	Dir    string `fig:"dir"`
 }
 
+// AuditConfig enables periodic fixity audits of the storage roots.
+type AuditConfig struct {
+	Interval time.Duration `fig:"interval" default:"24h"`
+	Rate     float64       `fig:"rate"`
+}
+
+// QuotasConfig configures upload quotas for users (see server.Quotas).
+type QuotasConfig struct {
+	Default QuotaConfig            `fig:"default"`
+	Roles   map[string]QuotaConfig `fig:"roles"`
+	Users   map[string]QuotaConfig `fig:"users"`
+}
+
+type QuotaConfig struct {
+	MaxBytes         int64 `fig:"max_bytes"`
+	MaxFiles         int   `fig:"max_files"`
+	MaxUploaderBytes int64 `fig:"max_uploader_bytes"`
+	MaxUploaderFiles int   `fig:"max_uploader_files"`
+}
+
+func (c QuotasConfig) quotas() server.Quotas {
+	quotas := server.Quotas{
+		Default: c.Default.quota(),
+		Roles:   make(map[string]uploader.Quota, len(c.Roles)),
+		Users:   make(map[string]uploader.Quota, len(c.Users)),
+	}
+	for role, q := range c.Roles {
+		quotas.Roles[role] = q.quota()
+	}
+	for user, q := range c.Users {
+		quotas.Users[user] = q.quota()
+	}
+	return quotas
+}
+
+func (c QuotaConfig) quota() uploader.Quota {
+	return uploader.Quota{
+		MaxBytes:         c.MaxBytes,
+		MaxFiles:         c.MaxFiles,
+		MaxUploaderBytes: c.MaxUploaderBytes,
+		MaxUploaderFiles: c.MaxUploaderFiles,
+	}
+}
+
+// RateLimitsConfig configures per-user rate limits (see server.RateLimits).
+type RateLimitsConfig struct {
+	Requests  RateLimitConfig `fig:"requests"`
+	Downloads RateLimitConfig `fig:"downloads"`
+	Uploads   RateLimitConfig `fig:"uploads"`
+	Commits   RateLimitConfig `fig:"commits"`
+}
+
+type RateLimitConfig struct {
+	Rate       float64 `fig:"rate"`
+	Burst      int     `fig:"burst"`
+	Concurrent int     `fig:"concurrent"`
+}
+
+func (c RateLimitsConfig) rateLimits() server.RateLimits {
+	return server.RateLimits{
+		Requests:  server.RateLimit(c.Requests),
+		Downloads: server.RateLimit(c.Downloads),
+		Uploads:   server.RateLimit(c.Uploads),
+		Commits:   server.RateLimit(c.Commits),
+	}
+}
+
+// WebhooksConfig configures event notifications sent to webhook endpoints
+// (see webhook.Config). Zero values use the defaults.
+type WebhooksConfig struct {
+	Endpoints    []WebhookEndpoint `fig:"endpoints"`
+	MaxAttempts  int               `fig:"max_attempts"`
+	MinBackoff   time.Duration     `fig:"min_backoff"`
+	MaxBackoff   time.Duration     `fig:"max_backoff"`
+	PollInterval time.Duration     `fig:"poll_interval"`
+	Timeout      time.Duration     `fig:"timeout"`
+	Concurrency  int               `fig:"concurrency"`
+}
+
+type WebhookEndpoint struct {
+	ID     string `fig:"id" validate:"required"`
+	URL    string `fig:"url" validate:"required"`
+	Secret string `fig:"secret" validate:"required"`
+	// Events lists the event types sent to the endpoint. If empty, all
+	// events are sent.
+	Events []string `fig:"events"`
+}
+
+// notifierConfig returns the webhook.Config for the webhooks config.
+func (c WebhooksConfig) notifierConfig() (webhook.Config, error) {
+	conf := webhook.Config{
+		MaxAttempts:  c.MaxAttempts,
+		MinBackoff:   c.MinBackoff,
+		MaxBackoff:   c.MaxBackoff,
+		PollInterval: c.PollInterval,
+		Timeout:      c.Timeout,
+		Concurrency:  c.Concurrency,
+	}
+	ids := map[string]bool{}
+	for _, e := range c.Endpoints {
+		if ids[e.ID] {
+			return conf, fmt.Errorf("duplicate webhook endpoint id: %q", e.ID)
+		}
+		ids[e.ID] = true
+		u, err := url.Parse(e.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return conf, fmt.Errorf("invalid URL for webhook endpoint %q: %q", e.ID, e.URL)
+		}
+		for _, event := range e.Events {
+			if !eventlog.ValidType(event) {
+				return conf, fmt.Errorf("invalid event type for webhook endpoint %q: %q", e.ID, event)
+			}
+		}
+		conf.Endpoints = append(conf.Endpoints, webhook.Endpoint{
+			ID:     e.ID,
+			URL:    e.URL,
+			Secret: e.Secret,
+			Events: e.Events,
+		})
+	}
+	return conf, nil
+}
+
 type Root struct {
 	ID   string `fig:"id"`
 	Path string `fig:"path" validate:"required"`
-	Init *struct {
+	// Revalidate sets how cached objects are revalidated: "never" (the
+	// default), "always", or a duration (e.g., "5m") for the maximum time
+	// between checks.
+	Revalidate string `fig:"revalidate"`
+	// MirrorTags enables mirroring of version tags to a file in each object's
+	// extensions directory.
+	MirrorTags bool `fig:"mirror_tags"`
+	// Commit limits for the storage root. Zero values are unlimited.
+	MaxCommitBytes  int64 `fig:"max_commit_bytes"`
+	MaxVersionFiles int   `fig:"max_version_files"`
+	MaxObjectBytes  int64 `fig:"max_object_bytes"`
+	// DigestAlgorithms lists the digest algorithms allowed for commits. If
+	// empty, sha512 and sha256 are allowed.
+	DigestAlgorithms []string `fig:"digest_algorithms"`
+	Init             *struct {
 		Layout      string `fig:"layout" default:"0002-flat-direct-storage-layout"`
 		Description string `fig:"description"`
 	} `fig:"init"`
 }
 
+// limitsOption returns the store.Option for the root's commit limits. It
+// returns nil if the root doesn't have limits.
+func (r Root) limitsOption() (store.Option, error) {
+	limits := store.Limits{
+		MaxCommitBytes:   r.MaxCommitBytes,
+		MaxVersionFiles:  r.MaxVersionFiles,
+		MaxObjectBytes:   r.MaxObjectBytes,
+		DigestAlgorithms: r.DigestAlgorithms,
+	}
+	if limits.MaxCommitBytes < 0 || limits.MaxVersionFiles < 0 || limits.MaxObjectBytes < 0 {
+		return nil, errors.New("limits must not be negative")
+	}
+	for _, alg := range limits.DigestAlgorithms {
+		if alg != ocfl.SHA512 && alg != ocfl.SHA256 {
+			return nil, fmt.Errorf("invalid digest algorithm: %q", alg)
+		}
+	}
+	if limits.MaxCommitBytes == 0 && limits.MaxVersionFiles == 0 &&
+		limits.MaxObjectBytes == 0 && len(limits.DigestAlgorithms) == 0 {
+		return nil, nil
+	}
+	return store.WithLimits(limits), nil
+}
+
+// revalidateOption returns the store.Option for the root's revalidation mode.
+// It returns nil if cached objects aren't revalidated.
+func (r Root) revalidateOption() (store.Option, error) {
+	switch r.Revalidate {
+	case "", "never":
+		return nil, nil
+	case "always":
+		return store.WithRevalidation(0), nil
+	default:
+		maxAge, err := time.ParseDuration(r.Revalidate)
+		if err != nil || maxAge < 0 {
+			return nil, fmt.Errorf("invalid revalidate value: %q", r.Revalidate)
+		}
+		return store.WithRevalidation(maxAge), nil
+	}
+}
+
 func Run(ctx context.Context, conf *Config) error {
 	var serviceOptions []server.Option
 
@@ -109,14 +303,13 @@ func Run(ctx context.Context, conf *Config) error {
 		"code_version", chaparral.CODE_VERSION,
 		"version", chaparral.VERSION)
 
-	// sqlite3 for server state
-	logger.Debug("opening database...", "config", conf.DB)
-	db, err := chapdb.Open("sqlite3", conf.DB, true)
+	// sqlite3 or postgres for server state
+	logger.Debug("opening database...", "config", redactDSN(conf.DB))
+	chapDB, err := chapdb.OpenDSN(conf.DB, true)
 	if err != nil {
 		return fmt.Errorf("loading database: %w", err)
 	}
-	defer db.Close()
-	chapDB := (*chapdb.SQLiteDB)(db)
+	defer chapDB.Close()
 
 	logger.Debug("initializing backend...", "config", conf.Backend)
 	fsys, err := newBackend(conf.Backend, logger.Logger)
@@ -133,11 +326,32 @@ func Run(ctx context.Context, conf *Config) error {
 				Layout:      rootConfig.Init.Layout,
 			}
 		}
+		rootOpts := []store.Option{store.WithVersionTags(chapDB)}
+		if rootConfig.MirrorTags {
+			rootOpts = append(rootOpts, store.WithTagMirroring())
+		}
+		revalidate, err := rootConfig.revalidateOption()
+		if err != nil {
+			return fmt.Errorf("storage root %q: %w", rootConfig.ID, err)
+		}
+		if revalidate != nil {
+			rootOpts = append(rootOpts, revalidate)
+		}
+		limits, err := rootConfig.limitsOption()
+		if err != nil {
+			return fmt.Errorf("storage root %q: %w", rootConfig.ID, err)
+		}
+		if limits != nil {
+			rootOpts = append(rootOpts, limits)
+		}
 		logger.Debug("using storage root",
 			"id", rootConfig.ID,
 			"path", rootConfig.Path,
-			"initialize", init != nil)
-		r := store.NewStorageRoot(rootConfig.ID, fsys, rootConfig.Path, init, chapDB)
+			"initialize", init != nil,
+			"revalidate", rootConfig.Revalidate,
+			"mirror_tags", rootConfig.MirrorTags,
+			"limits", limits != nil)
+		r := store.NewStorageRoot(rootConfig.ID, fsys, rootConfig.Path, init, chapDB, rootOpts...)
 		roots = append(roots, r)
 		rootPaths = append(rootPaths, rootConfig.Path)
 	}
@@ -149,7 +363,22 @@ func Run(ctx context.Context, conf *Config) error {
 
 	// upload manager is required for allowing uploads
 	if conf.Uploads != "" {
-		mgr := uploader.NewManager(fsys, conf.Uploads, chapDB)
+		var mgrOpts []uploader.Option
+		if conf.DirectUploads {
+			mp, err := uploader.NewS3Multipart(fsys)
+			if err != nil {
+				return fmt.Errorf("direct uploads require an S3 backend: %w", err)
+			}
+			mgrOpts = append(mgrOpts, uploader.WithMultipart(mp))
+			logger.Debug("direct multipart uploads are enabled")
+		}
+		if conf.Quotas != nil {
+			if quotas := conf.Quotas.quotas(); !quotas.Empty() {
+				mgrOpts = append(mgrOpts, uploader.WithQuotas(quotas.QuotaFunc()))
+				logger.Debug("upload quotas are enabled")
+			}
+		}
+		mgr := uploader.NewManager(fsys, conf.Uploads, chapDB, mgrOpts...)
 		rootPaths = append(rootPaths, conf.Uploads)
 		serviceOptions = append(serviceOptions, server.WithUploaderManager(mgr))
 		logger.Debug("uploads are enabled", "config", conf.Uploads)
@@ -159,6 +388,78 @@ func Run(ctx context.Context, conf *Config) error {
 		return fmt.Errorf("storage root and uploader paths have conflicts: %s", strings.Join(rootPaths, ", "))
 	}
 
+	// rebuild object cache
+	if conf.Reindex {
+		for _, root := range roots {
+			if err := reindexRoot(ctx, root, logger.Logger); err != nil {
+				return err
+			}
+		}
+	}
+
+	// background tasks (audits, event log cleanup, and webhook delivery) use
+	// the database and must stop before it's closed.
+	var background sync.WaitGroup
+
+	// periodic fixity audits
+	auditCtx, cancelAudit := context.WithCancel(ctx)
+	defer cancelAudit()
+	if conf.Audit != nil {
+		auditor := audit.NewAuditor(chapDB, audit.Config{
+			Interval: conf.Audit.Interval,
+			Rate:     conf.Audit.Rate,
+			Logger:   logger.Logger,
+		}, roots...)
+		serviceOptions = append(serviceOptions, server.WithAuditor(auditor))
+		logger.Debug("fixity audits are enabled", "interval", conf.Audit.Interval, "rate", conf.Audit.Rate)
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			if err := auditor.Run(auditCtx); err != nil && !errors.Is(err, context.Canceled) {
+				logger.Error("fixity audits stopped: " + err.Error())
+			}
+		}()
+	}
+
+	// event log for WatchEvents
+	eventsCtx, cancelEvents := context.WithCancel(ctx)
+	defer cancelEvents()
+	events := eventlog.NewLog(chapDB, eventlog.Config{
+		Retention: conf.EventRetention,
+		Logger:    logger.Logger,
+	})
+	serviceOptions = append(serviceOptions, server.WithEventLog(events))
+	if conf.EventRetention > 0 {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			if err := events.Run(eventsCtx); err != nil && !errors.Is(err, context.Canceled) {
+				logger.Error("event log cleanup stopped: " + err.Error())
+			}
+		}()
+	}
+
+	// webhook event notifications
+	webhookCtx, cancelWebhooks := context.WithCancel(ctx)
+	defer cancelWebhooks()
+	if conf.Webhooks != nil {
+		notifierConf, err := conf.Webhooks.notifierConfig()
+		if err != nil {
+			return fmt.Errorf("in webhooks config: %w", err)
+		}
+		notifierConf.Logger = logger.Logger
+		notifier := webhook.NewNotifier(chapDB, notifierConf)
+		serviceOptions = append(serviceOptions, server.WithNotifier(notifier))
+		logger.Debug("webhooks are enabled", "endpoints", len(notifierConf.Endpoints))
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			if err := notifier.Run(webhookCtx); err != nil && !errors.Is(err, context.Canceled) {
+				logger.Error("webhook delivery stopped: " + err.Error())
+			}
+		}()
+	}
+
 	// authentication config (load RSA key used in JWS signing)
 	if conf.Pubkey != "" || conf.PubkeyFile != "" {
 		pubkey, err := getPubkey([]byte(conf.Pubkey), conf.PubkeyFile)
@@ -173,6 +474,24 @@ func Run(ctx context.Context, conf *Config) error {
 		serviceOptions = append(serviceOptions, server.WithAuthUserFunc(authFunc))
 	}
 
+	// signing key for download URLs
+	downloadKey := []byte(conf.DownloadKey)
+	if len(downloadKey) == 0 {
+		downloadKey = make([]byte, 32)
+		if _, err := rand.Read(downloadKey); err != nil {
+			return fmt.Errorf("generating download URL key: %w", err)
+		}
+		logger.Debug("using a random key for signed download URLs; URLs will be invalid after restart")
+	}
+	serviceOptions = append(serviceOptions, server.WithDownloadKey(downloadKey))
+
+	// per-user rate limits
+	if conf.RateLimits != nil {
+		limits := conf.RateLimits.rateLimits()
+		serviceOptions = append(serviceOptions, server.WithMiddleware(server.RateLimitMiddleware(limits)))
+		logger.Debug("rate limits are enabled", "rate_limits", limits)
+	}
+
 	// role definitions
 	roles := conf.Permissions
 	if conf.Permissions.Empty() {
@@ -187,7 +506,6 @@ func Run(ctx context.Context, conf *Config) error {
 	mux.Handle(healthCheck, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprint(w, "OK")
 	}))
-
 	// grpc reflection endpoint
 	// reflector := grpcreflect.NewStaticReflector(server.AccessServiceName, server.CommitServiceName)
 	// mux.Mount(grpcreflect.NewHandlerV1(reflector))
@@ -198,21 +516,44 @@ func Run(ctx context.Context, conf *Config) error {
 		return fmt.Errorf("TLS config errors: %w", err)
 	}
 
+	// metrics are served on a separate listener so they aren't public
+	var debugSrv *http.Server
+	if conf.DebugListen != "" {
+		debugMux := http.NewServeMux()
+		debugMux.Handle("/debug/vars", expvar.Handler())
+		debugSrv = &http.Server{Addr: conf.DebugListen, Handler: debugMux}
+		go func() {
+			logger.Info("starting debug server", "listen", conf.DebugListen)
+			if err := debugSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("debug server stopped: " + err.Error())
+			}
+		}()
+	}
+
 	// handle shutdown
 	c := make(chan os.Signal, 2)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	shutdownDone := make(chan struct{})
 	go func() {
+		defer close(shutdownDone)
 		<-c
 		ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
 		defer cancel()
 		logger.Info("shutting down ...", "deadline", "2 mins")
+		cancelAudit()
+		cancelEvents()
+		cancelWebhooks()
+		if debugSrv != nil {
+			debugSrv.Close()
+		}
 		if err := httpSrv.Shutdown(ctx); err != nil {
 			if errors.Is(context.DeadlineExceeded, err) {
 				logger.Error("server didn't shutdown gracefully")
 				httpSrv.Close()
 			}
 		}
-		if err := db.Close(); err != nil {
+		background.Wait()
+		if err := chapDB.Close(); err != nil {
 			logger.Error("shutting down database: " + err.Error())
 		}
 	}()
@@ -229,11 +570,52 @@ func Run(ctx context.Context, conf *Config) error {
 		srvErr = httpSrv.ListenAndServe()
 	}
 	if errors.Is(http.ErrServerClosed, srvErr) {
+		// wait for background tasks to stop
+		<-shutdownDone
 		srvErr = nil
 	}
 	return srvErr
 }
 
+// reindexProgressInterval is the number of objects between progress messages
+// during reindex
+const reindexProgressInterval = 1000
+
+func reindexRoot(ctx context.Context, root *store.StorageRoot, logger *slog.Logger) error {
+	logger = logger.With("storage_root", root.ID())
+	logger.Info("reindexing storage root ...")
+	progress := func(objPath string, err error, stats store.ReindexStats) {
+		if err != nil {
+			logger.Warn("reindex: couldn't read object", "object_path", objPath, "err", err.Error())
+		}
+		if n := stats.Indexed + stats.Failed + stats.Skipped; n%reindexProgressInterval == 0 {
+			logger.Info("reindex progress", "indexed", stats.Indexed, "failed", stats.Failed, "skipped", stats.Skipped)
+		}
+	}
+	stats, err := root.Reindex(ctx, progress)
+	if err != nil {
+		return fmt.Errorf("reindexing storage root %q: %w", root.ID(), err)
+	}
+	logger.Info("reindex complete",
+		"indexed", stats.Indexed,
+		"failed", stats.Failed,
+		"skipped", stats.Skipped,
+		"removed", stats.Removed)
+	return nil
+}
+
+// redactDSN removes the password from PostgreSQL connection URLs
+func redactDSN(dsn string) string {
+	if !chapdb.IsPostgresDSN(dsn) {
+		return dsn
+	}
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "postgres://[invalid]"
+	}
+	return u.Redacted()
+}
+
 func newBackend(storage string, logger *slog.Logger) (ocfl.WriteFS, error) {
 	var b interface {
 		IsAccessible() (bool, error)
@@ -0,0 +1,112 @@
+package chaparral
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/srerickson/ocfl-go"
+)
+
+const (
+	// HeaderReprDigest is the HTTP field for representation digests (RFC
+	// 9530). It may be sent as a header or a trailer with uploads.
+	HeaderReprDigest = "Repr-Digest"
+	// HeaderDigest is the HTTP field for instance digests (RFC 3230), which
+	// RFC 9530 obsoletes.
+	HeaderDigest = "Digest"
+)
+
+// ErrDigestField is returned when parsing an invalid digest field value.
+var ErrDigestField = errors.New("invalid digest field")
+
+// httpDigestAlgs maps hash algorithm keys from the IANA HTTP digest algorithm
+// registry to OCFL digest algorithm names.
+var httpDigestAlgs = map[string]string{
+	"sha-256": ocfl.SHA256,
+	"sha-512": ocfl.SHA512,
+	"md5":     ocfl.MD5,
+	"sha":     ocfl.SHA1,
+}
+
+// FormatReprDigest returns a Repr-Digest field value for the digests.
+// Algorithms without an HTTP digest algorithm key are skipped.
+func FormatReprDigest(digests ocfl.DigestSet) (string, error) {
+	var members []string
+	for key, alg := range httpDigestAlgs {
+		val, ok := digests[alg]
+		if !ok {
+			continue
+		}
+		byts, err := hex.DecodeString(val)
+		if err != nil {
+			return "", fmt.Errorf("%s digest: %w", alg, err)
+		}
+		members = append(members, key+"=:"+base64.StdEncoding.EncodeToString(byts)+":")
+	}
+	sort.Strings(members)
+	return strings.Join(members, ", "), nil
+}
+
+// ParseReprDigest parses a Repr-Digest (or Content-Digest) field value,
+// returning digests keyed by OCFL digest algorithm names. Members with
+// unrecognized algorithms are ignored.
+func ParseReprDigest(val string) (ocfl.DigestSet, error) {
+	digests := ocfl.DigestSet{}
+	for _, member := range splitFieldList(val) {
+		key, byteSeq, _ := strings.Cut(member, "=")
+		// ignore parameters
+		byteSeq, _, _ = strings.Cut(byteSeq, ";")
+		byteSeq = strings.TrimSpace(byteSeq)
+		if len(byteSeq) < 2 || byteSeq[0] != ':' || byteSeq[len(byteSeq)-1] != ':' {
+			return nil, fmt.Errorf("%w: %q", ErrDigestField, member)
+		}
+		if err := addFieldDigest(digests, strings.TrimSpace(key), byteSeq[1:len(byteSeq)-1]); err != nil {
+			return nil, err
+		}
+	}
+	return digests, nil
+}
+
+// ParseDigest parses an RFC 3230 Digest field value, returning digests keyed
+// by OCFL digest algorithm names. Members with unrecognized algorithms are
+// ignored.
+func ParseDigest(val string) (ocfl.DigestSet, error) {
+	digests := ocfl.DigestSet{}
+	for _, member := range splitFieldList(val) {
+		key, encoded, found := strings.Cut(member, "=")
+		if !found {
+			return nil, fmt.Errorf("%w: %q", ErrDigestField, member)
+		}
+		if err := addFieldDigest(digests, strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(encoded)); err != nil {
+			return nil, err
+		}
+	}
+	return digests, nil
+}
+
+func splitFieldList(val string) []string {
+	var members []string
+	for _, member := range strings.Split(val, ",") {
+		if member = strings.TrimSpace(member); member != "" {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+func addFieldDigest(digests ocfl.DigestSet, key string, encoded string) error {
+	alg, ok := httpDigestAlgs[key]
+	if !ok {
+		return nil
+	}
+	byts, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrDigestField, key, err)
+	}
+	digests[alg] = hex.EncodeToString(byts)
+	return nil
+}
@@ -39,6 +39,38 @@ const (
 	// AccessServiceGetObjectManifestProcedure is the fully-qualified name of the AccessService's
 	// GetObjectManifest RPC.
 	AccessServiceGetObjectManifestProcedure = "/chaparral.v1.AccessService/GetObjectManifest"
+	// AccessServiceListObjectManifestProcedure is the fully-qualified name of the AccessService's
+	// ListObjectManifest RPC.
+	AccessServiceListObjectManifestProcedure = "/chaparral.v1.AccessService/ListObjectManifest"
+	// AccessServiceListVersionStateProcedure is the fully-qualified name of the AccessService's
+	// ListVersionState RPC.
+	AccessServiceListVersionStateProcedure = "/chaparral.v1.AccessService/ListVersionState"
+	// AccessServiceListVersionPathProcedure is the fully-qualified name of the AccessService's
+	// ListVersionPath RPC.
+	AccessServiceListVersionPathProcedure = "/chaparral.v1.AccessService/ListVersionPath"
+	// AccessServiceGetObjectMetadataProcedure is the fully-qualified name of the AccessService's
+	// GetObjectMetadata RPC.
+	AccessServiceGetObjectMetadataProcedure = "/chaparral.v1.AccessService/GetObjectMetadata"
+	// AccessServiceListObjectsProcedure is the fully-qualified name of the AccessService's ListObjects
+	// RPC.
+	AccessServiceListObjectsProcedure = "/chaparral.v1.AccessService/ListObjects"
+	// AccessServiceSearchObjectsProcedure is the fully-qualified name of the AccessService's
+	// SearchObjects RPC.
+	AccessServiceSearchObjectsProcedure = "/chaparral.v1.AccessService/SearchObjects"
+	// AccessServiceFindContentByDigestProcedure is the fully-qualified name of the AccessService's
+	// FindContentByDigest RPC.
+	AccessServiceFindContentByDigestProcedure = "/chaparral.v1.AccessService/FindContentByDigest"
+	// AccessServiceListTagsProcedure is the fully-qualified name of the AccessService's ListTags RPC.
+	AccessServiceListTagsProcedure = "/chaparral.v1.AccessService/ListTags"
+	// AccessServiceCreateDownloadURLProcedure is the fully-qualified name of the AccessService's
+	// CreateDownloadURL RPC.
+	AccessServiceCreateDownloadURLProcedure = "/chaparral.v1.AccessService/CreateDownloadURL"
+	// AccessServiceWatchEventsProcedure is the fully-qualified name of the AccessService's WatchEvents
+	// RPC.
+	AccessServiceWatchEventsProcedure = "/chaparral.v1.AccessService/WatchEvents"
+	// AccessServiceGetContentProcedure is the fully-qualified name of the AccessService's GetContent
+	// RPC.
+	AccessServiceGetContentProcedure = "/chaparral.v1.AccessService/GetContent"
 )
 
 // AccessServiceClient is a client for the chaparral.v1.AccessService service.
@@ -49,6 +81,45 @@ type AccessServiceClient interface {
 	// GetObjectManifest returns digests, sizes, and fixity information for all
 	// content associated with an object across all its versions.
 	GetObjectManifest(context.Context, *connect_go.Request[v1.GetObjectManifestRequest]) (*connect_go.Response[v1.GetObjectManifestResponse], error)
+	// ListObjectManifest returns an object's manifest in pages, sorted by
+	// digest. It is an alternative to GetObjectManifest for objects with
+	// too many files to return in one message.
+	ListObjectManifest(context.Context, *connect_go.Request[v1.ListObjectManifestRequest]) (*connect_go.Response[v1.ListObjectManifestResponse], error)
+	// ListVersionState returns the logical paths, digests, and sizes in an
+	// object version's state in pages, sorted by path. It is an alternative to
+	// GetObjectVersion for objects with too many files to return in one
+	// message.
+	ListVersionState(context.Context, *connect_go.Request[v1.ListVersionStateRequest]) (*connect_go.Response[v1.ListVersionStateResponse], error)
+	// ListVersionPath returns the files and subdirectories in a directory of
+	// an object version's logical state, with their sizes. Paths are from the
+	// requested version's state, and sizes are looked up by content digest in
+	// the object's manifest. Directories with more than 10000 entries aren't
+	// listed (the error code is RESOURCE_EXHAUSTED): use ListVersionState
+	// with a path prefix instead.
+	ListVersionPath(context.Context, *connect_go.Request[v1.ListVersionPathRequest]) (*connect_go.Response[v1.ListVersionPathResponse], error)
+	// GetObjectMetadata returns descriptive metadata from an object's most
+	// recent version.
+	GetObjectMetadata(context.Context, *connect_go.Request[v1.GetObjectMetadataRequest]) (*connect_go.Response[v1.GetObjectMetadataResponse], error)
+	// ListObjects returns a list of indexed objects in a storage root with
+	// their metadata.
+	ListObjects(context.Context, *connect_go.Request[v1.ListObjectsRequest]) (*connect_go.Response[v1.ListObjectsResponse], error)
+	// SearchObjects returns indexed objects in a storage root that match a
+	// text query or include content with a given digest.
+	SearchObjects(context.Context, *connect_go.Request[v1.SearchObjectsRequest]) (*connect_go.Response[v1.SearchObjectsResponse], error)
+	// FindContentByDigest returns the objects, versions, and logical paths in
+	// a storage root that include content with a given digest.
+	FindContentByDigest(context.Context, *connect_go.Request[v1.FindContentByDigestRequest]) (*connect_go.Response[v1.FindContentByDigestResponse], error)
+	// ListTags returns an object's version tags.
+	ListTags(context.Context, *connect_go.Request[v1.ListTagsRequest]) (*connect_go.Response[v1.ListTagsResponse], error)
+	// CreateDownloadURL returns a time-limited URL for downloading a file
+	// without an authorization token.
+	CreateDownloadURL(context.Context, *connect_go.Request[v1.CreateDownloadURLRequest]) (*connect_go.Response[v1.CreateDownloadURLResponse], error)
+	// WatchEvents streams events for objects that are committed or deleted.
+	// Only events for objects the user can read are sent.
+	WatchEvents(context.Context, *connect_go.Request[v1.WatchEventsRequest]) (*connect_go.ServerStreamForClient[v1.WatchEventsResponse], error)
+	// GetContent streams the contents of a file in an object. The first
+	// message includes the file's size and digest.
+	GetContent(context.Context, *connect_go.Request[v1.GetContentRequest]) (*connect_go.ServerStreamForClient[v1.GetContentResponse], error)
 }
 
 // NewAccessServiceClient constructs a client for the chaparral.v1.AccessService service. By
@@ -71,13 +142,79 @@ func NewAccessServiceClient(httpClient connect_go.HTTPClient, baseURL string, op
 			baseURL+AccessServiceGetObjectManifestProcedure,
 			opts...,
 		),
+		listObjectManifest: connect_go.NewClient[v1.ListObjectManifestRequest, v1.ListObjectManifestResponse](
+			httpClient,
+			baseURL+AccessServiceListObjectManifestProcedure,
+			opts...,
+		),
+		listVersionState: connect_go.NewClient[v1.ListVersionStateRequest, v1.ListVersionStateResponse](
+			httpClient,
+			baseURL+AccessServiceListVersionStateProcedure,
+			opts...,
+		),
+		listVersionPath: connect_go.NewClient[v1.ListVersionPathRequest, v1.ListVersionPathResponse](
+			httpClient,
+			baseURL+AccessServiceListVersionPathProcedure,
+			opts...,
+		),
+		getObjectMetadata: connect_go.NewClient[v1.GetObjectMetadataRequest, v1.GetObjectMetadataResponse](
+			httpClient,
+			baseURL+AccessServiceGetObjectMetadataProcedure,
+			opts...,
+		),
+		listObjects: connect_go.NewClient[v1.ListObjectsRequest, v1.ListObjectsResponse](
+			httpClient,
+			baseURL+AccessServiceListObjectsProcedure,
+			opts...,
+		),
+		searchObjects: connect_go.NewClient[v1.SearchObjectsRequest, v1.SearchObjectsResponse](
+			httpClient,
+			baseURL+AccessServiceSearchObjectsProcedure,
+			opts...,
+		),
+		findContentByDigest: connect_go.NewClient[v1.FindContentByDigestRequest, v1.FindContentByDigestResponse](
+			httpClient,
+			baseURL+AccessServiceFindContentByDigestProcedure,
+			opts...,
+		),
+		listTags: connect_go.NewClient[v1.ListTagsRequest, v1.ListTagsResponse](
+			httpClient,
+			baseURL+AccessServiceListTagsProcedure,
+			opts...,
+		),
+		createDownloadURL: connect_go.NewClient[v1.CreateDownloadURLRequest, v1.CreateDownloadURLResponse](
+			httpClient,
+			baseURL+AccessServiceCreateDownloadURLProcedure,
+			opts...,
+		),
+		watchEvents: connect_go.NewClient[v1.WatchEventsRequest, v1.WatchEventsResponse](
+			httpClient,
+			baseURL+AccessServiceWatchEventsProcedure,
+			opts...,
+		),
+		getContent: connect_go.NewClient[v1.GetContentRequest, v1.GetContentResponse](
+			httpClient,
+			baseURL+AccessServiceGetContentProcedure,
+			opts...,
+		),
 	}
 }
 
 // accessServiceClient implements AccessServiceClient.
 type accessServiceClient struct {
-	getObjectVersion  *connect_go.Client[v1.GetObjectVersionRequest, v1.GetObjectVersionResponse]
-	getObjectManifest *connect_go.Client[v1.GetObjectManifestRequest, v1.GetObjectManifestResponse]
+	getObjectVersion    *connect_go.Client[v1.GetObjectVersionRequest, v1.GetObjectVersionResponse]
+	getObjectManifest   *connect_go.Client[v1.GetObjectManifestRequest, v1.GetObjectManifestResponse]
+	listObjectManifest  *connect_go.Client[v1.ListObjectManifestRequest, v1.ListObjectManifestResponse]
+	listVersionState    *connect_go.Client[v1.ListVersionStateRequest, v1.ListVersionStateResponse]
+	listVersionPath     *connect_go.Client[v1.ListVersionPathRequest, v1.ListVersionPathResponse]
+	getObjectMetadata   *connect_go.Client[v1.GetObjectMetadataRequest, v1.GetObjectMetadataResponse]
+	listObjects         *connect_go.Client[v1.ListObjectsRequest, v1.ListObjectsResponse]
+	searchObjects       *connect_go.Client[v1.SearchObjectsRequest, v1.SearchObjectsResponse]
+	findContentByDigest *connect_go.Client[v1.FindContentByDigestRequest, v1.FindContentByDigestResponse]
+	listTags            *connect_go.Client[v1.ListTagsRequest, v1.ListTagsResponse]
+	createDownloadURL   *connect_go.Client[v1.CreateDownloadURLRequest, v1.CreateDownloadURLResponse]
+	watchEvents         *connect_go.Client[v1.WatchEventsRequest, v1.WatchEventsResponse]
+	getContent          *connect_go.Client[v1.GetContentRequest, v1.GetContentResponse]
 }
 
 // GetObjectVersion calls chaparral.v1.AccessService.GetObjectVersion.
@@ -90,6 +227,61 @@ func (c *accessServiceClient) GetObjectManifest(ctx context.Context, req *connec
 	return c.getObjectManifest.CallUnary(ctx, req)
 }
 
+// ListObjectManifest calls chaparral.v1.AccessService.ListObjectManifest.
+func (c *accessServiceClient) ListObjectManifest(ctx context.Context, req *connect_go.Request[v1.ListObjectManifestRequest]) (*connect_go.Response[v1.ListObjectManifestResponse], error) {
+	return c.listObjectManifest.CallUnary(ctx, req)
+}
+
+// ListVersionState calls chaparral.v1.AccessService.ListVersionState.
+func (c *accessServiceClient) ListVersionState(ctx context.Context, req *connect_go.Request[v1.ListVersionStateRequest]) (*connect_go.Response[v1.ListVersionStateResponse], error) {
+	return c.listVersionState.CallUnary(ctx, req)
+}
+
+// ListVersionPath calls chaparral.v1.AccessService.ListVersionPath.
+func (c *accessServiceClient) ListVersionPath(ctx context.Context, req *connect_go.Request[v1.ListVersionPathRequest]) (*connect_go.Response[v1.ListVersionPathResponse], error) {
+	return c.listVersionPath.CallUnary(ctx, req)
+}
+
+// GetObjectMetadata calls chaparral.v1.AccessService.GetObjectMetadata.
+func (c *accessServiceClient) GetObjectMetadata(ctx context.Context, req *connect_go.Request[v1.GetObjectMetadataRequest]) (*connect_go.Response[v1.GetObjectMetadataResponse], error) {
+	return c.getObjectMetadata.CallUnary(ctx, req)
+}
+
+// ListObjects calls chaparral.v1.AccessService.ListObjects.
+func (c *accessServiceClient) ListObjects(ctx context.Context, req *connect_go.Request[v1.ListObjectsRequest]) (*connect_go.Response[v1.ListObjectsResponse], error) {
+	return c.listObjects.CallUnary(ctx, req)
+}
+
+// SearchObjects calls chaparral.v1.AccessService.SearchObjects.
+func (c *accessServiceClient) SearchObjects(ctx context.Context, req *connect_go.Request[v1.SearchObjectsRequest]) (*connect_go.Response[v1.SearchObjectsResponse], error) {
+	return c.searchObjects.CallUnary(ctx, req)
+}
+
+// FindContentByDigest calls chaparral.v1.AccessService.FindContentByDigest.
+func (c *accessServiceClient) FindContentByDigest(ctx context.Context, req *connect_go.Request[v1.FindContentByDigestRequest]) (*connect_go.Response[v1.FindContentByDigestResponse], error) {
+	return c.findContentByDigest.CallUnary(ctx, req)
+}
+
+// ListTags calls chaparral.v1.AccessService.ListTags.
+func (c *accessServiceClient) ListTags(ctx context.Context, req *connect_go.Request[v1.ListTagsRequest]) (*connect_go.Response[v1.ListTagsResponse], error) {
+	return c.listTags.CallUnary(ctx, req)
+}
+
+// CreateDownloadURL calls chaparral.v1.AccessService.CreateDownloadURL.
+func (c *accessServiceClient) CreateDownloadURL(ctx context.Context, req *connect_go.Request[v1.CreateDownloadURLRequest]) (*connect_go.Response[v1.CreateDownloadURLResponse], error) {
+	return c.createDownloadURL.CallUnary(ctx, req)
+}
+
+// WatchEvents calls chaparral.v1.AccessService.WatchEvents.
+func (c *accessServiceClient) WatchEvents(ctx context.Context, req *connect_go.Request[v1.WatchEventsRequest]) (*connect_go.ServerStreamForClient[v1.WatchEventsResponse], error) {
+	return c.watchEvents.CallServerStream(ctx, req)
+}
+
+// GetContent calls chaparral.v1.AccessService.GetContent.
+func (c *accessServiceClient) GetContent(ctx context.Context, req *connect_go.Request[v1.GetContentRequest]) (*connect_go.ServerStreamForClient[v1.GetContentResponse], error) {
+	return c.getContent.CallServerStream(ctx, req)
+}
+
 // AccessServiceHandler is an implementation of the chaparral.v1.AccessService service.
 type AccessServiceHandler interface {
 	// GetObjectVersion returns details about the logical state of an OCFL object
@@ -98,6 +290,45 @@ type AccessServiceHandler interface {
 	// GetObjectManifest returns digests, sizes, and fixity information for all
 	// content associated with an object across all its versions.
 	GetObjectManifest(context.Context, *connect_go.Request[v1.GetObjectManifestRequest]) (*connect_go.Response[v1.GetObjectManifestResponse], error)
+	// ListObjectManifest returns an object's manifest in pages, sorted by
+	// digest. It is an alternative to GetObjectManifest for objects with
+	// too many files to return in one message.
+	ListObjectManifest(context.Context, *connect_go.Request[v1.ListObjectManifestRequest]) (*connect_go.Response[v1.ListObjectManifestResponse], error)
+	// ListVersionState returns the logical paths, digests, and sizes in an
+	// object version's state in pages, sorted by path. It is an alternative to
+	// GetObjectVersion for objects with too many files to return in one
+	// message.
+	ListVersionState(context.Context, *connect_go.Request[v1.ListVersionStateRequest]) (*connect_go.Response[v1.ListVersionStateResponse], error)
+	// ListVersionPath returns the files and subdirectories in a directory of
+	// an object version's logical state, with their sizes. Paths are from the
+	// requested version's state, and sizes are looked up by content digest in
+	// the object's manifest. Directories with more than 10000 entries aren't
+	// listed (the error code is RESOURCE_EXHAUSTED): use ListVersionState
+	// with a path prefix instead.
+	ListVersionPath(context.Context, *connect_go.Request[v1.ListVersionPathRequest]) (*connect_go.Response[v1.ListVersionPathResponse], error)
+	// GetObjectMetadata returns descriptive metadata from an object's most
+	// recent version.
+	GetObjectMetadata(context.Context, *connect_go.Request[v1.GetObjectMetadataRequest]) (*connect_go.Response[v1.GetObjectMetadataResponse], error)
+	// ListObjects returns a list of indexed objects in a storage root with
+	// their metadata.
+	ListObjects(context.Context, *connect_go.Request[v1.ListObjectsRequest]) (*connect_go.Response[v1.ListObjectsResponse], error)
+	// SearchObjects returns indexed objects in a storage root that match a
+	// text query or include content with a given digest.
+	SearchObjects(context.Context, *connect_go.Request[v1.SearchObjectsRequest]) (*connect_go.Response[v1.SearchObjectsResponse], error)
+	// FindContentByDigest returns the objects, versions, and logical paths in
+	// a storage root that include content with a given digest.
+	FindContentByDigest(context.Context, *connect_go.Request[v1.FindContentByDigestRequest]) (*connect_go.Response[v1.FindContentByDigestResponse], error)
+	// ListTags returns an object's version tags.
+	ListTags(context.Context, *connect_go.Request[v1.ListTagsRequest]) (*connect_go.Response[v1.ListTagsResponse], error)
+	// CreateDownloadURL returns a time-limited URL for downloading a file
+	// without an authorization token.
+	CreateDownloadURL(context.Context, *connect_go.Request[v1.CreateDownloadURLRequest]) (*connect_go.Response[v1.CreateDownloadURLResponse], error)
+	// WatchEvents streams events for objects that are committed or deleted.
+	// Only events for objects the user can read are sent.
+	WatchEvents(context.Context, *connect_go.Request[v1.WatchEventsRequest], *connect_go.ServerStream[v1.WatchEventsResponse]) error
+	// GetContent streams the contents of a file in an object. The first
+	// message includes the file's size and digest.
+	GetContent(context.Context, *connect_go.Request[v1.GetContentRequest], *connect_go.ServerStream[v1.GetContentResponse]) error
 }
 
 // NewAccessServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -116,12 +347,89 @@ func NewAccessServiceHandler(svc AccessServiceHandler, opts ...connect_go.Handle
 		svc.GetObjectManifest,
 		opts...,
 	)
+	accessServiceListObjectManifestHandler := connect_go.NewUnaryHandler(
+		AccessServiceListObjectManifestProcedure,
+		svc.ListObjectManifest,
+		opts...,
+	)
+	accessServiceListVersionStateHandler := connect_go.NewUnaryHandler(
+		AccessServiceListVersionStateProcedure,
+		svc.ListVersionState,
+		opts...,
+	)
+	accessServiceListVersionPathHandler := connect_go.NewUnaryHandler(
+		AccessServiceListVersionPathProcedure,
+		svc.ListVersionPath,
+		opts...,
+	)
+	accessServiceGetObjectMetadataHandler := connect_go.NewUnaryHandler(
+		AccessServiceGetObjectMetadataProcedure,
+		svc.GetObjectMetadata,
+		opts...,
+	)
+	accessServiceListObjectsHandler := connect_go.NewUnaryHandler(
+		AccessServiceListObjectsProcedure,
+		svc.ListObjects,
+		opts...,
+	)
+	accessServiceSearchObjectsHandler := connect_go.NewUnaryHandler(
+		AccessServiceSearchObjectsProcedure,
+		svc.SearchObjects,
+		opts...,
+	)
+	accessServiceFindContentByDigestHandler := connect_go.NewUnaryHandler(
+		AccessServiceFindContentByDigestProcedure,
+		svc.FindContentByDigest,
+		opts...,
+	)
+	accessServiceListTagsHandler := connect_go.NewUnaryHandler(
+		AccessServiceListTagsProcedure,
+		svc.ListTags,
+		opts...,
+	)
+	accessServiceCreateDownloadURLHandler := connect_go.NewUnaryHandler(
+		AccessServiceCreateDownloadURLProcedure,
+		svc.CreateDownloadURL,
+		opts...,
+	)
+	accessServiceWatchEventsHandler := connect_go.NewServerStreamHandler(
+		AccessServiceWatchEventsProcedure,
+		svc.WatchEvents,
+		opts...,
+	)
+	accessServiceGetContentHandler := connect_go.NewServerStreamHandler(
+		AccessServiceGetContentProcedure,
+		svc.GetContent,
+		opts...,
+	)
 	return "/chaparral.v1.AccessService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AccessServiceGetObjectVersionProcedure:
 			accessServiceGetObjectVersionHandler.ServeHTTP(w, r)
 		case AccessServiceGetObjectManifestProcedure:
 			accessServiceGetObjectManifestHandler.ServeHTTP(w, r)
+		case AccessServiceListObjectManifestProcedure:
+			accessServiceListObjectManifestHandler.ServeHTTP(w, r)
+		case AccessServiceListVersionStateProcedure:
+			accessServiceListVersionStateHandler.ServeHTTP(w, r)
+		case AccessServiceListVersionPathProcedure:
+			accessServiceListVersionPathHandler.ServeHTTP(w, r)
+		case AccessServiceGetObjectMetadataProcedure:
+			accessServiceGetObjectMetadataHandler.ServeHTTP(w, r)
+		case AccessServiceListObjectsProcedure:
+			accessServiceListObjectsHandler.ServeHTTP(w, r)
+		case AccessServiceSearchObjectsProcedure:
+			accessServiceSearchObjectsHandler.ServeHTTP(w, r)
+		case AccessServiceFindContentByDigestProcedure:
+			accessServiceFindContentByDigestHandler.ServeHTTP(w, r)
+		case AccessServiceListTagsProcedure:
+			accessServiceListTagsHandler.ServeHTTP(w, r)
+		case AccessServiceCreateDownloadURLProcedure:
+			accessServiceCreateDownloadURLHandler.ServeHTTP(w, r)
+		case AccessServiceWatchEventsProcedure:
+			accessServiceWatchEventsHandler.ServeHTTP(w, r)
+		case AccessServiceGetContentProcedure:
+			accessServiceGetContentHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -138,3 +446,47 @@ func (UnimplementedAccessServiceHandler) GetObjectVersion(context.Context, *conn
 func (UnimplementedAccessServiceHandler) GetObjectManifest(context.Context, *connect_go.Request[v1.GetObjectManifestRequest]) (*connect_go.Response[v1.GetObjectManifestResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AccessService.GetObjectManifest is not implemented"))
 }
+
+func (UnimplementedAccessServiceHandler) ListObjectManifest(context.Context, *connect_go.Request[v1.ListObjectManifestRequest]) (*connect_go.Response[v1.ListObjectManifestResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AccessService.ListObjectManifest is not implemented"))
+}
+
+func (UnimplementedAccessServiceHandler) ListVersionState(context.Context, *connect_go.Request[v1.ListVersionStateRequest]) (*connect_go.Response[v1.ListVersionStateResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AccessService.ListVersionState is not implemented"))
+}
+
+func (UnimplementedAccessServiceHandler) ListVersionPath(context.Context, *connect_go.Request[v1.ListVersionPathRequest]) (*connect_go.Response[v1.ListVersionPathResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AccessService.ListVersionPath is not implemented"))
+}
+
+func (UnimplementedAccessServiceHandler) GetObjectMetadata(context.Context, *connect_go.Request[v1.GetObjectMetadataRequest]) (*connect_go.Response[v1.GetObjectMetadataResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AccessService.GetObjectMetadata is not implemented"))
+}
+
+func (UnimplementedAccessServiceHandler) ListObjects(context.Context, *connect_go.Request[v1.ListObjectsRequest]) (*connect_go.Response[v1.ListObjectsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AccessService.ListObjects is not implemented"))
+}
+
+func (UnimplementedAccessServiceHandler) SearchObjects(context.Context, *connect_go.Request[v1.SearchObjectsRequest]) (*connect_go.Response[v1.SearchObjectsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AccessService.SearchObjects is not implemented"))
+}
+
+func (UnimplementedAccessServiceHandler) FindContentByDigest(context.Context, *connect_go.Request[v1.FindContentByDigestRequest]) (*connect_go.Response[v1.FindContentByDigestResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AccessService.FindContentByDigest is not implemented"))
+}
+
+func (UnimplementedAccessServiceHandler) ListTags(context.Context, *connect_go.Request[v1.ListTagsRequest]) (*connect_go.Response[v1.ListTagsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AccessService.ListTags is not implemented"))
+}
+
+func (UnimplementedAccessServiceHandler) CreateDownloadURL(context.Context, *connect_go.Request[v1.CreateDownloadURLRequest]) (*connect_go.Response[v1.CreateDownloadURLResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AccessService.CreateDownloadURL is not implemented"))
+}
+
+func (UnimplementedAccessServiceHandler) WatchEvents(context.Context, *connect_go.Request[v1.WatchEventsRequest], *connect_go.ServerStream[v1.WatchEventsResponse]) error {
+	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AccessService.WatchEvents is not implemented"))
+}
+
+func (UnimplementedAccessServiceHandler) GetContent(context.Context, *connect_go.Request[v1.GetContentRequest], *connect_go.ServerStream[v1.GetContentResponse]) error {
+	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AccessService.GetContent is not implemented"))
+}
@@ -0,0 +1,262 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: chaparral/v1/admin_service.proto
+
+package chaparralv1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "github.com/srerickson/chaparral/gen/chaparral/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// AdminServiceName is the fully-qualified name of the AdminService service.
+	AdminServiceName = "chaparral.v1.AdminService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AdminServiceListStorageRootsProcedure is the fully-qualified name of the AdminService's
+	// ListStorageRoots RPC.
+	AdminServiceListStorageRootsProcedure = "/chaparral.v1.AdminService/ListStorageRoots"
+	// AdminServiceValidateStorageRootProcedure is the fully-qualified name of the AdminService's
+	// ValidateStorageRoot RPC.
+	AdminServiceValidateStorageRootProcedure = "/chaparral.v1.AdminService/ValidateStorageRoot"
+	// AdminServiceReindexStorageRootProcedure is the fully-qualified name of the AdminService's
+	// ReindexStorageRoot RPC.
+	AdminServiceReindexStorageRootProcedure = "/chaparral.v1.AdminService/ReindexStorageRoot"
+	// AdminServiceListAuditResultsProcedure is the fully-qualified name of the AdminService's
+	// ListAuditResults RPC.
+	AdminServiceListAuditResultsProcedure = "/chaparral.v1.AdminService/ListAuditResults"
+	// AdminServiceGetDedupReportProcedure is the fully-qualified name of the AdminService's
+	// GetDedupReport RPC.
+	AdminServiceGetDedupReportProcedure = "/chaparral.v1.AdminService/GetDedupReport"
+	// AdminServiceListWebhookDeliveriesProcedure is the fully-qualified name of the AdminService's
+	// ListWebhookDeliveries RPC.
+	AdminServiceListWebhookDeliveriesProcedure = "/chaparral.v1.AdminService/ListWebhookDeliveries"
+)
+
+// AdminServiceClient is a client for the chaparral.v1.AdminService service.
+type AdminServiceClient interface {
+	// ListStorageRoots returns information about the storage roots managed by
+	// the server.
+	ListStorageRoots(context.Context, *connect_go.Request[v1.ListStorageRootsRequest]) (*connect_go.Response[v1.ListStorageRootsResponse], error)
+	// ValidateStorageRoot validates a storage root and its objects. Validation
+	// errors and warnings are streamed as they are found.
+	ValidateStorageRoot(context.Context, *connect_go.Request[v1.ValidateStorageRootRequest]) (*connect_go.ServerStreamForClient[v1.ValidateStorageRootResponse], error)
+	// ReindexStorageRoot rebuilds the server's object cache for a storage root
+	// from the objects in storage.
+	ReindexStorageRoot(context.Context, *connect_go.Request[v1.ReindexStorageRootRequest]) (*connect_go.Response[v1.ReindexStorageRootResponse], error)
+	// ListAuditResults returns results from fixity audits of objects in a
+	// storage root.
+	ListAuditResults(context.Context, *connect_go.Request[v1.ListAuditResultsRequest]) (*connect_go.Response[v1.ListAuditResultsResponse], error)
+	// GetDedupReport returns a report of content that is stored in more than
+	// one object in a storage root.
+	GetDedupReport(context.Context, *connect_go.Request[v1.GetDedupReportRequest]) (*connect_go.Response[v1.GetDedupReportResponse], error)
+	// ListWebhookDeliveries returns the log of webhook event deliveries.
+	ListWebhookDeliveries(context.Context, *connect_go.Request[v1.ListWebhookDeliveriesRequest]) (*connect_go.Response[v1.ListWebhookDeliveriesResponse], error)
+}
+
+// NewAdminServiceClient constructs a client for the chaparral.v1.AdminService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAdminServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) AdminServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &adminServiceClient{
+		listStorageRoots: connect_go.NewClient[v1.ListStorageRootsRequest, v1.ListStorageRootsResponse](
+			httpClient,
+			baseURL+AdminServiceListStorageRootsProcedure,
+			opts...,
+		),
+		validateStorageRoot: connect_go.NewClient[v1.ValidateStorageRootRequest, v1.ValidateStorageRootResponse](
+			httpClient,
+			baseURL+AdminServiceValidateStorageRootProcedure,
+			opts...,
+		),
+		reindexStorageRoot: connect_go.NewClient[v1.ReindexStorageRootRequest, v1.ReindexStorageRootResponse](
+			httpClient,
+			baseURL+AdminServiceReindexStorageRootProcedure,
+			opts...,
+		),
+		listAuditResults: connect_go.NewClient[v1.ListAuditResultsRequest, v1.ListAuditResultsResponse](
+			httpClient,
+			baseURL+AdminServiceListAuditResultsProcedure,
+			opts...,
+		),
+		getDedupReport: connect_go.NewClient[v1.GetDedupReportRequest, v1.GetDedupReportResponse](
+			httpClient,
+			baseURL+AdminServiceGetDedupReportProcedure,
+			opts...,
+		),
+		listWebhookDeliveries: connect_go.NewClient[v1.ListWebhookDeliveriesRequest, v1.ListWebhookDeliveriesResponse](
+			httpClient,
+			baseURL+AdminServiceListWebhookDeliveriesProcedure,
+			opts...,
+		),
+	}
+}
+
+// adminServiceClient implements AdminServiceClient.
+type adminServiceClient struct {
+	listStorageRoots      *connect_go.Client[v1.ListStorageRootsRequest, v1.ListStorageRootsResponse]
+	validateStorageRoot   *connect_go.Client[v1.ValidateStorageRootRequest, v1.ValidateStorageRootResponse]
+	reindexStorageRoot    *connect_go.Client[v1.ReindexStorageRootRequest, v1.ReindexStorageRootResponse]
+	listAuditResults      *connect_go.Client[v1.ListAuditResultsRequest, v1.ListAuditResultsResponse]
+	getDedupReport        *connect_go.Client[v1.GetDedupReportRequest, v1.GetDedupReportResponse]
+	listWebhookDeliveries *connect_go.Client[v1.ListWebhookDeliveriesRequest, v1.ListWebhookDeliveriesResponse]
+}
+
+// ListStorageRoots calls chaparral.v1.AdminService.ListStorageRoots.
+func (c *adminServiceClient) ListStorageRoots(ctx context.Context, req *connect_go.Request[v1.ListStorageRootsRequest]) (*connect_go.Response[v1.ListStorageRootsResponse], error) {
+	return c.listStorageRoots.CallUnary(ctx, req)
+}
+
+// ValidateStorageRoot calls chaparral.v1.AdminService.ValidateStorageRoot.
+func (c *adminServiceClient) ValidateStorageRoot(ctx context.Context, req *connect_go.Request[v1.ValidateStorageRootRequest]) (*connect_go.ServerStreamForClient[v1.ValidateStorageRootResponse], error) {
+	return c.validateStorageRoot.CallServerStream(ctx, req)
+}
+
+// ReindexStorageRoot calls chaparral.v1.AdminService.ReindexStorageRoot.
+func (c *adminServiceClient) ReindexStorageRoot(ctx context.Context, req *connect_go.Request[v1.ReindexStorageRootRequest]) (*connect_go.Response[v1.ReindexStorageRootResponse], error) {
+	return c.reindexStorageRoot.CallUnary(ctx, req)
+}
+
+// ListAuditResults calls chaparral.v1.AdminService.ListAuditResults.
+func (c *adminServiceClient) ListAuditResults(ctx context.Context, req *connect_go.Request[v1.ListAuditResultsRequest]) (*connect_go.Response[v1.ListAuditResultsResponse], error) {
+	return c.listAuditResults.CallUnary(ctx, req)
+}
+
+// GetDedupReport calls chaparral.v1.AdminService.GetDedupReport.
+func (c *adminServiceClient) GetDedupReport(ctx context.Context, req *connect_go.Request[v1.GetDedupReportRequest]) (*connect_go.Response[v1.GetDedupReportResponse], error) {
+	return c.getDedupReport.CallUnary(ctx, req)
+}
+
+// ListWebhookDeliveries calls chaparral.v1.AdminService.ListWebhookDeliveries.
+func (c *adminServiceClient) ListWebhookDeliveries(ctx context.Context, req *connect_go.Request[v1.ListWebhookDeliveriesRequest]) (*connect_go.Response[v1.ListWebhookDeliveriesResponse], error) {
+	return c.listWebhookDeliveries.CallUnary(ctx, req)
+}
+
+// AdminServiceHandler is an implementation of the chaparral.v1.AdminService service.
+type AdminServiceHandler interface {
+	// ListStorageRoots returns information about the storage roots managed by
+	// the server.
+	ListStorageRoots(context.Context, *connect_go.Request[v1.ListStorageRootsRequest]) (*connect_go.Response[v1.ListStorageRootsResponse], error)
+	// ValidateStorageRoot validates a storage root and its objects. Validation
+	// errors and warnings are streamed as they are found.
+	ValidateStorageRoot(context.Context, *connect_go.Request[v1.ValidateStorageRootRequest], *connect_go.ServerStream[v1.ValidateStorageRootResponse]) error
+	// ReindexStorageRoot rebuilds the server's object cache for a storage root
+	// from the objects in storage.
+	ReindexStorageRoot(context.Context, *connect_go.Request[v1.ReindexStorageRootRequest]) (*connect_go.Response[v1.ReindexStorageRootResponse], error)
+	// ListAuditResults returns results from fixity audits of objects in a
+	// storage root.
+	ListAuditResults(context.Context, *connect_go.Request[v1.ListAuditResultsRequest]) (*connect_go.Response[v1.ListAuditResultsResponse], error)
+	// GetDedupReport returns a report of content that is stored in more than
+	// one object in a storage root.
+	GetDedupReport(context.Context, *connect_go.Request[v1.GetDedupReportRequest]) (*connect_go.Response[v1.GetDedupReportResponse], error)
+	// ListWebhookDeliveries returns the log of webhook event deliveries.
+	ListWebhookDeliveries(context.Context, *connect_go.Request[v1.ListWebhookDeliveriesRequest]) (*connect_go.Response[v1.ListWebhookDeliveriesResponse], error)
+}
+
+// NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	adminServiceListStorageRootsHandler := connect_go.NewUnaryHandler(
+		AdminServiceListStorageRootsProcedure,
+		svc.ListStorageRoots,
+		opts...,
+	)
+	adminServiceValidateStorageRootHandler := connect_go.NewServerStreamHandler(
+		AdminServiceValidateStorageRootProcedure,
+		svc.ValidateStorageRoot,
+		opts...,
+	)
+	adminServiceReindexStorageRootHandler := connect_go.NewUnaryHandler(
+		AdminServiceReindexStorageRootProcedure,
+		svc.ReindexStorageRoot,
+		opts...,
+	)
+	adminServiceListAuditResultsHandler := connect_go.NewUnaryHandler(
+		AdminServiceListAuditResultsProcedure,
+		svc.ListAuditResults,
+		opts...,
+	)
+	adminServiceGetDedupReportHandler := connect_go.NewUnaryHandler(
+		AdminServiceGetDedupReportProcedure,
+		svc.GetDedupReport,
+		opts...,
+	)
+	adminServiceListWebhookDeliveriesHandler := connect_go.NewUnaryHandler(
+		AdminServiceListWebhookDeliveriesProcedure,
+		svc.ListWebhookDeliveries,
+		opts...,
+	)
+	return "/chaparral.v1.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AdminServiceListStorageRootsProcedure:
+			adminServiceListStorageRootsHandler.ServeHTTP(w, r)
+		case AdminServiceValidateStorageRootProcedure:
+			adminServiceValidateStorageRootHandler.ServeHTTP(w, r)
+		case AdminServiceReindexStorageRootProcedure:
+			adminServiceReindexStorageRootHandler.ServeHTTP(w, r)
+		case AdminServiceListAuditResultsProcedure:
+			adminServiceListAuditResultsHandler.ServeHTTP(w, r)
+		case AdminServiceGetDedupReportProcedure:
+			adminServiceGetDedupReportHandler.ServeHTTP(w, r)
+		case AdminServiceListWebhookDeliveriesProcedure:
+			adminServiceListWebhookDeliveriesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAdminServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAdminServiceHandler struct{}
+
+func (UnimplementedAdminServiceHandler) ListStorageRoots(context.Context, *connect_go.Request[v1.ListStorageRootsRequest]) (*connect_go.Response[v1.ListStorageRootsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AdminService.ListStorageRoots is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ValidateStorageRoot(context.Context, *connect_go.Request[v1.ValidateStorageRootRequest], *connect_go.ServerStream[v1.ValidateStorageRootResponse]) error {
+	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AdminService.ValidateStorageRoot is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ReindexStorageRoot(context.Context, *connect_go.Request[v1.ReindexStorageRootRequest]) (*connect_go.Response[v1.ReindexStorageRootResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AdminService.ReindexStorageRoot is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListAuditResults(context.Context, *connect_go.Request[v1.ListAuditResultsRequest]) (*connect_go.Response[v1.ListAuditResultsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AdminService.ListAuditResults is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) GetDedupReport(context.Context, *connect_go.Request[v1.GetDedupReportRequest]) (*connect_go.Response[v1.GetDedupReportResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AdminService.GetDedupReport is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListWebhookDeliveries(context.Context, *connect_go.Request[v1.ListWebhookDeliveriesRequest]) (*connect_go.Response[v1.ListWebhookDeliveriesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.AdminService.ListWebhookDeliveries is not implemented"))
+}
@@ -50,6 +50,33 @@ const (
 	// CommitServiceDeleteObjectProcedure is the fully-qualified name of the CommitService's
 	// DeleteObject RPC.
 	CommitServiceDeleteObjectProcedure = "/chaparral.v1.CommitService/DeleteObject"
+	// CommitServiceCopyObjectProcedure is the fully-qualified name of the CommitService's CopyObject
+	// RPC.
+	CommitServiceCopyObjectProcedure = "/chaparral.v1.CommitService/CopyObject"
+	// CommitServiceMoveObjectProcedure is the fully-qualified name of the CommitService's MoveObject
+	// RPC.
+	CommitServiceMoveObjectProcedure = "/chaparral.v1.CommitService/MoveObject"
+	// CommitServiceTagVersionProcedure is the fully-qualified name of the CommitService's TagVersion
+	// RPC.
+	CommitServiceTagVersionProcedure = "/chaparral.v1.CommitService/TagVersion"
+	// CommitServiceDeleteTagProcedure is the fully-qualified name of the CommitService's DeleteTag RPC.
+	CommitServiceDeleteTagProcedure = "/chaparral.v1.CommitService/DeleteTag"
+	// CommitServiceNewMultipartUploadProcedure is the fully-qualified name of the CommitService's
+	// NewMultipartUpload RPC.
+	CommitServiceNewMultipartUploadProcedure = "/chaparral.v1.CommitService/NewMultipartUpload"
+	// CommitServiceCompleteMultipartUploadProcedure is the fully-qualified name of the CommitService's
+	// CompleteMultipartUpload RPC.
+	CommitServiceCompleteMultipartUploadProcedure = "/chaparral.v1.CommitService/CompleteMultipartUpload"
+	// CommitServiceAbortMultipartUploadProcedure is the fully-qualified name of the CommitService's
+	// AbortMultipartUpload RPC.
+	CommitServiceAbortMultipartUploadProcedure = "/chaparral.v1.CommitService/AbortMultipartUpload"
+	// CommitServiceHasContentProcedure is the fully-qualified name of the CommitService's HasContent
+	// RPC.
+	CommitServiceHasContentProcedure = "/chaparral.v1.CommitService/HasContent"
+	// CommitServiceGetUsageProcedure is the fully-qualified name of the CommitService's GetUsage RPC.
+	CommitServiceGetUsageProcedure = "/chaparral.v1.CommitService/GetUsage"
+	// CommitServiceUploadProcedure is the fully-qualified name of the CommitService's Upload RPC.
+	CommitServiceUploadProcedure = "/chaparral.v1.CommitService/Upload"
 )
 
 // CommitServiceClient is a client for the chaparral.v1.CommitService service.
@@ -67,6 +94,41 @@ type CommitServiceClient interface {
 	DeleteUploader(context.Context, *connect_go.Request[v1.DeleteUploaderRequest]) (*connect_go.Response[v1.DeleteUploaderResponse], error)
 	// DeleteObject permanently deletes an existing OCFL object.
 	DeleteObject(context.Context, *connect_go.Request[v1.DeleteObjectRequest]) (*connect_go.Response[v1.DeleteObjectResponse], error)
+	// CopyObject copies an existing OCFL object, with its full version
+	// history, to a different storage root.
+	CopyObject(context.Context, *connect_go.Request[v1.CopyObjectRequest]) (*connect_go.Response[v1.CopyObjectResponse], error)
+	// MoveObject moves an existing OCFL object, with its full version history,
+	// to a different storage root. The source object is only deleted after the
+	// copy has been validated.
+	MoveObject(context.Context, *connect_go.Request[v1.MoveObjectRequest]) (*connect_go.Response[v1.MoveObjectResponse], error)
+	// TagVersion sets a named tag on an object version. If the tag already
+	// exists for the object, it is moved to the version.
+	TagVersion(context.Context, *connect_go.Request[v1.TagVersionRequest]) (*connect_go.Response[v1.TagVersionResponse], error)
+	// DeleteTag removes a version tag from an object.
+	DeleteTag(context.Context, *connect_go.Request[v1.DeleteTagRequest]) (*connect_go.Response[v1.DeleteTagResponse], error)
+	// NewMultipartUpload starts a multipart upload to an uploader. The
+	// response includes presigned URLs for uploading each part directly to
+	// the storage backend. It is only available if the server's uploads use
+	// an S3 backend with direct uploads enabled.
+	NewMultipartUpload(context.Context, *connect_go.Request[v1.NewMultipartUploadRequest]) (*connect_go.Response[v1.NewMultipartUploadResponse], error)
+	// CompleteMultipartUpload assembles the uploaded parts of a multipart
+	// upload and adds the resulting file to the uploader. The file is read to
+	// compute its digests.
+	CompleteMultipartUpload(context.Context, *connect_go.Request[v1.CompleteMultipartUploadRequest]) (*connect_go.Response[v1.CompleteMultipartUploadResponse], error)
+	// AbortMultipartUpload cancels a multipart upload and removes any uploaded
+	// parts.
+	AbortMultipartUpload(context.Context, *connect_go.Request[v1.AbortMultipartUploadRequest]) (*connect_go.Response[v1.AbortMultipartUploadResponse], error)
+	// HasContent reports which digests are already available in an uploader
+	// or an existing object, so clients can skip uploading them before a
+	// commit.
+	HasContent(context.Context, *connect_go.Request[v1.HasContentRequest]) (*connect_go.Response[v1.HasContentResponse], error)
+	// GetUsage returns the total size and number of files in a user's
+	// uploaders and the user's upload quota.
+	GetUsage(context.Context, *connect_go.Request[v1.GetUsageRequest]) (*connect_go.Response[v1.GetUsageResponse], error)
+	// Upload adds a file to an uploader. The file is sent in chunks; the
+	// first message must include the uploader id. It is an alternative to
+	// the HTTP upload path for gRPC clients.
+	Upload(context.Context) *connect_go.ClientStreamForClient[v1.UploadRequest, v1.UploadResponse]
 }
 
 // NewCommitServiceClient constructs a client for the chaparral.v1.CommitService service. By
@@ -109,17 +171,77 @@ func NewCommitServiceClient(httpClient connect_go.HTTPClient, baseURL string, op
 			baseURL+CommitServiceDeleteObjectProcedure,
 			opts...,
 		),
+		copyObject: connect_go.NewClient[v1.CopyObjectRequest, v1.CopyObjectResponse](
+			httpClient,
+			baseURL+CommitServiceCopyObjectProcedure,
+			opts...,
+		),
+		moveObject: connect_go.NewClient[v1.MoveObjectRequest, v1.MoveObjectResponse](
+			httpClient,
+			baseURL+CommitServiceMoveObjectProcedure,
+			opts...,
+		),
+		tagVersion: connect_go.NewClient[v1.TagVersionRequest, v1.TagVersionResponse](
+			httpClient,
+			baseURL+CommitServiceTagVersionProcedure,
+			opts...,
+		),
+		deleteTag: connect_go.NewClient[v1.DeleteTagRequest, v1.DeleteTagResponse](
+			httpClient,
+			baseURL+CommitServiceDeleteTagProcedure,
+			opts...,
+		),
+		newMultipartUpload: connect_go.NewClient[v1.NewMultipartUploadRequest, v1.NewMultipartUploadResponse](
+			httpClient,
+			baseURL+CommitServiceNewMultipartUploadProcedure,
+			opts...,
+		),
+		completeMultipartUpload: connect_go.NewClient[v1.CompleteMultipartUploadRequest, v1.CompleteMultipartUploadResponse](
+			httpClient,
+			baseURL+CommitServiceCompleteMultipartUploadProcedure,
+			opts...,
+		),
+		abortMultipartUpload: connect_go.NewClient[v1.AbortMultipartUploadRequest, v1.AbortMultipartUploadResponse](
+			httpClient,
+			baseURL+CommitServiceAbortMultipartUploadProcedure,
+			opts...,
+		),
+		hasContent: connect_go.NewClient[v1.HasContentRequest, v1.HasContentResponse](
+			httpClient,
+			baseURL+CommitServiceHasContentProcedure,
+			opts...,
+		),
+		getUsage: connect_go.NewClient[v1.GetUsageRequest, v1.GetUsageResponse](
+			httpClient,
+			baseURL+CommitServiceGetUsageProcedure,
+			opts...,
+		),
+		upload: connect_go.NewClient[v1.UploadRequest, v1.UploadResponse](
+			httpClient,
+			baseURL+CommitServiceUploadProcedure,
+			opts...,
+		),
 	}
 }
 
 // commitServiceClient implements CommitServiceClient.
 type commitServiceClient struct {
-	commit         *connect_go.Client[v1.CommitRequest, v1.CommitResponse]
-	newUploader    *connect_go.Client[v1.NewUploaderRequest, v1.NewUploaderResponse]
-	getUploader    *connect_go.Client[v1.GetUploaderRequest, v1.GetUploaderResponse]
-	listUploaders  *connect_go.Client[v1.ListUploadersRequest, v1.ListUploadersResponse]
-	deleteUploader *connect_go.Client[v1.DeleteUploaderRequest, v1.DeleteUploaderResponse]
-	deleteObject   *connect_go.Client[v1.DeleteObjectRequest, v1.DeleteObjectResponse]
+	commit                  *connect_go.Client[v1.CommitRequest, v1.CommitResponse]
+	newUploader             *connect_go.Client[v1.NewUploaderRequest, v1.NewUploaderResponse]
+	getUploader             *connect_go.Client[v1.GetUploaderRequest, v1.GetUploaderResponse]
+	listUploaders           *connect_go.Client[v1.ListUploadersRequest, v1.ListUploadersResponse]
+	deleteUploader          *connect_go.Client[v1.DeleteUploaderRequest, v1.DeleteUploaderResponse]
+	deleteObject            *connect_go.Client[v1.DeleteObjectRequest, v1.DeleteObjectResponse]
+	copyObject              *connect_go.Client[v1.CopyObjectRequest, v1.CopyObjectResponse]
+	moveObject              *connect_go.Client[v1.MoveObjectRequest, v1.MoveObjectResponse]
+	tagVersion              *connect_go.Client[v1.TagVersionRequest, v1.TagVersionResponse]
+	deleteTag               *connect_go.Client[v1.DeleteTagRequest, v1.DeleteTagResponse]
+	newMultipartUpload      *connect_go.Client[v1.NewMultipartUploadRequest, v1.NewMultipartUploadResponse]
+	completeMultipartUpload *connect_go.Client[v1.CompleteMultipartUploadRequest, v1.CompleteMultipartUploadResponse]
+	abortMultipartUpload    *connect_go.Client[v1.AbortMultipartUploadRequest, v1.AbortMultipartUploadResponse]
+	hasContent              *connect_go.Client[v1.HasContentRequest, v1.HasContentResponse]
+	getUsage                *connect_go.Client[v1.GetUsageRequest, v1.GetUsageResponse]
+	upload                  *connect_go.Client[v1.UploadRequest, v1.UploadResponse]
 }
 
 // Commit calls chaparral.v1.CommitService.Commit.
@@ -152,6 +274,56 @@ func (c *commitServiceClient) DeleteObject(ctx context.Context, req *connect_go.
 	return c.deleteObject.CallUnary(ctx, req)
 }
 
+// CopyObject calls chaparral.v1.CommitService.CopyObject.
+func (c *commitServiceClient) CopyObject(ctx context.Context, req *connect_go.Request[v1.CopyObjectRequest]) (*connect_go.Response[v1.CopyObjectResponse], error) {
+	return c.copyObject.CallUnary(ctx, req)
+}
+
+// MoveObject calls chaparral.v1.CommitService.MoveObject.
+func (c *commitServiceClient) MoveObject(ctx context.Context, req *connect_go.Request[v1.MoveObjectRequest]) (*connect_go.Response[v1.MoveObjectResponse], error) {
+	return c.moveObject.CallUnary(ctx, req)
+}
+
+// TagVersion calls chaparral.v1.CommitService.TagVersion.
+func (c *commitServiceClient) TagVersion(ctx context.Context, req *connect_go.Request[v1.TagVersionRequest]) (*connect_go.Response[v1.TagVersionResponse], error) {
+	return c.tagVersion.CallUnary(ctx, req)
+}
+
+// DeleteTag calls chaparral.v1.CommitService.DeleteTag.
+func (c *commitServiceClient) DeleteTag(ctx context.Context, req *connect_go.Request[v1.DeleteTagRequest]) (*connect_go.Response[v1.DeleteTagResponse], error) {
+	return c.deleteTag.CallUnary(ctx, req)
+}
+
+// NewMultipartUpload calls chaparral.v1.CommitService.NewMultipartUpload.
+func (c *commitServiceClient) NewMultipartUpload(ctx context.Context, req *connect_go.Request[v1.NewMultipartUploadRequest]) (*connect_go.Response[v1.NewMultipartUploadResponse], error) {
+	return c.newMultipartUpload.CallUnary(ctx, req)
+}
+
+// CompleteMultipartUpload calls chaparral.v1.CommitService.CompleteMultipartUpload.
+func (c *commitServiceClient) CompleteMultipartUpload(ctx context.Context, req *connect_go.Request[v1.CompleteMultipartUploadRequest]) (*connect_go.Response[v1.CompleteMultipartUploadResponse], error) {
+	return c.completeMultipartUpload.CallUnary(ctx, req)
+}
+
+// AbortMultipartUpload calls chaparral.v1.CommitService.AbortMultipartUpload.
+func (c *commitServiceClient) AbortMultipartUpload(ctx context.Context, req *connect_go.Request[v1.AbortMultipartUploadRequest]) (*connect_go.Response[v1.AbortMultipartUploadResponse], error) {
+	return c.abortMultipartUpload.CallUnary(ctx, req)
+}
+
+// HasContent calls chaparral.v1.CommitService.HasContent.
+func (c *commitServiceClient) HasContent(ctx context.Context, req *connect_go.Request[v1.HasContentRequest]) (*connect_go.Response[v1.HasContentResponse], error) {
+	return c.hasContent.CallUnary(ctx, req)
+}
+
+// GetUsage calls chaparral.v1.CommitService.GetUsage.
+func (c *commitServiceClient) GetUsage(ctx context.Context, req *connect_go.Request[v1.GetUsageRequest]) (*connect_go.Response[v1.GetUsageResponse], error) {
+	return c.getUsage.CallUnary(ctx, req)
+}
+
+// Upload calls chaparral.v1.CommitService.Upload.
+func (c *commitServiceClient) Upload(ctx context.Context) *connect_go.ClientStreamForClient[v1.UploadRequest, v1.UploadResponse] {
+	return c.upload.CallClientStream(ctx)
+}
+
 // CommitServiceHandler is an implementation of the chaparral.v1.CommitService service.
 type CommitServiceHandler interface {
 	// Commit creates or updates individual OCFL objects
@@ -167,6 +339,41 @@ type CommitServiceHandler interface {
 	DeleteUploader(context.Context, *connect_go.Request[v1.DeleteUploaderRequest]) (*connect_go.Response[v1.DeleteUploaderResponse], error)
 	// DeleteObject permanently deletes an existing OCFL object.
 	DeleteObject(context.Context, *connect_go.Request[v1.DeleteObjectRequest]) (*connect_go.Response[v1.DeleteObjectResponse], error)
+	// CopyObject copies an existing OCFL object, with its full version
+	// history, to a different storage root.
+	CopyObject(context.Context, *connect_go.Request[v1.CopyObjectRequest]) (*connect_go.Response[v1.CopyObjectResponse], error)
+	// MoveObject moves an existing OCFL object, with its full version history,
+	// to a different storage root. The source object is only deleted after the
+	// copy has been validated.
+	MoveObject(context.Context, *connect_go.Request[v1.MoveObjectRequest]) (*connect_go.Response[v1.MoveObjectResponse], error)
+	// TagVersion sets a named tag on an object version. If the tag already
+	// exists for the object, it is moved to the version.
+	TagVersion(context.Context, *connect_go.Request[v1.TagVersionRequest]) (*connect_go.Response[v1.TagVersionResponse], error)
+	// DeleteTag removes a version tag from an object.
+	DeleteTag(context.Context, *connect_go.Request[v1.DeleteTagRequest]) (*connect_go.Response[v1.DeleteTagResponse], error)
+	// NewMultipartUpload starts a multipart upload to an uploader. The
+	// response includes presigned URLs for uploading each part directly to
+	// the storage backend. It is only available if the server's uploads use
+	// an S3 backend with direct uploads enabled.
+	NewMultipartUpload(context.Context, *connect_go.Request[v1.NewMultipartUploadRequest]) (*connect_go.Response[v1.NewMultipartUploadResponse], error)
+	// CompleteMultipartUpload assembles the uploaded parts of a multipart
+	// upload and adds the resulting file to the uploader. The file is read to
+	// compute its digests.
+	CompleteMultipartUpload(context.Context, *connect_go.Request[v1.CompleteMultipartUploadRequest]) (*connect_go.Response[v1.CompleteMultipartUploadResponse], error)
+	// AbortMultipartUpload cancels a multipart upload and removes any uploaded
+	// parts.
+	AbortMultipartUpload(context.Context, *connect_go.Request[v1.AbortMultipartUploadRequest]) (*connect_go.Response[v1.AbortMultipartUploadResponse], error)
+	// HasContent reports which digests are already available in an uploader
+	// or an existing object, so clients can skip uploading them before a
+	// commit.
+	HasContent(context.Context, *connect_go.Request[v1.HasContentRequest]) (*connect_go.Response[v1.HasContentResponse], error)
+	// GetUsage returns the total size and number of files in a user's
+	// uploaders and the user's upload quota.
+	GetUsage(context.Context, *connect_go.Request[v1.GetUsageRequest]) (*connect_go.Response[v1.GetUsageResponse], error)
+	// Upload adds a file to an uploader. The file is sent in chunks; the
+	// first message must include the uploader id. It is an alternative to
+	// the HTTP upload path for gRPC clients.
+	Upload(context.Context, *connect_go.ClientStream[v1.UploadRequest]) (*connect_go.Response[v1.UploadResponse], error)
 }
 
 // NewCommitServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -205,6 +412,56 @@ func NewCommitServiceHandler(svc CommitServiceHandler, opts ...connect_go.Handle
 		svc.DeleteObject,
 		opts...,
 	)
+	commitServiceCopyObjectHandler := connect_go.NewUnaryHandler(
+		CommitServiceCopyObjectProcedure,
+		svc.CopyObject,
+		opts...,
+	)
+	commitServiceMoveObjectHandler := connect_go.NewUnaryHandler(
+		CommitServiceMoveObjectProcedure,
+		svc.MoveObject,
+		opts...,
+	)
+	commitServiceTagVersionHandler := connect_go.NewUnaryHandler(
+		CommitServiceTagVersionProcedure,
+		svc.TagVersion,
+		opts...,
+	)
+	commitServiceDeleteTagHandler := connect_go.NewUnaryHandler(
+		CommitServiceDeleteTagProcedure,
+		svc.DeleteTag,
+		opts...,
+	)
+	commitServiceNewMultipartUploadHandler := connect_go.NewUnaryHandler(
+		CommitServiceNewMultipartUploadProcedure,
+		svc.NewMultipartUpload,
+		opts...,
+	)
+	commitServiceCompleteMultipartUploadHandler := connect_go.NewUnaryHandler(
+		CommitServiceCompleteMultipartUploadProcedure,
+		svc.CompleteMultipartUpload,
+		opts...,
+	)
+	commitServiceAbortMultipartUploadHandler := connect_go.NewUnaryHandler(
+		CommitServiceAbortMultipartUploadProcedure,
+		svc.AbortMultipartUpload,
+		opts...,
+	)
+	commitServiceHasContentHandler := connect_go.NewUnaryHandler(
+		CommitServiceHasContentProcedure,
+		svc.HasContent,
+		opts...,
+	)
+	commitServiceGetUsageHandler := connect_go.NewUnaryHandler(
+		CommitServiceGetUsageProcedure,
+		svc.GetUsage,
+		opts...,
+	)
+	commitServiceUploadHandler := connect_go.NewClientStreamHandler(
+		CommitServiceUploadProcedure,
+		svc.Upload,
+		opts...,
+	)
 	return "/chaparral.v1.CommitService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case CommitServiceCommitProcedure:
@@ -219,6 +476,26 @@ func NewCommitServiceHandler(svc CommitServiceHandler, opts ...connect_go.Handle
 			commitServiceDeleteUploaderHandler.ServeHTTP(w, r)
 		case CommitServiceDeleteObjectProcedure:
 			commitServiceDeleteObjectHandler.ServeHTTP(w, r)
+		case CommitServiceCopyObjectProcedure:
+			commitServiceCopyObjectHandler.ServeHTTP(w, r)
+		case CommitServiceMoveObjectProcedure:
+			commitServiceMoveObjectHandler.ServeHTTP(w, r)
+		case CommitServiceTagVersionProcedure:
+			commitServiceTagVersionHandler.ServeHTTP(w, r)
+		case CommitServiceDeleteTagProcedure:
+			commitServiceDeleteTagHandler.ServeHTTP(w, r)
+		case CommitServiceNewMultipartUploadProcedure:
+			commitServiceNewMultipartUploadHandler.ServeHTTP(w, r)
+		case CommitServiceCompleteMultipartUploadProcedure:
+			commitServiceCompleteMultipartUploadHandler.ServeHTTP(w, r)
+		case CommitServiceAbortMultipartUploadProcedure:
+			commitServiceAbortMultipartUploadHandler.ServeHTTP(w, r)
+		case CommitServiceHasContentProcedure:
+			commitServiceHasContentHandler.ServeHTTP(w, r)
+		case CommitServiceGetUsageProcedure:
+			commitServiceGetUsageHandler.ServeHTTP(w, r)
+		case CommitServiceUploadProcedure:
+			commitServiceUploadHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -251,3 +528,43 @@ func (UnimplementedCommitServiceHandler) DeleteUploader(context.Context, *connec
 func (UnimplementedCommitServiceHandler) DeleteObject(context.Context, *connect_go.Request[v1.DeleteObjectRequest]) (*connect_go.Response[v1.DeleteObjectResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.CommitService.DeleteObject is not implemented"))
 }
+
+func (UnimplementedCommitServiceHandler) CopyObject(context.Context, *connect_go.Request[v1.CopyObjectRequest]) (*connect_go.Response[v1.CopyObjectResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.CommitService.CopyObject is not implemented"))
+}
+
+func (UnimplementedCommitServiceHandler) MoveObject(context.Context, *connect_go.Request[v1.MoveObjectRequest]) (*connect_go.Response[v1.MoveObjectResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.CommitService.MoveObject is not implemented"))
+}
+
+func (UnimplementedCommitServiceHandler) TagVersion(context.Context, *connect_go.Request[v1.TagVersionRequest]) (*connect_go.Response[v1.TagVersionResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.CommitService.TagVersion is not implemented"))
+}
+
+func (UnimplementedCommitServiceHandler) DeleteTag(context.Context, *connect_go.Request[v1.DeleteTagRequest]) (*connect_go.Response[v1.DeleteTagResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.CommitService.DeleteTag is not implemented"))
+}
+
+func (UnimplementedCommitServiceHandler) NewMultipartUpload(context.Context, *connect_go.Request[v1.NewMultipartUploadRequest]) (*connect_go.Response[v1.NewMultipartUploadResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.CommitService.NewMultipartUpload is not implemented"))
+}
+
+func (UnimplementedCommitServiceHandler) CompleteMultipartUpload(context.Context, *connect_go.Request[v1.CompleteMultipartUploadRequest]) (*connect_go.Response[v1.CompleteMultipartUploadResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.CommitService.CompleteMultipartUpload is not implemented"))
+}
+
+func (UnimplementedCommitServiceHandler) AbortMultipartUpload(context.Context, *connect_go.Request[v1.AbortMultipartUploadRequest]) (*connect_go.Response[v1.AbortMultipartUploadResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.CommitService.AbortMultipartUpload is not implemented"))
+}
+
+func (UnimplementedCommitServiceHandler) HasContent(context.Context, *connect_go.Request[v1.HasContentRequest]) (*connect_go.Response[v1.HasContentResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.CommitService.HasContent is not implemented"))
+}
+
+func (UnimplementedCommitServiceHandler) GetUsage(context.Context, *connect_go.Request[v1.GetUsageRequest]) (*connect_go.Response[v1.GetUsageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.CommitService.GetUsage is not implemented"))
+}
+
+func (UnimplementedCommitServiceHandler) Upload(context.Context, *connect_go.ClientStream[v1.UploadRequest]) (*connect_go.Response[v1.UploadResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("chaparral.v1.CommitService.Upload is not implemented"))
+}
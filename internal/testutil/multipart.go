@@ -0,0 +1,131 @@
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srerickson/chaparral/server/uploader"
+	"github.com/srerickson/ocfl-go"
+)
+
+// FakeMultipart is an uploader.MultipartBackend for testing. Parts are
+// uploaded to an HTTP test server and kept in memory until the upload is
+// completed and written to the FS.
+type FakeMultipart struct {
+	fsys    ocfl.WriteFS
+	srv     *httptest.Server
+	mx      sync.Mutex
+	uploads map[string]map[int32][]byte // upload id -> part number -> bytes
+}
+
+var _ uploader.MultipartBackend = (*FakeMultipart)(nil)
+
+// NewFakeMultipart returns a new FakeMultipart for uploads to fsys. The
+// test server is closed when the test completes.
+func NewFakeMultipart(t *testing.T, fsys ocfl.WriteFS) *FakeMultipart {
+	t.Helper()
+	mp := &FakeMultipart{
+		fsys:    fsys,
+		uploads: map[string]map[int32][]byte{},
+	}
+	mp.srv = httptest.NewServer(http.HandlerFunc(mp.handlePart))
+	t.Cleanup(mp.srv.Close)
+	return mp
+}
+
+// Len returns the number of uploads that haven't been completed or aborted.
+func (mp *FakeMultipart) Len() int {
+	mp.mx.Lock()
+	defer mp.mx.Unlock()
+	return len(mp.uploads)
+}
+
+func (mp *FakeMultipart) NewMultipartUpload(_ context.Context, _ string) (string, error) {
+	mp.mx.Lock()
+	defer mp.mx.Unlock()
+	id := uuid.NewString()
+	mp.uploads[id] = map[int32][]byte{}
+	return id, nil
+}
+
+func (mp *FakeMultipart) PresignUploadPart(_ context.Context, _ string, uploadID string, number int32, _ time.Duration) (string, error) {
+	vals := url.Values{
+		"uploadId":   {uploadID},
+		"partNumber": {strconv.Itoa(int(number))},
+	}
+	return mp.srv.URL + "/?" + vals.Encode(), nil
+}
+
+func (mp *FakeMultipart) CompleteMultipartUpload(ctx context.Context, name string, uploadID string, parts []uploader.Part) error {
+	mp.mx.Lock()
+	uploaded, ok := mp.uploads[uploadID]
+	delete(mp.uploads, uploadID)
+	mp.mx.Unlock()
+	if !ok {
+		return errors.New("no such upload")
+	}
+	var buf bytes.Buffer
+	for _, p := range parts {
+		byts, ok := uploaded[p.Number]
+		if !ok || p.ETag != partETag(byts) {
+			return fmt.Errorf("invalid part: %d", p.Number)
+		}
+		buf.Write(byts)
+	}
+	_, err := mp.fsys.Write(ctx, name, &buf)
+	return err
+}
+
+func (mp *FakeMultipart) AbortMultipartUpload(_ context.Context, _ string, uploadID string) error {
+	mp.mx.Lock()
+	defer mp.mx.Unlock()
+	if _, ok := mp.uploads[uploadID]; !ok {
+		return errors.New("no such upload")
+	}
+	delete(mp.uploads, uploadID)
+	return nil
+}
+
+func (mp *FakeMultipart) handlePart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	number, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	byts, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	mp.mx.Lock()
+	defer mp.mx.Unlock()
+	uploaded, ok := mp.uploads[r.URL.Query().Get("uploadId")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	uploaded[int32(number)] = byts
+	w.Header().Set("ETag", partETag(byts))
+}
+
+func partETag(byts []byte) string {
+	sum := md5.Sum(byts)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
@@ -49,7 +49,8 @@ func RunServiceTest(t *testing.T, tests ...ServiceTestFunc) {
 	t.Run("local-root", func(t *testing.T) {
 		db := TestDB(t)
 		store := NewStoreTempDir(t)
-		mgr := uploader.NewManager(store.FS(), "uploads", db)
+		mp := NewFakeMultipart(t, store.FS())
+		mgr := uploader.NewManager(store.FS(), "uploads", db, uploader.WithMultipart(mp))
 		mux := server.New(append(opts,
 			server.WithStorageRoots(store),
 			server.WithUploaderManager(mgr))...)
@@ -67,7 +68,11 @@ func RunServiceTest(t *testing.T, tests ...ServiceTestFunc) {
 	t.Run("s3-root", func(t *testing.T) {
 		db := TestDB(t)
 		root := NewStoreS3(t)
-		mgr := uploader.NewManager(root.FS(), "uploads", db)
+		mp, err := uploader.NewS3Multipart(root.FS())
+		if err != nil {
+			t.Fatal(err)
+		}
+		mgr := uploader.NewManager(root.FS(), "uploads", db, uploader.WithMultipart(mp))
 		mux := server.New(append(opts,
 			server.WithStorageRoots(root),
 			server.WithUploaderManager(mgr))...)
@@ -116,7 +121,8 @@ func NewStoreTestdata(t *testing.T, testdataPath string) *store.StorageRoot {
 		t.Fatal(err)
 	}
 	dir := path.Join("storage-roots", "root-01")
-	root := store.NewStorageRoot(TestStoreID, fsys, dir, nil, TestDB(t))
+	db := TestDB(t)
+	root := store.NewStorageRoot(TestStoreID, fsys, dir, nil, db, store.WithVersionTags(db))
 	if err := root.Ready(context.Background()); err != nil {
 		t.Fatal(err)
 	}
@@ -125,12 +131,19 @@ func NewStoreTestdata(t *testing.T, testdataPath string) *store.StorageRoot {
 
 // new temp directory storage root for testing
 func NewStoreTempDir(t *testing.T) *store.StorageRoot {
+	t.Helper()
+	return NewStoreTempDirID(t, TestStoreID)
+}
+
+// new temp directory storage root for testing with the given storage root id
+func NewStoreTempDirID(t *testing.T, id string) *store.StorageRoot {
 	t.Helper()
 	fsys, err := local.NewFS(t.TempDir())
 	if err != nil {
 		t.Fatal(err)
 	}
-	root := store.NewStorageRoot(TestStoreID, fsys, "ocfl", &storeConf, TestDB(t))
+	db := TestDB(t)
+	root := store.NewStorageRoot(id, fsys, "ocfl", &storeConf, db, store.WithVersionTags(db))
 	if err := root.Ready(context.Background()); err != nil {
 		t.Fatal(err)
 	}
@@ -145,7 +158,8 @@ func NewStoreS3(t *testing.T) *store.StorageRoot {
 	if err != nil {
 		t.Fatal(err)
 	}
-	root := store.NewStorageRoot(TestStoreID, fsys, "ocfl", &storeConf, TestDB(t))
+	db := TestDB(t)
+	root := store.NewStorageRoot(TestStoreID, fsys, "ocfl", &storeConf, db, store.WithVersionTags(db))
 	if err := root.Ready(context.Background()); err != nil {
 		t.Fatal(err)
 	}
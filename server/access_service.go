@@ -8,16 +8,35 @@ import (
 	"io/fs"
 	"net/http"
 	"path"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bufbuild/connect-go"
 	chap "github.com/srerickson/chaparral"
 	chaparralv1 "github.com/srerickson/chaparral/gen/chaparral/v1"
 	"github.com/srerickson/chaparral/gen/chaparral/v1/chaparralv1connect"
+	"github.com/srerickson/chaparral/server/backend"
+	"github.com/srerickson/chaparral/server/eventlog"
 	"github.com/srerickson/chaparral/server/store"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// defaultListObjectsLimit is the maximum number of objects returned by
+// ListObjects if the request doesn't set a limit.
+const defaultListObjectsLimit = 1000
+
+// default and maximum page sizes for ListObjectManifest and ListVersionState
+const (
+	defaultPageSize = 1000
+	maxPageSize     = 10000
+)
+
+// maxPathEntries is the maximum number of entries in a ListVersionPath
+// response.
+const maxPathEntries = maxPageSize
+
 type AccessService struct {
 	*chaparral
 }
@@ -51,7 +70,14 @@ func (s *AccessService) GetObjectVersion(ctx context.Context, req *connect.Reque
 	if err != nil {
 		return nil, connect.NewError(connect.CodeNotFound, err)
 	}
-	obj, err := store.GetObjectVersion(ctx, req.Msg.ObjectId, int(req.Msg.Version))
+	version, connErr := requestVersion(ctx, store, req.Msg.ObjectId, req.Msg.Version, req.Msg.Tag)
+	if connErr != nil {
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error(connErr.Error())
+		}
+		return nil, connErr
+	}
+	obj, err := store.GetObjectVersion(ctx, req.Msg.ObjectId, version)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
 			return nil, connect.NewError(connect.CodeNotFound, err)
@@ -113,7 +139,9 @@ func (s *AccessService) GetObjectManifest(ctx context.Context, req *connect.Requ
 		Path:            obj.Path,
 		DigestAlgorithm: obj.DigestAlgorithm,
 		Spec:            obj.Spec,
+		InventoryDigest: obj.InventoryDigest,
 		Manifest:        map[string]*chaparralv1.FileInfo{},
+		Metadata:        (*Metadata)(obj.Metadata).AsProto(),
 	}
 	for d, info := range obj.Manifest {
 		resp.Manifest[d] = &chaparralv1.FileInfo{
@@ -125,6 +153,792 @@ func (s *AccessService) GetObjectManifest(ctx context.Context, req *connect.Requ
 	return connect.NewResponse(resp), nil
 }
 
+func (s *AccessService) ListObjectManifest(ctx context.Context, req *connect.Request[chaparralv1.ListObjectManifestRequest]) (*connect.Response[chaparralv1.ListObjectManifestResponse], error) {
+	logger := LoggerFromCtx(ctx).With(
+		chap.QueryStorageRoot, req.Msg.StorageRootId,
+		chap.QueryObjectID, req.Msg.ObjectId,
+	)
+	authResource := AuthResource(req.Msg.StorageRootId, req.Msg.ObjectId)
+	if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, authResource) {
+		err := errors.New("you don't have permission to read from the storage root")
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	pageSize, err := pageSize(req.Msg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	// get an extra item to determine if there is another page
+	obj, err := store.GetObjectManifestPage(ctx, req.Msg.ObjectId, req.Msg.PathPrefix, req.Msg.PageToken, pageSize+1)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		logger.Error(err.Error())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	digests := make([]string, 0, len(obj.Manifest))
+	for d := range obj.Manifest {
+		digests = append(digests, d)
+	}
+	slices.Sort(digests)
+	resp := &chaparralv1.ListObjectManifestResponse{
+		DigestAlgorithm: obj.DigestAlgorithm,
+	}
+	if len(digests) > pageSize {
+		digests = digests[:pageSize]
+		resp.NextPageToken = digests[pageSize-1]
+	}
+	resp.Items = make([]*chaparralv1.ListObjectManifestResponse_Item, len(digests))
+	for i, d := range digests {
+		info := obj.Manifest[d]
+		resp.Items[i] = &chaparralv1.ListObjectManifestResponse_Item{
+			Digest: d,
+			Info: &chaparralv1.FileInfo{
+				Paths:  info.Paths,
+				Size:   info.Size,
+				Fixity: info.Fixity,
+			},
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *AccessService) ListVersionState(ctx context.Context, req *connect.Request[chaparralv1.ListVersionStateRequest]) (*connect.Response[chaparralv1.ListVersionStateResponse], error) {
+	logger := LoggerFromCtx(ctx).With(
+		chap.QueryStorageRoot, req.Msg.StorageRootId,
+		chap.QueryObjectID, req.Msg.ObjectId,
+		"version", req.Msg.Version,
+	)
+	authResource := AuthResource(req.Msg.StorageRootId, req.Msg.ObjectId)
+	if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, authResource) {
+		err := errors.New("you don't have permission to read from the storage root")
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	pageSize, err := pageSize(req.Msg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.Version < 0 {
+		err := errors.New("version must not be negative")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	version, connErr := requestVersion(ctx, store, req.Msg.ObjectId, req.Msg.Version, req.Msg.Tag)
+	if connErr != nil {
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error(connErr.Error())
+		}
+		return nil, connErr
+	}
+	// get an extra item to determine if there is another page
+	page, err := store.ListVersionState(ctx, req.Msg.ObjectId, version, req.Msg.PathPrefix, req.Msg.PageToken, pageSize+1)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		logger.Error(err.Error())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	items := page.Items
+	resp := &chaparralv1.ListVersionStateResponse{
+		Version:         int32(page.Version),
+		DigestAlgorithm: page.DigestAlgorithm,
+	}
+	if len(items) > pageSize {
+		items = items[:pageSize]
+		resp.NextPageToken = items[pageSize-1].Path
+	}
+	resp.Items = make([]*chaparralv1.ListVersionStateResponse_Item, len(items))
+	for i, item := range items {
+		resp.Items[i] = &chaparralv1.ListVersionStateResponse_Item{
+			Path:   item.Path,
+			Digest: item.Digest,
+			Size:   item.Size,
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *AccessService) ListVersionPath(ctx context.Context, req *connect.Request[chaparralv1.ListVersionPathRequest]) (*connect.Response[chaparralv1.ListVersionPathResponse], error) {
+	logger := LoggerFromCtx(ctx).With(
+		chap.QueryStorageRoot, req.Msg.StorageRootId,
+		chap.QueryObjectID, req.Msg.ObjectId,
+		"version", req.Msg.Version,
+	)
+	authResource := AuthResource(req.Msg.StorageRootId, req.Msg.ObjectId)
+	if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, authResource) {
+		err := errors.New("you don't have permission to read from the storage root")
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if req.Msg.Version < 0 {
+		err := errors.New("version must not be negative")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	dir := strings.TrimSuffix(req.Msg.Path, "/")
+	if dir == "." {
+		dir = ""
+	}
+	if dir != "" && !fs.ValidPath(dir) {
+		err := fmt.Errorf("invalid path: %q", req.Msg.Path)
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	version, connErr := requestVersion(ctx, store, req.Msg.ObjectId, req.Msg.Version, req.Msg.Tag)
+	if connErr != nil {
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error(connErr.Error())
+		}
+		return nil, connErr
+	}
+	listErr := func(err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return connect.NewError(connect.CodeNotFound, err)
+		}
+		logger.Error(err.Error())
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	// paths are read from the cached state of the requested version in pages,
+	// with sizes from the cached manifest.
+	var prefix string
+	if dir != "" {
+		prefix = dir + "/"
+		// a file with the same name sorts before paths in the directory
+		first, err := store.ListVersionState(ctx, req.Msg.ObjectId, version, dir, "", 1)
+		if err != nil {
+			return nil, listErr(err)
+		}
+		version = first.Version
+		if len(first.Items) > 0 && first.Items[0].Path == dir {
+			err := fmt.Errorf("not a directory: %q", dir)
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+	resp := &chaparralv1.ListVersionPathResponse{Path: dir}
+	entries := map[string]*chaparralv1.ListVersionPathResponse_Entry{}
+	var after string
+	for {
+		page, err := store.ListVersionState(ctx, req.Msg.ObjectId, version, prefix, after, maxPageSize)
+		if err != nil {
+			return nil, listErr(err)
+		}
+		// later pages are from the same version
+		version = page.Version
+		resp.Version = int32(page.Version)
+		resp.DigestAlgorithm = page.DigestAlgorithm
+		for _, item := range page.Items {
+			rel := strings.TrimPrefix(item.Path, prefix)
+			name, _, isDir := strings.Cut(rel, "/")
+			entry := entries[name]
+			if entry == nil {
+				if len(entries) == maxPathEntries {
+					err := fmt.Errorf("directory has more than %d entries: list its files with ListVersionState", maxPathEntries)
+					return nil, connect.NewError(connect.CodeResourceExhausted, err)
+				}
+				entry = &chaparralv1.ListVersionPathResponse_Entry{Name: name, IsDir: isDir}
+				if !isDir {
+					entry.Digest = item.Digest
+				}
+				entries[name] = entry
+			}
+			entry.Size = addSize(entry.Size, item.Size)
+			entry.FileCount++
+			resp.Size = addSize(resp.Size, item.Size)
+			resp.FileCount++
+		}
+		if len(page.Items) < maxPageSize {
+			break
+		}
+		after = page.Items[len(page.Items)-1].Path
+	}
+	if dir != "" && len(entries) == 0 {
+		err := fmt.Errorf("directory not found: %q", dir)
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	resp.Entries = make([]*chaparralv1.ListVersionPathResponse_Entry, 0, len(entries))
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, entry)
+	}
+	slices.SortFunc(resp.Entries, func(a, b *chaparralv1.ListVersionPathResponse_Entry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return connect.NewResponse(resp), nil
+}
+
+// addSize adds size to total. Sizes that aren't known are -1; the total isn't
+// known if any of its sizes aren't.
+func addSize(total int64, size int64) int64 {
+	if total < 0 || size < 0 {
+		return -1
+	}
+	return total + size
+}
+
+// pageSize returns the page size for a request with the given page_size
+// value.
+func pageSize(size int32) (int, error) {
+	switch {
+	case size < 0:
+		err := errors.New("page_size must not be negative")
+		return 0, connect.NewError(connect.CodeInvalidArgument, err)
+	case size == 0:
+		return defaultPageSize, nil
+	case size > maxPageSize:
+		err := fmt.Errorf("page_size must not be greater than %d", maxPageSize)
+		return 0, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return int(size), nil
+}
+
+func (s *AccessService) GetObjectMetadata(ctx context.Context, req *connect.Request[chaparralv1.GetObjectMetadataRequest]) (*connect.Response[chaparralv1.GetObjectMetadataResponse], error) {
+	logger := LoggerFromCtx(ctx).With(
+		chap.QueryStorageRoot, req.Msg.StorageRootId,
+		chap.QueryObjectID, req.Msg.ObjectId,
+	)
+	authResource := AuthResource(req.Msg.StorageRootId, req.Msg.ObjectId)
+	if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, authResource) {
+		err := errors.New("you don't have permission to read from the storage root")
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	obj, err := store.GetObjectManifest(ctx, req.Msg.ObjectId)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		logger.Error(err.Error())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer obj.Close()
+	resp := &chaparralv1.GetObjectMetadataResponse{
+		StorageRootId: obj.StorageRootID,
+		ObjectId:      obj.ID,
+		Metadata:      (*Metadata)(obj.Metadata).AsProto(),
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *AccessService) ListObjects(ctx context.Context, req *connect.Request[chaparralv1.ListObjectsRequest]) (*connect.Response[chaparralv1.ListObjectsResponse], error) {
+	logger := LoggerFromCtx(ctx).With(chap.QueryStorageRoot, req.Msg.StorageRootId)
+	// the user must be able to read some objects in the storage root
+	authResource := AuthResource(req.Msg.StorageRootId, "*")
+	if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, authResource) {
+		err := errors.New("you don't have permission to read from the storage root")
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	limit := int(req.Msg.Limit)
+	if limit < 1 {
+		limit = defaultListObjectsLimit
+	}
+	if req.Msg.Offset < 0 {
+		err := errors.New("offset must not be negative")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	items, next, err := s.readableObjects(ctx, limit, int(req.Msg.Offset), func(limit, offset int) ([]chap.ObjectListItem, error) {
+		return store.ListObjects(ctx, limit, offset)
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp := &chaparralv1.ListObjectsResponse{
+		Objects:    items,
+		NextOffset: int32(next),
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *AccessService) SearchObjects(ctx context.Context, req *connect.Request[chaparralv1.SearchObjectsRequest]) (*connect.Response[chaparralv1.SearchObjectsResponse], error) {
+	logger := LoggerFromCtx(ctx).With(chap.QueryStorageRoot, req.Msg.StorageRootId)
+	// the user must be able to read some objects in the storage root
+	authResource := AuthResource(req.Msg.StorageRootId, "*")
+	if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, authResource) {
+		err := errors.New("you don't have permission to read from the storage root")
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if req.Msg.Query == "" && req.Msg.Digest == "" {
+		err := errors.New("missing required 'query' or 'digest' value")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	limit := int(req.Msg.Limit)
+	if limit < 1 {
+		limit = defaultListObjectsLimit
+	}
+	if req.Msg.Offset < 0 {
+		err := errors.New("offset must not be negative")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	items, next, err := s.readableObjects(ctx, limit, int(req.Msg.Offset), func(limit, offset int) ([]chap.ObjectListItem, error) {
+		return store.SearchObjects(ctx, req.Msg.Query, req.Msg.Digest, limit, offset)
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp := &chaparralv1.SearchObjectsResponse{
+		Objects:    items,
+		NextOffset: int32(next),
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *AccessService) FindContentByDigest(ctx context.Context, req *connect.Request[chaparralv1.FindContentByDigestRequest]) (*connect.Response[chaparralv1.FindContentByDigestResponse], error) {
+	logger := LoggerFromCtx(ctx).With(chap.QueryStorageRoot, req.Msg.StorageRootId, chap.QueryDigest, req.Msg.Digest)
+	// the user must be able to read some objects in the storage root
+	authResource := AuthResource(req.Msg.StorageRootId, "*")
+	if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, authResource) {
+		err := errors.New("you don't have permission to read from the storage root")
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if req.Msg.Digest == "" {
+		err := errors.New("missing required 'digest' value")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	locs, err := store.FindContent(ctx, req.Msg.Digest)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp := &chaparralv1.FindContentByDigestResponse{
+		Objects: make([]*chaparralv1.FindContentByDigestResponse_Item, 0, len(locs)),
+	}
+	for _, loc := range locs {
+		if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, AuthResource(loc.StorageRootID, loc.ID)) {
+			continue
+		}
+		item := &chaparralv1.FindContentByDigestResponse_Item{
+			ObjectId:     loc.ID,
+			ContentPaths: loc.ContentPaths,
+			Versions:     make([]*chaparralv1.FindContentByDigestResponse_Version, len(loc.Versions)),
+		}
+		for i, v := range loc.Versions {
+			item.Versions[i] = &chaparralv1.FindContentByDigestResponse_Version{
+				Version: int32(v.Version),
+				Paths:   v.Paths,
+			}
+		}
+		resp.Objects = append(resp.Objects, item)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *AccessService) ListTags(ctx context.Context, req *connect.Request[chaparralv1.ListTagsRequest]) (*connect.Response[chaparralv1.ListTagsResponse], error) {
+	logger := LoggerFromCtx(ctx).With(
+		chap.QueryStorageRoot, req.Msg.StorageRootId,
+		chap.QueryObjectID, req.Msg.ObjectId,
+	)
+	authResource := AuthResource(req.Msg.StorageRootId, req.Msg.ObjectId)
+	if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, authResource) {
+		err := errors.New("you don't have permission to read from the storage root")
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	tags, err := store.ListVersionTags(ctx, req.Msg.ObjectId)
+	if err != nil {
+		connErr := tagError(err)
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error(err.Error())
+		}
+		return nil, connErr
+	}
+	resp := &chaparralv1.ListTagsResponse{
+		Tags: make([]*chaparralv1.VersionTag, len(tags)),
+	}
+	for i, tag := range tags {
+		resp.Tags[i] = VersionTag(tag).AsProto()
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *AccessService) CreateDownloadURL(ctx context.Context, req *connect.Request[chaparralv1.CreateDownloadURLRequest]) (*connect.Response[chaparralv1.CreateDownloadURLResponse], error) {
+	logger := LoggerFromCtx(ctx).With(
+		chap.QueryStorageRoot, req.Msg.StorageRootId,
+		chap.QueryObjectID, req.Msg.ObjectId,
+		chap.QueryDigest, req.Msg.Digest,
+		chap.QueryContentPath, req.Msg.ContentPath,
+	)
+	authResource := AuthResource(req.Msg.StorageRootId, req.Msg.ObjectId)
+	if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, authResource) {
+		err := errors.New("you don't have permission to read from the storage root")
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	if req.Msg.ObjectId == "" {
+		err := errors.New("missing required 'object_id'")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if req.Msg.Digest == "" && req.Msg.ContentPath == "" {
+		err := errors.New("must provide 'content_path' or 'digest'")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	expiresIn := time.Duration(req.Msg.ExpiresIn) * time.Second
+	switch {
+	case expiresIn == 0:
+		expiresIn = defaultDownloadURLExpiry
+	case expiresIn < 0 || expiresIn > maxDownloadURLExpiry:
+		err := fmt.Errorf("'expires_in' must be between 1 and %d seconds", int64(maxDownloadURLExpiry.Seconds()))
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	fullPath, err := contentFullPath(ctx, store, req.Msg.ObjectId, req.Msg.Digest, req.Msg.ContentPath)
+	if err == nil {
+		// make sure the content exists
+		var f fs.File
+		if f, err = store.FS().OpenFile(ctx, fullPath); err == nil {
+			err = f.Close()
+		}
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		case errors.Is(err, errInvalidContentPath):
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		logger.Error(err.Error())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	expires := time.Now().Add(expiresIn).Truncate(time.Second)
+	if req.Msg.Direct {
+		directURL, err := backend.PresignGet(ctx, store.FS(), fullPath, expiresIn)
+		switch {
+		case err == nil:
+			return connect.NewResponse(&chaparralv1.CreateDownloadURLResponse{
+				Url:     directURL,
+				Expires: timestamppb.New(expires),
+				Direct:  true,
+			}), nil
+		case !errors.Is(err, backend.ErrPresignUnsupported):
+			logger.Error("presigning download URL: " + err.Error())
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	signedURL, err := s.signedDownloadURL(req.Msg.StorageRootId, req.Msg.ObjectId, req.Msg.Digest, req.Msg.ContentPath, expires)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, err)
+	}
+	return connect.NewResponse(&chaparralv1.CreateDownloadURLResponse{
+		Url:     signedURL,
+		Expires: timestamppb.New(expires),
+	}), nil
+}
+
+// WatchEvents streams events for objects that are committed or deleted,
+// starting after the request's cursor. Events for objects the user can't read
+// are skipped. The stream continues until the client disconnects.
+func (s *AccessService) WatchEvents(ctx context.Context, req *connect.Request[chaparralv1.WatchEventsRequest], stream *connect.ServerStream[chaparralv1.WatchEventsResponse]) error {
+	logger := LoggerFromCtx(ctx)
+	if s.events == nil {
+		err := errors.New("the server does not have an event log")
+		return connect.NewError(connect.CodeUnimplemented, err)
+	}
+	for _, id := range req.Msg.StorageRootIds {
+		if _, err := s.storageRoot(id); err != nil {
+			return connect.NewError(connect.CodeNotFound, err)
+		}
+	}
+	var after int64
+	switch {
+	case req.Msg.Cursor != "":
+		var err error
+		after, err = strconv.ParseInt(req.Msg.Cursor, 10, 64)
+		if err != nil || after < 0 {
+			err := fmt.Errorf("invalid cursor: %q", req.Msg.Cursor)
+			return connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	default:
+		var err error
+		after, err = s.events.Last(ctx)
+		if err != nil {
+			logger.Error("reading event log: " + err.Error())
+			return connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	err := s.events.Watch(ctx, after, func(event eventlog.Event) error {
+		if !event.IsObjectEvent() || !watchMatch(req.Msg, event) {
+			return nil
+		}
+		if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, AuthResource(event.StorageRootID, event.ObjectID)) {
+			return nil
+		}
+		return stream.Send(&chaparralv1.WatchEventsResponse{
+			Cursor:        strconv.FormatInt(event.Seq, 10),
+			EventId:       event.ID,
+			EventType:     event.Type,
+			StorageRootId: event.StorageRootID,
+			ObjectId:      event.ObjectID,
+			UserId:        event.UserID,
+			Created:       timestamppb.New(event.Created),
+		})
+	})
+	if err != nil && ctx.Err() == nil {
+		logger.Error("watching events: " + err.Error())
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+// watchMatch returns true if the event matches the request's storage roots
+// and object id prefixes.
+func watchMatch(req *chaparralv1.WatchEventsRequest, event eventlog.Event) bool {
+	if len(req.StorageRootIds) > 0 && !slices.Contains(req.StorageRootIds, event.StorageRootID) {
+		return false
+	}
+	if len(req.ObjectIdPrefixes) > 0 && !slices.ContainsFunc(req.ObjectIdPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(event.ObjectID, prefix)
+	}) {
+		return false
+	}
+	return true
+}
+
+// getContentChunkSize is the size of content chunks sent by GetContent.
+const getContentChunkSize = 256 * 1024
+
+// GetContent streams a file from an object in chunks. The first message
+// includes the file's size and digest.
+func (s *AccessService) GetContent(ctx context.Context, req *connect.Request[chaparralv1.GetContentRequest], stream *connect.ServerStream[chaparralv1.GetContentResponse]) error {
+	logger := LoggerFromCtx(ctx).With(
+		chap.QueryStorageRoot, req.Msg.StorageRootId,
+		chap.QueryObjectID, req.Msg.ObjectId,
+		chap.QueryDigest, req.Msg.Digest,
+		chap.QueryContentPath, req.Msg.ContentPath,
+		"logical_path", req.Msg.LogicalPath,
+	)
+	authResource := AuthResource(req.Msg.StorageRootId, req.Msg.ObjectId)
+	if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, authResource) {
+		err := errors.New("you don't have permission to read from the storage root")
+		return connect.NewError(connect.CodePermissionDenied, err)
+	}
+	if req.Msg.ObjectId == "" {
+		err := errors.New("missing required 'object_id'")
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	var set int
+	for _, val := range []string{req.Msg.Digest, req.Msg.ContentPath, req.Msg.LogicalPath} {
+		if val != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		err := errors.New("must provide one of 'digest', 'content_path', or 'logical_path'")
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if req.Msg.Version < 0 {
+		err := errors.New("'version' must not be negative")
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	version, connErr := requestVersion(ctx, store, req.Msg.ObjectId, req.Msg.Version, req.Msg.Tag)
+	if connErr != nil {
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error(connErr.Error())
+		}
+		return connErr
+	}
+	digest := req.Msg.Digest
+	if req.Msg.LogicalPath != "" {
+		digest, err = logicalPathDigest(ctx, store, req.Msg.ObjectId, version, req.Msg.LogicalPath)
+		if err != nil {
+			connErr := contentError(err)
+			if connErr.Code() == connect.CodeInternal {
+				logger.Error(err.Error())
+			}
+			return connErr
+		}
+	}
+	obj, err := store.GetObjectManifest(ctx, req.Msg.ObjectId)
+	if err != nil {
+		connErr := contentError(err)
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error(err.Error())
+		}
+		return connErr
+	}
+	// hold the object's read lock until the content is sent.
+	defer obj.Close()
+	contentPath := req.Msg.ContentPath
+	switch {
+	case contentPath != "":
+		for d, info := range obj.Manifest {
+			if slices.Contains(info.Paths, contentPath) {
+				digest = d
+				break
+			}
+		}
+		if digest == "" {
+			err := fmt.Errorf("object %q has no content path %q", req.Msg.ObjectId, contentPath)
+			return connect.NewError(connect.CodeNotFound, err)
+		}
+	default:
+		if paths := obj.Manifest[digest].Paths; len(paths) > 0 {
+			contentPath = paths[0]
+		}
+		if contentPath == "" {
+			err := fmt.Errorf("object %q has no content with digest %q", req.Msg.ObjectId, digest)
+			return connect.NewError(connect.CodeNotFound, err)
+		}
+	}
+	f, err := store.FS().OpenFile(ctx, path.Join(obj.Path, contentPath))
+	if err != nil {
+		connErr := contentError(err)
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error(err.Error())
+		}
+		return connErr
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			logger.Error("closing file: " + closeErr.Error())
+		}
+	}()
+	info, err := f.Stat()
+	if err != nil {
+		logger.Error(err.Error())
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	err = stream.Send(&chaparralv1.GetContentResponse{
+		Header: &chaparralv1.GetContentResponse_Header{
+			DigestAlgorithm: obj.DigestAlgorithm,
+			Digest:          digest,
+			Size:            info.Size(),
+			ContentPath:     contentPath,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, getContentChunkSize)
+	for {
+		n, readErr := f.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&chaparralv1.GetContentResponse{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		if readErr != nil {
+			logger.Error("during download: " + readErr.Error())
+			return connect.NewError(connect.CodeInternal, readErr)
+		}
+	}
+}
+
+// logicalPathDigest returns the digest for the logical path in the object
+// version.
+func logicalPathDigest(ctx context.Context, root *store.StorageRoot, objectID string, version int, logicalPath string) (string, error) {
+	obj, err := root.GetObjectVersion(ctx, objectID, version)
+	if err != nil {
+		return "", err
+	}
+	defer obj.Close()
+	for digest, info := range obj.State {
+		if _, found := slices.BinarySearch(info.Paths, logicalPath); found {
+			return digest, nil
+		}
+	}
+	return "", fmt.Errorf("object %q version %d has no logical path %q: %w", objectID, obj.Version, logicalPath, fs.ErrNotExist)
+}
+
+// requestVersion returns the version number identified by a request's version
+// and tag values. If tag is set, the tagged version is returned.
+func requestVersion(ctx context.Context, root *store.StorageRoot, objectID string, version int32, tag string) (int, *connect.Error) {
+	if tag == "" {
+		return int(version), nil
+	}
+	if version != 0 {
+		err := errors.New("'version' and 'tag' can't both be set")
+		return 0, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	vtag, err := root.GetVersionTag(ctx, objectID, tag)
+	if err != nil {
+		return 0, tagError(err)
+	}
+	return vtag.Version, nil
+}
+
+func contentError(err error) *connect.Error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+// readableObjects returns up to limit objects that the user can read, calling
+// list to get pages of objects starting at offset. Pages are read until the
+// limit is reached or there are no more objects. It also returns the offset
+// after the last object read, or 0 if there are no more objects.
+func (s *AccessService) readableObjects(ctx context.Context, limit int, offset int, list func(limit, offset int) ([]chap.ObjectListItem, error)) ([]*chaparralv1.ListObjectsResponse_Item, int, error) {
+	var items []*chaparralv1.ListObjectsResponse_Item
+	for {
+		objects, err := list(limit, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := range objects {
+			items = append(items, s.objectListItems(ctx, objects[i:i+1])...)
+			if len(items) == limit {
+				return items, offset + i + 1, nil
+			}
+		}
+		offset += len(objects)
+		if len(objects) < limit {
+			return items, 0, nil
+		}
+	}
+}
+
+// objectListItems converts objects to protobuf list items, skipping objects
+// the user doesn't have permission to read.
+func (s *AccessService) objectListItems(ctx context.Context, objects []chap.ObjectListItem) []*chaparralv1.ListObjectsResponse_Item {
+	items := make([]*chaparralv1.ListObjectsResponse_Item, 0, len(objects))
+	for _, obj := range objects {
+		if s.auth != nil && !s.auth.Allowed(ctx, ActionReadObject, AuthResource(obj.StorageRootID, obj.ID)) {
+			continue
+		}
+		items = append(items, &chaparralv1.ListObjectsResponse_Item{
+			ObjectId: obj.ID,
+			Path:     obj.Path,
+			Metadata: (*Metadata)(obj.Metadata).AsProto(),
+		})
+	}
+	return items
+}
+
 func (srv *AccessService) DownloadHandler(w http.ResponseWriter, r *http.Request) {
 	var (
 		err         error
@@ -166,11 +980,19 @@ func (srv *AccessService) DownloadHandler(w http.ResponseWriter, r *http.Request
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
-	authResource := AuthResource(storeID, objectID)
-	if srv.auth != nil && !srv.auth.Allowed(ctx, ActionReadObject, authResource) {
-		w.WriteHeader(http.StatusUnauthorized)
-		err = errors.New("you don't have permission to download from the storage root")
-		return
+	if r.URL.Query().Has(chap.QuerySignature) {
+		// signed download URLs don't require authorization
+		if err = srv.verifyDownloadURL(r.URL.Query()); err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+	} else {
+		authResource := AuthResource(storeID, objectID)
+		if srv.auth != nil && !srv.auth.Allowed(ctx, ActionReadObject, authResource) {
+			w.WriteHeader(http.StatusUnauthorized)
+			err = errors.New("you don't have permission to download from the storage root")
+			return
+		}
 	}
 	// make sure storage root's base is initialized
 	if err = root.Ready(ctx); err != nil {
@@ -226,7 +1048,7 @@ func (srv *AccessService) DownloadHandler(w http.ResponseWriter, r *http.Request
 	}
 	defer func() {
 		if closeErr := f.Close(); closeErr != nil {
-			logger.Error("closing file: %w", closeErr)
+			logger.Error("closing file: " + closeErr.Error())
 		}
 	}()
 	info, err := f.Stat()
@@ -1,13 +1,18 @@
 package server_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,6 +23,9 @@ import (
 	"github.com/srerickson/chaparral/gen/chaparral/v1/chaparralv1connect"
 	"github.com/srerickson/chaparral/internal/testutil"
 	"github.com/srerickson/chaparral/server"
+	"github.com/srerickson/chaparral/server/eventlog"
+	"github.com/srerickson/chaparral/server/uploader"
+	"github.com/srerickson/ocfl-go"
 )
 
 // digest from testdata manifest
@@ -200,3 +208,765 @@ func TestAccessServiceHandler(t *testing.T) {
 		be.Equal(t, http.StatusNotFound, resp.StatusCode)
 	})
 }
+
+func TestAccessServiceObjectMetadata(t *testing.T) {
+	ctx := context.Background()
+	objectID := "ark:123/abc"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	store := testutil.NewStoreTempDir(t)
+	be.NilErr(t, store.CopyObject(ctx, fixture, objectID))
+	mux := server.New(server.WithStorageRoots(store),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	httpClient := srv.Client()
+	cli := chap.NewClient(httpClient, srv.URL)
+	testutil.SetUserToken(httpClient, testutil.ManagerUser)
+
+	// the fixture object doesn't have metadata
+	meta, err := cli.GetObjectMetadata(ctx, store.ID(), objectID)
+	be.NilErr(t, err)
+	be.True(t, meta == nil)
+
+	version, err := cli.GetObjectVersion(ctx, store.ID(), objectID, 0)
+	be.NilErr(t, err)
+	expect := &chap.ObjectMetadata{
+		Title:    "A Test Object",
+		Creators: []string{"A Person"},
+		License:  "CC0-1.0",
+		Keywords: []string{"test", "metadata"},
+	}
+	commit := &chap.Commit{
+		To:       chap.ObjectRef{StorageRootID: store.ID(), ID: objectID},
+		Message:  "add metadata",
+		User:     ocfl.User{Name: "Test"},
+		State:    version.State.PathMap(),
+		Alg:      version.DigestAlgorithm,
+		Metadata: expect,
+	}
+	be.NilErr(t, cli.Commit(ctx, commit))
+	meta, err = cli.GetObjectMetadata(ctx, store.ID(), objectID)
+	be.NilErr(t, err)
+	be.DeepEqual(t, expect, meta)
+	// metadata is saved in the new version state
+	version, err = cli.GetObjectVersion(ctx, store.ID(), objectID, 0)
+	be.NilErr(t, err)
+	be.Nonzero(t, version.State.PathMap()[chap.MetadataPath])
+	items, _, err := cli.ListObjects(ctx, store.ID(), 0, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(items))
+	be.Equal(t, objectID, items[0].ID)
+	be.DeepEqual(t, expect, items[0].Metadata)
+	items, _, err = cli.SearchObjects(ctx, store.ID(), "test object", "", 0, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(items))
+	items, _, err = cli.SearchObjects(ctx, store.ID(), "a_file", testDigest, 0, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(items))
+	items, _, err = cli.SearchObjects(ctx, store.ID(), "missing", "", 0, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 0, len(items))
+	_, _, err = cli.SearchObjects(ctx, store.ID(), "", "", 0, 0)
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+
+	// a new version without the metadata file doesn't have metadata
+	state := version.State.PathMap()
+	delete(state, chap.MetadataPath)
+	commit.Message = "remove metadata"
+	commit.State = state
+	commit.Metadata = nil
+	be.NilErr(t, cli.Commit(ctx, commit))
+	meta, err = cli.GetObjectMetadata(ctx, store.ID(), objectID)
+	be.NilErr(t, err)
+	be.True(t, meta == nil)
+
+	// users without read permission can't list objects
+	testutil.SetUserToken(httpClient, testutil.AnonUser)
+	_, _, err = cli.ListObjects(ctx, store.ID(), 0, 0)
+	isConnectErrCode(t, err, connect.CodePermissionDenied)
+	_, _, err = cli.SearchObjects(ctx, store.ID(), "test", "", 0, 0)
+	isConnectErrCode(t, err, connect.CodePermissionDenied)
+}
+
+func TestAccessServiceListObjectsPermissions(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStoreTempDir(t)
+	mgr := uploader.NewManager(store.FS(), "uploads", nil)
+	// members can only read some objects
+	perms := testutil.DefaultRoles(store.ID())
+	perms.Roles[testutil.MemberUser.Roles[0]] = server.Permissions{
+		server.ActionReadObject: []string{
+			server.AuthResource(store.ID(), "obj-b"),
+			server.AuthResource(store.ID(), "obj-d"),
+		},
+	}
+	mux := server.New(server.WithStorageRoots(store),
+		server.WithUploaderManager(mgr),
+		server.WithAuthorizer(perms),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	httpClient := srv.Client()
+	cli := chap.NewClient(httpClient, srv.URL)
+	testutil.SetUserToken(httpClient, testutil.ManagerUser)
+	for _, id := range []string{"obj-a", "obj-b", "obj-c", "obj-d", "obj-e"} {
+		commitFiles(t, cli, store.ID(), id, map[string]string{"file.txt": id})
+	}
+	testutil.SetUserToken(httpClient, testutil.MemberUser)
+	// pages are filled with readable objects
+	items, next, err := cli.ListObjects(ctx, store.ID(), 2, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 2, len(items))
+	be.Equal(t, "obj-b", items[0].ID)
+	be.Equal(t, "obj-d", items[1].ID)
+	be.Equal(t, 4, next)
+	items, next, err = cli.ListObjects(ctx, store.ID(), 2, next)
+	be.NilErr(t, err)
+	be.Equal(t, 0, len(items))
+	be.Equal(t, 0, next)
+	items, next, err = cli.ListObjects(ctx, store.ID(), 1, 2)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(items))
+	be.Equal(t, "obj-d", items[0].ID)
+	be.Equal(t, 4, next)
+	// search results are filled the same way
+	items, next, err = cli.SearchObjects(ctx, store.ID(), "file.txt", "", 1, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(items))
+	be.Equal(t, "obj-b", items[0].ID)
+	be.Equal(t, 2, next)
+	items, next, err = cli.SearchObjects(ctx, store.ID(), "file.txt", "", 2, next)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(items))
+	be.Equal(t, "obj-d", items[0].ID)
+	be.Equal(t, 0, next)
+}
+
+func TestAccessServiceFindContentByDigest(t *testing.T) {
+	ctx := context.Background()
+	objectID := "ark:123/abc"
+	copyID := "ark:123/copy"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	store := testutil.NewStoreTempDir(t)
+	be.NilErr(t, store.CopyObject(ctx, fixture, objectID))
+	mux := server.New(server.WithStorageRoots(store),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	httpClient := srv.Client()
+	cli := chap.NewClient(httpClient, srv.URL)
+	testutil.SetUserToken(httpClient, testutil.ManagerUser)
+
+	// new object with the same content from the fixture
+	be.NilErr(t, cli.Commit(ctx, &chap.Commit{
+		To:             chap.ObjectRef{StorageRootID: store.ID(), ID: copyID},
+		Message:        "copy content",
+		User:           ocfl.User{Name: "Test"},
+		State:          map[string]string{"dir/copy.txt": testDigest},
+		Alg:            "sha512",
+		ContentSources: []any{chap.ObjectRef{StorageRootID: store.ID(), ID: objectID}},
+	}))
+	locs, err := cli.FindContentByDigest(ctx, store.ID(), testDigest)
+	be.NilErr(t, err)
+	be.Equal(t, 2, len(locs))
+	be.Equal(t, objectID, locs[0].ID)
+	be.DeepEqual(t, []string{"v1/content/a_file.txt"}, locs[0].ContentPaths)
+	be.DeepEqual(t, []chap.VersionPaths{{Version: 1, Paths: []string{"a_file.txt"}}}, locs[0].Versions)
+	be.Equal(t, copyID, locs[1].ID)
+	be.DeepEqual(t, []string{"v1/content/dir/copy.txt"}, locs[1].ContentPaths)
+	be.DeepEqual(t, []chap.VersionPaths{{Version: 1, Paths: []string{"dir/copy.txt"}}}, locs[1].Versions)
+
+	locs, err = cli.FindContentByDigest(ctx, store.ID(), "missing")
+	be.NilErr(t, err)
+	be.Equal(t, 0, len(locs))
+	_, err = cli.FindContentByDigest(ctx, store.ID(), "")
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+	testutil.SetUserToken(httpClient, testutil.AnonUser)
+	_, err = cli.FindContentByDigest(ctx, store.ID(), testDigest)
+	isConnectErrCode(t, err, connect.CodePermissionDenied)
+}
+
+func TestAccessServiceDownloadURL(t *testing.T) {
+	ctx := context.Background()
+	objectID := "ark:123/abc"
+	store := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	mux := server.New(server.WithStorageRoots(store),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()),
+		server.WithDownloadKey([]byte("secret")))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	httpClient := srv.Client()
+	cli := chap.NewClient(httpClient, srv.URL)
+	download := func(t *testing.T, u string) (int, string) {
+		t.Helper()
+		resp, err := httpClient.Get(u)
+		be.NilErr(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		be.NilErr(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	testutil.SetUserToken(httpClient, testutil.MemberUser)
+	byDigest, err := cli.CreateDownloadURL(ctx, store.ID(), objectID, testDigest, "", time.Minute, false)
+	be.NilErr(t, err)
+	be.False(t, byDigest.Direct)
+	be.True(t, byDigest.Expires.After(time.Now()))
+	be.True(t, byDigest.Expires.Before(time.Now().Add(time.Minute+time.Second)))
+	byPath, err := cli.CreateDownloadURL(ctx, store.ID(), objectID, "", "v1/content/a_file.txt", 0, true)
+	be.NilErr(t, err)
+	be.False(t, byPath.Direct) // local backend doesn't support presigned URLs
+	be.True(t, byPath.Expires.After(time.Now().Add(59*time.Minute)))
+
+	// signed URLs don't require authorization
+	testutil.SetUserToken(httpClient, testutil.AnonUser)
+	for _, u := range []string{byDigest.URL, byPath.URL} {
+		code, body := download(t, u)
+		be.Equal(t, http.StatusOK, code)
+		be.Equal(t, contentLength, len(body))
+	}
+	// tampered URL
+	tampered, err := url.Parse(byDigest.URL)
+	be.NilErr(t, err)
+	vals := tampered.Query()
+	vals.Set(chap.QueryExpires, fmt.Sprint(time.Now().Add(time.Hour).Unix()))
+	tampered.RawQuery = vals.Encode()
+	code, _ := download(t, tampered.String())
+	be.Equal(t, http.StatusForbidden, code)
+	// without signature, the request isn't authorized
+	vals.Del(chap.QuerySignature)
+	tampered.RawQuery = vals.Encode()
+	code, _ = download(t, tampered.String())
+	be.Equal(t, http.StatusUnauthorized, code)
+
+	// anonymous users can't create URLs
+	_, err = cli.CreateDownloadURL(ctx, store.ID(), objectID, testDigest, "", 0, false)
+	isConnectErrCode(t, err, connect.CodePermissionDenied)
+
+	testutil.SetUserToken(httpClient, testutil.MemberUser)
+	_, err = cli.CreateDownloadURL(ctx, store.ID(), objectID, "missing", "", 0, false)
+	isConnectErrCode(t, err, connect.CodeNotFound)
+	_, err = cli.CreateDownloadURL(ctx, store.ID(), objectID, "", "v1/content/missing.txt", 0, false)
+	isConnectErrCode(t, err, connect.CodeNotFound)
+	_, err = cli.CreateDownloadURL(ctx, store.ID(), objectID, "", "../a_file.txt", 0, false)
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+	_, err = cli.CreateDownloadURL(ctx, store.ID(), objectID, "", "", 0, false)
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+	_, err = cli.CreateDownloadURL(ctx, store.ID(), objectID, testDigest, "", 8*24*time.Hour, false)
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+
+	t.Run("without download key", func(t *testing.T) {
+		mux := server.New(server.WithStorageRoots(store))
+		srv := httptest.NewTLSServer(mux)
+		defer srv.Close()
+		cli := chap.NewClient(srv.Client(), srv.URL)
+		_, err := cli.CreateDownloadURL(ctx, store.ID(), objectID, testDigest, "", 0, false)
+		isConnectErrCode(t, err, connect.CodeUnimplemented)
+	})
+}
+
+func TestAccessServiceWatchEvents(t *testing.T) {
+	ctx := context.Background()
+	objectID := "ark:123/abc"
+	otherID := "other"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	store := testutil.NewStoreTempDir(t)
+	otherStore := testutil.NewStoreTempDirID(t, otherID)
+	be.NilErr(t, otherStore.CopyObject(ctx, fixture, objectID))
+	mux := server.New(server.WithStorageRoots(store, otherStore),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()),
+		server.WithEventLog(eventlog.NewLog(testutil.TestDB(t), eventlog.Config{})))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	// separate http clients for watching and for making changes
+	watchClient := &http.Client{Transport: srv.Client().Transport}
+	httpClient := srv.Client()
+	cli := chap.NewClient(httpClient, srv.URL)
+	watchCli := chap.NewClient(watchClient, srv.URL)
+	errStop := errors.New("stop")
+	// first returns the first event received from the watch
+	first := func(t *testing.T, opts chap.WatchOptions) chap.ObjectEvent {
+		t.Helper()
+		var event chap.ObjectEvent
+		err := watchCli.WatchEvents(ctx, opts, func(e chap.ObjectEvent) error {
+			event = e
+			return errStop
+		})
+		be.True(t, errors.Is(err, errStop))
+		return event
+	}
+
+	// copy the object to the test storage root and delete it from the other
+	testutil.SetUserToken(httpClient, testutil.AdminUser)
+	be.NilErr(t, cli.CopyObject(ctx, otherID, objectID, store.ID()))
+	be.NilErr(t, cli.DeleteObject(ctx, otherID, objectID))
+
+	t.Run("catch up", func(t *testing.T) {
+		testutil.SetUserToken(watchClient, testutil.AdminUser)
+		event := first(t, chap.WatchOptions{Cursor: "0"})
+		be.Equal(t, eventlog.ObjectCommitted, event.Type)
+		be.Equal(t, store.ID(), event.StorageRootID)
+		be.Equal(t, objectID, event.ObjectID)
+		be.Equal(t, testutil.AdminUser.ID, event.UserID)
+		be.Nonzero(t, event.ID)
+		be.Nonzero(t, event.Cursor)
+		be.False(t, event.Created.IsZero())
+		event = first(t, chap.WatchOptions{Cursor: event.Cursor})
+		be.Equal(t, eventlog.ObjectDeleted, event.Type)
+		be.Equal(t, otherID, event.StorageRootID)
+	})
+	t.Run("filters", func(t *testing.T) {
+		testutil.SetUserToken(watchClient, testutil.AdminUser)
+		event := first(t, chap.WatchOptions{Cursor: "0", StorageRootIDs: []string{otherID}})
+		be.Equal(t, eventlog.ObjectDeleted, event.Type)
+		// no events for the prefix: the watch ends when the context is done
+		timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		err := watchCli.WatchEvents(timeout, chap.WatchOptions{
+			Cursor:           "0",
+			ObjectIDPrefixes: []string{"ark:456/"},
+		}, func(e chap.ObjectEvent) error {
+			return fmt.Errorf("unexpected event for %q", e.ObjectID)
+		})
+		be.True(t, err != nil)
+		be.False(t, errors.Is(err, errStop))
+		be.Equal(t, connect.CodeDeadlineExceeded, connect.CodeOf(err))
+	})
+	t.Run("resume", func(t *testing.T) {
+		// members can't read objects in the other storage root
+		testutil.SetUserToken(watchClient, testutil.MemberUser)
+		event := first(t, chap.WatchOptions{Cursor: "0", ObjectIDPrefixes: []string{"ark:123/"}})
+		be.Equal(t, eventlog.ObjectCommitted, event.Type)
+		result := make(chan chap.ObjectEvent, 1)
+		go func() {
+			watchCli.WatchEvents(ctx, chap.WatchOptions{Cursor: event.Cursor}, func(e chap.ObjectEvent) error {
+				result <- e
+				return errStop
+			})
+		}()
+		testutil.SetUserToken(httpClient, testutil.ManagerUser)
+		be.NilErr(t, cli.DeleteObject(ctx, store.ID(), objectID))
+		select {
+		case event = <-result:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+		be.Equal(t, eventlog.ObjectDeleted, event.Type)
+		be.Equal(t, store.ID(), event.StorageRootID)
+		be.Equal(t, testutil.ManagerUser.ID, event.UserID)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		testutil.SetUserToken(watchClient, testutil.MemberUser)
+		err := watchCli.WatchEvents(ctx, chap.WatchOptions{Cursor: "bad"}, nil)
+		isConnectErrCode(t, err, connect.CodeInvalidArgument)
+		err = watchCli.WatchEvents(ctx, chap.WatchOptions{StorageRootIDs: []string{"missing"}}, nil)
+		isConnectErrCode(t, err, connect.CodeNotFound)
+	})
+	t.Run("no event log", func(t *testing.T) {
+		srv := httptest.NewTLSServer(server.New(server.WithStorageRoots(store)))
+		defer srv.Close()
+		cli := chap.NewClient(srv.Client(), srv.URL)
+		err := cli.WatchEvents(ctx, chap.WatchOptions{}, nil)
+		isConnectErrCode(t, err, connect.CodeUnimplemented)
+	})
+}
+
+func TestAccessServiceGetContent(t *testing.T) {
+	ctx := context.Background()
+	objectID := "ark:123/abc"
+	largeID := "large-object"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	store := testutil.NewStoreTempDir(t)
+	be.NilErr(t, store.CopyObject(ctx, fixture, objectID))
+	mgr := uploader.NewManager(store.FS(), "uploads", nil)
+	mux := server.New(server.WithStorageRoots(store),
+		server.WithUploaderManager(mgr),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	httpClient := srv.Client()
+	cli := chap.NewClient(httpClient, srv.URL)
+	testutil.SetUserToken(httpClient, testutil.ManagerUser)
+	// content sent in several chunks
+	large := make([]byte, 600_000)
+	_, err := rand.Read(large)
+	be.NilErr(t, err)
+	up, err := cli.NewUploader(ctx, []string{ocfl.SHA256}, "get content test")
+	be.NilErr(t, err)
+	upload, err := cli.UploadStream(ctx, up.ID, bytes.NewReader(large))
+	be.NilErr(t, err)
+	be.NilErr(t, cli.Commit(ctx, &chap.Commit{
+		To:             chap.ObjectRef{StorageRootID: store.ID(), ID: largeID},
+		Message:        "large file",
+		User:           ocfl.User{Name: "Test"},
+		State:          map[string]string{"large.dat": upload.Digests[ocfl.SHA256]},
+		Alg:            ocfl.SHA256,
+		ContentSources: []any{up.UploaderRef},
+	}))
+	testutil.SetUserToken(httpClient, testutil.MemberUser)
+	get := func(t *testing.T, objectID string, opts chap.ContentOptions) (*chap.Content, []byte) {
+		t.Helper()
+		cont, err := cli.GetContentStream(ctx, store.ID(), objectID, opts)
+		be.NilErr(t, err)
+		defer cont.Close()
+		byts, err := io.ReadAll(cont)
+		be.NilErr(t, err)
+		return cont, byts
+	}
+
+	t.Run("by digest", func(t *testing.T) {
+		cont, byts := get(t, objectID, chap.ContentOptions{Digest: testDigest})
+		be.Equal(t, contentLength, cont.Size)
+		be.Equal(t, contentLength, len(byts))
+		be.Equal(t, testDigest, cont.Digest)
+		be.Equal(t, ocfl.SHA512, cont.DigestAlgorithm)
+		// same content as the download route
+		httpCont, err := cli.GetContent(ctx, store.ID(), objectID, testDigest)
+		be.NilErr(t, err)
+		defer httpCont.Close()
+		httpBytes, err := io.ReadAll(httpCont)
+		be.NilErr(t, err)
+		be.Equal(t, string(httpBytes), string(byts))
+	})
+	t.Run("by content path", func(t *testing.T) {
+		cont, byts := get(t, objectID, chap.ContentOptions{ContentPath: "v1/content/a_file.txt"})
+		be.Equal(t, testDigest, cont.Digest)
+		be.Equal(t, contentLength, len(byts))
+	})
+	t.Run("by logical path", func(t *testing.T) {
+		cont, byts := get(t, objectID, chap.ContentOptions{LogicalPath: "a_file.txt", Version: 1})
+		be.Equal(t, testDigest, cont.Digest)
+		be.Equal(t, contentLength, len(byts))
+		cont, byts = get(t, largeID, chap.ContentOptions{LogicalPath: "large.dat"})
+		be.Equal(t, upload.Digests[ocfl.SHA256], cont.Digest)
+		be.Equal(t, int64(len(large)), cont.Size)
+		be.True(t, bytes.Equal(large, byts))
+	})
+	t.Run("by tag", func(t *testing.T) {
+		testutil.SetUserToken(httpClient, testutil.ManagerUser)
+		_, err := cli.TagVersion(ctx, store.ID(), objectID, "first", 1)
+		be.NilErr(t, err)
+		testutil.SetUserToken(httpClient, testutil.MemberUser)
+		cont, byts := get(t, objectID, chap.ContentOptions{LogicalPath: "a_file.txt", Tag: "first"})
+		be.Equal(t, testDigest, cont.Digest)
+		be.Equal(t, contentLength, len(byts))
+	})
+	t.Run("close early", func(t *testing.T) {
+		cont, err := cli.GetContentStream(ctx, store.ID(), largeID, chap.ContentOptions{LogicalPath: "large.dat"})
+		be.NilErr(t, err)
+		buf := make([]byte, 10)
+		_, err = io.ReadFull(cont, buf)
+		be.NilErr(t, err)
+		be.True(t, bytes.Equal(large[:10], buf))
+		be.NilErr(t, cont.Close())
+	})
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			desc     string
+			objectID string
+			opts     chap.ContentOptions
+			code     connect.Code
+		}{
+			{desc: "no content", objectID: objectID, code: connect.CodeInvalidArgument},
+			{desc: "digest and path", objectID: objectID, opts: chap.ContentOptions{Digest: testDigest, LogicalPath: "a_file.txt"}, code: connect.CodeInvalidArgument},
+			{desc: "missing object", objectID: "missing", opts: chap.ContentOptions{Digest: testDigest}, code: connect.CodeNotFound},
+			{desc: "missing digest", objectID: objectID, opts: chap.ContentOptions{Digest: "missing"}, code: connect.CodeNotFound},
+			{desc: "missing content path", objectID: objectID, opts: chap.ContentOptions{ContentPath: "v1/content/missing"}, code: connect.CodeNotFound},
+			{desc: "missing logical path", objectID: objectID, opts: chap.ContentOptions{LogicalPath: "missing"}, code: connect.CodeNotFound},
+			{desc: "missing version", objectID: objectID, opts: chap.ContentOptions{LogicalPath: "a_file.txt", Version: 9}, code: connect.CodeNotFound},
+			{desc: "missing tag", objectID: objectID, opts: chap.ContentOptions{LogicalPath: "a_file.txt", Tag: "missing"}, code: connect.CodeNotFound},
+			{desc: "version and tag", objectID: objectID, opts: chap.ContentOptions{LogicalPath: "a_file.txt", Version: 1, Tag: "first"}, code: connect.CodeInvalidArgument},
+		}
+		for _, tcase := range tests {
+			t.Run(tcase.desc, func(t *testing.T) {
+				_, err := cli.GetContentStream(ctx, store.ID(), tcase.objectID, tcase.opts)
+				isConnectErrCode(t, err, tcase.code)
+			})
+		}
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		testutil.SetUserToken(httpClient, testutil.AnonUser)
+		_, err := cli.GetContentStream(ctx, store.ID(), objectID, chap.ContentOptions{Digest: testDigest})
+		isConnectErrCode(t, err, connect.CodePermissionDenied)
+	})
+}
+
+func TestAccessServiceListManifestAndState(t *testing.T) {
+	ctx := context.Background()
+	objectID := "paged-object"
+	store := testutil.NewStoreTempDir(t)
+	mgr := uploader.NewManager(store.FS(), "uploads", nil)
+	mux := server.New(server.WithStorageRoots(store),
+		server.WithUploaderManager(mgr),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	httpClient := srv.Client()
+	cli := chap.NewClient(httpClient, srv.URL)
+	testutil.SetUserToken(httpClient, testutil.ManagerUser)
+	commitFiles(t, cli, store.ID(), objectID, map[string]string{
+		"a.txt":         "content a",
+		"dir/b.txt":     "content b",
+		"dir/c.txt":     "content a",
+		"dir/sub/d.txt": "content d",
+		"e.txt":         "content e",
+	})
+	commitFiles(t, cli, store.ID(), objectID, map[string]string{
+		"a.txt": "content a",
+		"f.txt": "content f",
+	})
+	testutil.SetUserToken(httpClient, testutil.MemberUser)
+
+	t.Run("manifest", func(t *testing.T) {
+		expect, err := cli.GetObjectManifest(ctx, store.ID(), objectID)
+		be.NilErr(t, err)
+		var digests []string
+		err = cli.ListObjectManifest(ctx, store.ID(), objectID, "", 2, func(item chap.ManifestItem) error {
+			digests = append(digests, item.Digest)
+			be.DeepEqual(t, expect.Manifest[item.Digest], item.FileInfo)
+			return nil
+		})
+		be.NilErr(t, err)
+		be.Equal(t, len(expect.Manifest), len(digests))
+		be.True(t, slices.IsSorted(digests))
+		// items with a logical path that has the prefix
+		list := func(t *testing.T, prefix string) []string {
+			t.Helper()
+			var paths []string
+			err := cli.ListObjectManifest(ctx, store.ID(), objectID, prefix, 1, func(item chap.ManifestItem) error {
+				paths = append(paths, item.Paths...)
+				return nil
+			})
+			be.NilErr(t, err)
+			slices.Sort(paths)
+			return paths
+		}
+		// "content a" is stored twice in v1: items include all of their paths
+		be.DeepEqual(t, []string{"v1/content/a.txt", "v1/content/dir/b.txt", "v1/content/dir/c.txt", "v1/content/dir/sub/d.txt"}, list(t, "dir/"))
+		be.DeepEqual(t, []string{"v2/content/f.txt"}, list(t, "f"))
+		be.Equal(t, 0, len(list(t, "v1/")))
+		be.Equal(t, 0, len(list(t, "missing/")))
+	})
+	t.Run("state", func(t *testing.T) {
+		list := func(t *testing.T, ver int, prefix string) []string {
+			t.Helper()
+			var paths []string
+			err := cli.ListVersionState(ctx, store.ID(), objectID, ver, prefix, 2, func(item chap.StateItem) error {
+				paths = append(paths, item.Path)
+				return nil
+			})
+			be.NilErr(t, err)
+			return paths
+		}
+		be.DeepEqual(t, []string{"a.txt", "f.txt"}, list(t, 0, ""))
+		be.DeepEqual(t, []string{"a.txt", "dir/b.txt", "dir/c.txt", "dir/sub/d.txt", "e.txt"}, list(t, 1, ""))
+		be.DeepEqual(t, []string{"dir/b.txt", "dir/c.txt", "dir/sub/d.txt"}, list(t, 1, "dir/"))
+		be.DeepEqual(t, []string{"dir/sub/d.txt"}, list(t, 1, "dir/sub"))
+		be.Equal(t, 0, len(list(t, 1, "missing/")))
+		// digests match the version state
+		ver, err := cli.GetObjectVersion(ctx, store.ID(), objectID, 1)
+		be.NilErr(t, err)
+		paths := ver.State.PathMap()
+		err = cli.ListVersionState(ctx, store.ID(), objectID, 1, "", 0, func(item chap.StateItem) error {
+			be.Equal(t, paths[item.Path], item.Digest)
+			be.Equal(t, int64(len("content a")), item.Size)
+			return nil
+		})
+		be.NilErr(t, err)
+	})
+	t.Run("stop early", func(t *testing.T) {
+		errStop := errors.New("stop")
+		var count int
+		err := cli.ListVersionState(ctx, store.ID(), objectID, 1, "", 2, func(chap.StateItem) error {
+			count++
+			if count == 3 {
+				return errStop
+			}
+			return nil
+		})
+		be.True(t, errors.Is(err, errStop))
+		be.Equal(t, 3, count)
+	})
+	t.Run("errors", func(t *testing.T) {
+		noop := func(chap.StateItem) error { return nil }
+		err := cli.ListVersionState(ctx, store.ID(), objectID, 9, "", 0, noop)
+		isConnectErrCode(t, err, connect.CodeNotFound)
+		err = cli.ListVersionState(ctx, store.ID(), objectID, -1, "", 0, noop)
+		isConnectErrCode(t, err, connect.CodeInvalidArgument)
+		err = cli.ListVersionState(ctx, store.ID(), objectID, 0, "", 10001, noop)
+		isConnectErrCode(t, err, connect.CodeInvalidArgument)
+		err = cli.ListObjectManifest(ctx, store.ID(), "missing", "", 0, func(chap.ManifestItem) error { return nil })
+		isConnectErrCode(t, err, connect.CodeNotFound)
+		err = cli.ListObjectManifest(ctx, store.ID(), objectID, "", -1, func(chap.ManifestItem) error { return nil })
+		isConnectErrCode(t, err, connect.CodeInvalidArgument)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		testutil.SetUserToken(httpClient, testutil.AnonUser)
+		err := cli.ListObjectManifest(ctx, store.ID(), objectID, "", 0, func(chap.ManifestItem) error { return nil })
+		isConnectErrCode(t, err, connect.CodePermissionDenied)
+		err = cli.ListVersionState(ctx, store.ID(), objectID, 0, "", 0, func(chap.StateItem) error { return nil })
+		isConnectErrCode(t, err, connect.CodePermissionDenied)
+	})
+}
+
+func TestAccessServiceListVersionPath(t *testing.T) {
+	ctx := context.Background()
+	objectID := "dir-object"
+	store := testutil.NewStoreTempDir(t)
+	mgr := uploader.NewManager(store.FS(), "uploads", nil)
+	mux := server.New(server.WithStorageRoots(store),
+		server.WithUploaderManager(mgr),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	httpClient := srv.Client()
+	cli := chap.NewClient(httpClient, srv.URL)
+	testutil.SetUserToken(httpClient, testutil.ManagerUser)
+	commitFiles(t, cli, store.ID(), objectID, map[string]string{
+		"a.txt":         "aaa",
+		"dir/b.txt":     "bbbb",
+		"dir/c.txt":     "aaa",
+		"dir/sub/d.txt": "ddddd",
+	})
+	commitFiles(t, cli, store.ID(), objectID, map[string]string{
+		"a.txt": "aaa",
+	})
+	testutil.SetUserToken(httpClient, testutil.MemberUser)
+
+	t.Run("top-level", func(t *testing.T) {
+		for _, dir := range []string{"", "."} {
+			vp, err := cli.ListVersionPath(ctx, store.ID(), objectID, 1, dir)
+			be.NilErr(t, err)
+			be.Equal(t, 1, vp.Version)
+			be.Equal(t, ocfl.SHA256, vp.DigestAlgorithm)
+			be.Equal(t, "", vp.Path)
+			be.Equal(t, 15, vp.Size)
+			be.Equal(t, 4, vp.FileCount)
+			be.Equal(t, 2, len(vp.Entries))
+			be.Equal(t, chap.VersionPathEntry{Name: "dir", IsDir: true, Size: 12, FileCount: 3}, vp.Entries[1])
+			file := vp.Entries[0]
+			be.Equal(t, "a.txt", file.Name)
+			be.False(t, file.IsDir)
+			be.Nonzero(t, file.Digest)
+			be.Equal(t, 3, file.Size)
+			be.Equal(t, 1, file.FileCount)
+		}
+	})
+	t.Run("subdirectory", func(t *testing.T) {
+		for _, dir := range []string{"dir", "dir/"} {
+			vp, err := cli.ListVersionPath(ctx, store.ID(), objectID, 1, dir)
+			be.NilErr(t, err)
+			be.Equal(t, "dir", vp.Path)
+			be.Equal(t, 12, vp.Size)
+			be.Equal(t, 3, vp.FileCount)
+			var names []string
+			for _, entry := range vp.Entries {
+				names = append(names, entry.Name)
+			}
+			be.DeepEqual(t, []string{"b.txt", "c.txt", "sub"}, names)
+			be.True(t, vp.Entries[2].IsDir)
+			be.Equal(t, 5, vp.Entries[2].Size)
+		}
+	})
+	t.Run("head", func(t *testing.T) {
+		vp, err := cli.ListVersionPath(ctx, store.ID(), objectID, 0, "")
+		be.NilErr(t, err)
+		be.Equal(t, 2, vp.Version)
+		be.Equal(t, 1, len(vp.Entries))
+		be.Equal(t, 3, vp.Size)
+	})
+	t.Run("tag", func(t *testing.T) {
+		testutil.SetUserToken(httpClient, testutil.ManagerUser)
+		_, err := cli.TagVersion(ctx, store.ID(), objectID, "first", 1)
+		be.NilErr(t, err)
+		testutil.SetUserToken(httpClient, testutil.MemberUser)
+		vp, err := cli.ListTaggedVersionPath(ctx, store.ID(), objectID, "first", "dir")
+		be.NilErr(t, err)
+		be.Equal(t, 1, vp.Version)
+		be.Equal(t, 3, len(vp.Entries))
+		var paths []string
+		err = cli.ListTaggedVersionState(ctx, store.ID(), objectID, "first", "dir/", 1, func(item chap.StateItem) error {
+			paths = append(paths, item.Path)
+			return nil
+		})
+		be.NilErr(t, err)
+		be.DeepEqual(t, []string{"dir/b.txt", "dir/c.txt", "dir/sub/d.txt"}, paths)
+		_, err = cli.ListTaggedVersionPath(ctx, store.ID(), objectID, "missing", "")
+		isConnectErrCode(t, err, connect.CodeNotFound)
+		err = cli.ListTaggedVersionState(ctx, store.ID(), objectID, "missing", "", 0, func(chap.StateItem) error { return nil })
+		isConnectErrCode(t, err, connect.CodeNotFound)
+	})
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			desc     string
+			objectID string
+			ver      int
+			dir      string
+			code     connect.Code
+		}{
+			{desc: "missing object", objectID: "missing", code: connect.CodeNotFound},
+			{desc: "missing version", objectID: objectID, ver: 9, code: connect.CodeNotFound},
+			{desc: "missing directory", objectID: objectID, ver: 1, dir: "missing", code: connect.CodeNotFound},
+			{desc: "file", objectID: objectID, ver: 1, dir: "dir/b.txt", code: connect.CodeInvalidArgument},
+			{desc: "invalid path", objectID: objectID, ver: 1, dir: "../dir", code: connect.CodeInvalidArgument},
+			{desc: "negative version", objectID: objectID, ver: -1, code: connect.CodeInvalidArgument},
+		}
+		for _, tcase := range tests {
+			t.Run(tcase.desc, func(t *testing.T) {
+				_, err := cli.ListVersionPath(ctx, store.ID(), tcase.objectID, tcase.ver, tcase.dir)
+				isConnectErrCode(t, err, tcase.code)
+			})
+		}
+	})
+	t.Run("too many entries", func(t *testing.T) {
+		files := map[string][]byte{}
+		for i := 0; i <= 10000; i++ {
+			files[fmt.Sprintf("big/%05d.txt", i)] = []byte("content")
+		}
+		stage, err := ocfl.StageBytes(files, ocfl.SHA256)
+		be.NilErr(t, err)
+		be.NilErr(t, store.Commit(ctx, "big-object", stage, nil))
+		_, err = cli.ListVersionPath(ctx, store.ID(), "big-object", 0, "big")
+		isConnectErrCode(t, err, connect.CodeResourceExhausted)
+		// the parent directory can be listed
+		vp, err := cli.ListVersionPath(ctx, store.ID(), "big-object", 0, "")
+		be.NilErr(t, err)
+		be.Equal(t, 1, len(vp.Entries))
+		be.Equal(t, 10001, vp.Entries[0].FileCount)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		testutil.SetUserToken(httpClient, testutil.AnonUser)
+		_, err := cli.ListVersionPath(ctx, store.ID(), objectID, 0, "")
+		isConnectErrCode(t, err, connect.CodePermissionDenied)
+	})
+}
+
+// commitFiles commits a new version of the object with the files, which map
+// logical paths to their contents.
+func commitFiles(t *testing.T, cli *chap.Client, storeID string, objectID string, files map[string]string) {
+	t.Helper()
+	ctx := context.Background()
+	up, err := cli.NewUploader(ctx, []string{ocfl.SHA256}, "test files")
+	be.NilErr(t, err)
+	state := map[string]string{}
+	for name, content := range files {
+		upload, err := cli.UploadStream(ctx, up.ID, strings.NewReader(content))
+		be.NilErr(t, err)
+		state[name] = upload.Digests[ocfl.SHA256]
+	}
+	be.NilErr(t, cli.Commit(ctx, &chap.Commit{
+		To:             chap.ObjectRef{StorageRootID: storeID, ID: objectID},
+		Message:        "test files",
+		User:           ocfl.User{Name: "Test"},
+		State:          state,
+		Alg:            ocfl.SHA256,
+		ContentSources: []any{up.UploaderRef},
+	}))
+}
@@ -0,0 +1,358 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/bufbuild/connect-go"
+	chap "github.com/srerickson/chaparral"
+	chaparralv1 "github.com/srerickson/chaparral/gen/chaparral/v1"
+	"github.com/srerickson/chaparral/gen/chaparral/v1/chaparralv1connect"
+	"github.com/srerickson/chaparral/server/store"
+	"github.com/srerickson/chaparral/server/webhook"
+	"github.com/srerickson/ocfl-go/ocflv1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultAuditResultsLimit = 1000
+	defaultDedupReportLimit  = 100
+	defaultDeliveriesLimit   = 1000
+)
+
+// AdminService implements chaparral.v1.AdminService
+type AdminService struct {
+	*chaparral
+}
+
+func (s *AdminService) Handler() (string, http.Handler) {
+	opts := []connect.HandlerOption{}
+	if s.auth != nil {
+		opts = append(opts, connect.WithInterceptors(s.AuthorizeInterceptor()))
+	}
+	return chaparralv1connect.NewAdminServiceHandler(s, opts...)
+}
+
+// ListStorageRoots returns information about the storage roots for which the
+// user has the admin permission. Stats are only included if the request asks
+// for them, since they require walking the storage roots.
+func (s *AdminService) ListStorageRoots(ctx context.Context, req *connect.Request[chaparralv1.ListStorageRootsRequest]) (*connect.Response[chaparralv1.ListStorageRootsResponse], error) {
+	logger := LoggerFromCtx(ctx)
+	ids := make([]string, 0, len(s.roots))
+	for id := range s.roots {
+		if s.auth != nil && !s.auth.Allowed(ctx, ActionAdmin, AuthResource(id, "*")) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	resp := &chaparralv1.ListStorageRootsResponse{
+		StorageRoots: make([]*chaparralv1.ListStorageRootsResponse_Item, len(ids)),
+	}
+	for i, id := range ids {
+		root := s.roots[id]
+		item := &chaparralv1.ListStorageRootsResponse_Item{
+			Id:          id,
+			Path:        root.Path(),
+			Description: root.Description(),
+			Spec:        string(root.Spec()),
+			Layout:      root.LayoutName(),
+		}
+		if req.Msg.IncludeStats {
+			stats, err := root.Stats(ctx)
+			if err != nil {
+				logger.Error("getting storage root stats", "storage_root", id, "err", err.Error())
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			item.ObjectCount = stats.ObjectCount
+			item.TotalBytes = stats.TotalBytes
+		}
+		resp.StorageRoots[i] = item
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ValidateStorageRoot validates the storage root and all its objects. Errors
+// and warnings are sent to the stream as they are found.
+func (s *AdminService) ValidateStorageRoot(ctx context.Context, req *connect.Request[chaparralv1.ValidateStorageRootRequest], stream *connect.ServerStream[chaparralv1.ValidateStorageRootResponse]) error {
+	logger := LoggerFromCtx(ctx).With(chap.QueryStorageRoot, req.Msg.StorageRootId)
+	authResource := AuthResource(req.Msg.StorageRootId, "*")
+	if s.auth != nil && !s.auth.Allowed(ctx, ActionAdmin, authResource) {
+		err := errors.New("you don't have permission to validate the storage root")
+		return connect.NewError(connect.CodePermissionDenied, err)
+	}
+	root, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	findings := &findingHandler{stream: stream, state: &findingState{}}
+	opts := []ocflv1.ValidationOption{
+		ocflv1.ValidationLogger(slog.New(findings)),
+		ocflv1.ValidationMaxErrs(-1),
+	}
+	if req.Msg.SkipDigests {
+		opts = append(opts, ocflv1.SkipDigests())
+	}
+	if _, err := root.Validate(ctx, opts...); err != nil {
+		logger.Error("validating storage root: " + err.Error())
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if err := findings.err(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReindexStorageRoot rebuilds the object cache for the storage root.
+func (s *AdminService) ReindexStorageRoot(ctx context.Context, req *connect.Request[chaparralv1.ReindexStorageRootRequest]) (*connect.Response[chaparralv1.ReindexStorageRootResponse], error) {
+	logger := LoggerFromCtx(ctx).With(chap.QueryStorageRoot, req.Msg.StorageRootId)
+	root, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	progress := func(objPath string, err error, _ store.ReindexStats) {
+		if err != nil {
+			logger.Warn("reindex: couldn't read object", "object_path", objPath, "err", err.Error())
+		}
+	}
+	stats, err := root.Reindex(ctx, progress)
+	if err != nil {
+		logger.Error("reindexing storage root: " + err.Error())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	logger.Info("storage root reindexed",
+		"indexed", stats.Indexed,
+		"failed", stats.Failed,
+		"skipped", stats.Skipped,
+		"removed", stats.Removed)
+	return connect.NewResponse(&chaparralv1.ReindexStorageRootResponse{
+		Objects: int64(stats.Indexed),
+		Failed:  int64(stats.Failed),
+		Skipped: int64(stats.Skipped),
+		Removed: int64(stats.Removed),
+	}), nil
+}
+
+// ListAuditResults returns results from fixity audits for objects in a
+// storage root.
+func (s *AdminService) ListAuditResults(ctx context.Context, req *connect.Request[chaparralv1.ListAuditResultsRequest]) (*connect.Response[chaparralv1.ListAuditResultsResponse], error) {
+	logger := LoggerFromCtx(ctx).With(chap.QueryStorageRoot, req.Msg.StorageRootId)
+	if s.auditor == nil {
+		err := errors.New("the server is not configured for fixity audits")
+		return nil, connect.NewError(connect.CodeUnimplemented, err)
+	}
+	if _, err := s.storageRoot(req.Msg.StorageRootId); err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	limit := int(req.Msg.Limit)
+	if limit < 1 {
+		limit = defaultAuditResultsLimit
+	}
+	if req.Msg.Offset < 0 {
+		err := errors.New("offset must not be negative")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	results, err := s.auditor.Results(ctx, req.Msg.StorageRootId, req.Msg.FailedOnly, limit, int(req.Msg.Offset))
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp := &chaparralv1.ListAuditResultsResponse{
+		Results: make([]*chaparralv1.ListAuditResultsResponse_Item, len(results)),
+	}
+	for i, r := range results {
+		resp.Results[i] = &chaparralv1.ListAuditResultsResponse_Item{
+			StorageRootId: r.StorageRootID,
+			Path:          r.Path,
+			ObjectId:      r.ObjectID,
+			Checked:       timestamppb.New(r.Checked),
+			Valid:         r.Valid(),
+			Errors:        r.Errors,
+			Warnings:      r.Warnings,
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *AdminService) GetDedupReport(ctx context.Context, req *connect.Request[chaparralv1.GetDedupReportRequest]) (*connect.Response[chaparralv1.GetDedupReportResponse], error) {
+	logger := LoggerFromCtx(ctx).With(chap.QueryStorageRoot, req.Msg.StorageRootId)
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	limit := int(req.Msg.Limit)
+	if limit < 1 {
+		limit = defaultDedupReportLimit
+	}
+	report, err := store.DedupReport(ctx, limit)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp := &chaparralv1.GetDedupReportResponse{
+		DuplicateDigests: int64(report.DuplicateDigests),
+		DuplicateBytes:   report.DuplicateBytes,
+		Items:            make([]*chaparralv1.GetDedupReportResponse_Item, len(report.Items)),
+	}
+	for i, dup := range report.Items {
+		resp.Items[i] = &chaparralv1.GetDedupReportResponse_Item{
+			Digest:         dup.Digest,
+			Size:           dup.Size,
+			ObjectIds:      dup.ObjectIDs,
+			DuplicateBytes: dup.DuplicateBytes(),
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ListWebhookDeliveries returns the webhook delivery log.
+func (s *AdminService) ListWebhookDeliveries(ctx context.Context, req *connect.Request[chaparralv1.ListWebhookDeliveriesRequest]) (*connect.Response[chaparralv1.ListWebhookDeliveriesResponse], error) {
+	logger := LoggerFromCtx(ctx)
+	if s.notifier == nil {
+		err := errors.New("the server is not configured for webhooks")
+		return nil, connect.NewError(connect.CodeUnimplemented, err)
+	}
+	limit := int(req.Msg.Limit)
+	if limit < 1 {
+		limit = defaultDeliveriesLimit
+	}
+	if req.Msg.Offset < 0 {
+		err := errors.New("offset must not be negative")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	deliveries, err := s.notifier.Deliveries(ctx, req.Msg.EndpointId, limit, int(req.Msg.Offset))
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp := &chaparralv1.ListWebhookDeliveriesResponse{
+		Deliveries: make([]*chaparralv1.ListWebhookDeliveriesResponse_Item, len(deliveries)),
+	}
+	for i, d := range deliveries {
+		item := &chaparralv1.ListWebhookDeliveriesResponse_Item{
+			Id:            d.ID,
+			EndpointId:    d.EndpointID,
+			EventId:       d.Event.ID,
+			EventType:     d.Event.Type,
+			StorageRootId: d.Event.StorageRootID,
+			ObjectId:      d.Event.ObjectID,
+			UploaderId:    d.Event.UploaderID,
+			UserId:        d.Event.UserID,
+			Status:        d.Status,
+			Attempts:      int32(d.Attempts),
+			LastStatus:    int32(d.LastStatus),
+			LastError:     d.LastError,
+			Created:       timestamppb.New(d.Event.Created),
+			Updated:       timestamppb.New(d.Updated),
+		}
+		if d.Status == webhook.StatusPending {
+			item.NextAttempt = timestamppb.New(d.NextAttempt)
+		}
+		resp.Deliveries[i] = item
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// AuthorizeInterceptor is middleware that does authorization for all admin
+// service requests.
+func (s *AdminService) AuthorizeInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		if s.auth == nil {
+			return next
+		}
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				// just for server side
+				return next(ctx, req)
+			}
+			var ok bool
+			switch msg := req.Any().(type) {
+			case *chaparralv1.ListStorageRootsRequest:
+				// results are filtered by storage root, so any authenticated
+				// user may list them.
+				ok = !AuthUserFromCtx(ctx).Empty() ||
+					s.auth.Allowed(ctx, ActionAdmin, AuthResource("*", "*"))
+			case *chaparralv1.ReindexStorageRootRequest:
+				ok = s.auth.Allowed(ctx, ActionAdmin, AuthResource(msg.StorageRootId, "*"))
+			case *chaparralv1.ListAuditResultsRequest:
+				ok = s.auth.Allowed(ctx, ActionAdmin, AuthResource(msg.StorageRootId, "*"))
+			case *chaparralv1.GetDedupReportRequest:
+				ok = s.auth.Allowed(ctx, ActionAdmin, AuthResource(msg.StorageRootId, "*"))
+			case *chaparralv1.ListWebhookDeliveriesRequest:
+				// deliveries include events for all storage roots
+				ok = s.auth.Allowed(ctx, ActionAdmin, AuthResource("*", "*"))
+			}
+			if !ok {
+				return nil, connect.NewError(connect.CodePermissionDenied, errors.New("API key insufficient permission"))
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// findingHandler is a slog.Handler that sends validation errors and warnings
+// logged during validation to a stream.
+type findingHandler struct {
+	stream *connect.ServerStream[chaparralv1.ValidateStorageRootResponse]
+	attrs  []slog.Attr
+	state  *findingState
+}
+
+// findingState is shared by all handlers derived from the same findingHandler.
+type findingState struct {
+	mx      sync.Mutex
+	sendErr error
+}
+
+func (h *findingHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= slog.LevelWarn
+}
+
+func (h *findingHandler) Handle(_ context.Context, rec slog.Record) error {
+	finding := &chaparralv1.ValidateStorageRootResponse{
+		Fatal:   rec.Level >= slog.LevelError,
+		Message: rec.Message,
+	}
+	setAttr := func(a slog.Attr) bool {
+		switch a.Key {
+		case "ocfl_err":
+			finding.OcflCode = a.Value.String()
+		case "object_path":
+			finding.ObjectPath = a.Value.String()
+		}
+		return true
+	}
+	for _, a := range h.attrs {
+		setAttr(a)
+	}
+	rec.Attrs(setAttr)
+	h.state.mx.Lock()
+	defer h.state.mx.Unlock()
+	if h.state.sendErr != nil {
+		return h.state.sendErr
+	}
+	h.state.sendErr = h.stream.Send(finding)
+	return h.state.sendErr
+}
+
+func (h *findingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &findingHandler{
+		stream: h.stream,
+		attrs:  append(slices.Clip(h.attrs), attrs...),
+		state:  h.state,
+	}
+}
+
+// WithGroup is a no-op: validation attributes aren't grouped.
+func (h *findingHandler) WithGroup(_ string) slog.Handler { return h }
+
+// err returns the first error that occurred sending to the stream.
+func (h *findingHandler) err() error {
+	h.state.mx.Lock()
+	defer h.state.mx.Unlock()
+	return h.state.sendErr
+}
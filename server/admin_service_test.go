@@ -0,0 +1,299 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/carlmjohnson/be"
+	"github.com/srerickson/chaparral"
+	chapv1 "github.com/srerickson/chaparral/gen/chaparral/v1"
+	chapv1connect "github.com/srerickson/chaparral/gen/chaparral/v1/chaparralv1connect"
+	"github.com/srerickson/chaparral/internal/testutil"
+	"github.com/srerickson/chaparral/server"
+	"github.com/srerickson/chaparral/server/audit"
+	"github.com/srerickson/chaparral/server/eventlog"
+	"github.com/srerickson/chaparral/server/webhook"
+	"github.com/srerickson/ocfl-go"
+)
+
+var _ chapv1connect.AdminServiceHandler = (*server.AdminService)(nil)
+
+func TestAdminServiceListAuditResults(t *testing.T) {
+	ctx := context.Background()
+	objID := "ark:123/abc"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	root := testutil.NewStoreTempDir(t)
+	be.NilErr(t, root.CopyObject(ctx, fixture, objID))
+	auditor := audit.NewAuditor(testutil.TestDB(t), audit.Config{Interval: time.Hour}, root)
+	be.NilErr(t, auditor.AuditRoot(ctx, root))
+	mux := server.New(
+		server.WithStorageRoots(root),
+		server.WithAuditor(auditor),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	htc := srv.Client()
+	adminCli := chapv1connect.NewAdminServiceClient(htc, srv.URL)
+	req := &chapv1.ListAuditResultsRequest{StorageRootId: root.ID()}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		for _, user := range []server.AuthUser{testutil.AnonUser, testutil.ManagerUser} {
+			testutil.SetUserToken(htc, user)
+			_, err := adminCli.ListAuditResults(ctx, connect.NewRequest(req))
+			isConnectErrCode(t, err, connect.CodePermissionDenied)
+		}
+	})
+	t.Run("admin", func(t *testing.T) {
+		testutil.SetUserToken(htc, testutil.AdminUser)
+		resp, err := adminCli.ListAuditResults(ctx, connect.NewRequest(req))
+		be.NilErr(t, err)
+		be.Equal(t, 1, len(resp.Msg.Results))
+		be.Equal(t, objID, resp.Msg.Results[0].ObjectId)
+		be.True(t, resp.Msg.Results[0].Valid)
+		failedReq := &chapv1.ListAuditResultsRequest{StorageRootId: root.ID(), FailedOnly: true}
+		resp, err = adminCli.ListAuditResults(ctx, connect.NewRequest(failedReq))
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(resp.Msg.Results))
+		missingReq := &chapv1.ListAuditResultsRequest{StorageRootId: "missing"}
+		_, err = adminCli.ListAuditResults(ctx, connect.NewRequest(missingReq))
+		isConnectErrCode(t, err, connect.CodeNotFound)
+	})
+}
+
+func TestAdminServiceStorageRoots(t *testing.T) {
+	ctx := context.Background()
+	objID := "ark:123/abc"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	root := testutil.NewStoreTempDir(t)
+	other := testutil.NewStoreTempDirID(t, "other")
+	be.NilErr(t, root.CopyObject(ctx, fixture, objID))
+	// managers are admins of one storage root
+	perms := testutil.DefaultRoles(root.ID())
+	perms.Roles[testutil.ManagerUser.Roles[0]][server.ActionAdmin] = []string{server.AuthResource(root.ID(), "*")}
+	mux := server.New(
+		server.WithStorageRoots(root, other),
+		server.WithAuthorizer(perms),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	htc := srv.Client()
+	cli := chaparral.NewClient(htc, srv.URL)
+
+	t.Run("unauthorized", func(t *testing.T) {
+		testutil.SetUserToken(htc, testutil.AnonUser)
+		_, err := cli.ListStorageRoots(ctx, false)
+		isConnectErrCode(t, err, connect.CodePermissionDenied)
+		testutil.SetUserToken(htc, testutil.ManagerUser)
+		err = cli.ValidateStorageRoot(ctx, other.ID(), false, func(chaparral.ValidationFinding) error {
+			return nil
+		})
+		isConnectErrCode(t, err, connect.CodePermissionDenied)
+		_, err = cli.ReindexStorageRoot(ctx, other.ID())
+		isConnectErrCode(t, err, connect.CodePermissionDenied)
+	})
+	t.Run("list filtered", func(t *testing.T) {
+		testutil.SetUserToken(htc, testutil.MemberUser)
+		roots, err := cli.ListStorageRoots(ctx, false)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(roots))
+		testutil.SetUserToken(htc, testutil.ManagerUser)
+		roots, err = cli.ListStorageRoots(ctx, false)
+		be.NilErr(t, err)
+		be.Equal(t, 1, len(roots))
+		be.Equal(t, root.ID(), roots[0].ID)
+	})
+	testutil.SetUserToken(htc, testutil.AdminUser)
+	t.Run("list", func(t *testing.T) {
+		roots, err := cli.ListStorageRoots(ctx, false)
+		be.NilErr(t, err)
+		be.Equal(t, 2, len(roots))
+		be.Equal(t, "other", roots[0].ID)
+		be.Equal(t, root.ID(), roots[1].ID)
+		be.Equal(t, root.Path(), roots[1].Path)
+		be.Equal(t, string(root.Spec()), roots[1].Spec)
+		be.Equal(t, root.LayoutName(), roots[1].Layout)
+		// stats are opt-in
+		be.Equal(t, 0, roots[1].ObjectCount)
+		be.Equal(t, 0, roots[1].TotalBytes)
+		roots, err = cli.ListStorageRoots(ctx, true)
+		be.NilErr(t, err)
+		be.Equal(t, 1, roots[1].ObjectCount)
+		be.True(t, roots[1].TotalBytes > 0)
+	})
+	t.Run("reindex", func(t *testing.T) {
+		result, err := cli.ReindexStorageRoot(ctx, root.ID())
+		be.NilErr(t, err)
+		be.Equal(t, 1, result.Indexed)
+		be.Equal(t, 0, result.Failed)
+		be.Equal(t, 0, result.Removed)
+		_, err = cli.ReindexStorageRoot(ctx, "missing")
+		isConnectErrCode(t, err, connect.CodeNotFound)
+	})
+	t.Run("validate", func(t *testing.T) {
+		var fatal int
+		err := cli.ValidateStorageRoot(ctx, root.ID(), false, func(f chaparral.ValidationFinding) error {
+			if f.Fatal {
+				fatal++
+			}
+			return nil
+		})
+		be.NilErr(t, err)
+		be.Equal(t, 0, fatal)
+
+		// corrupt a content file
+		obj, err := root.GetObjectManifest(ctx, objID)
+		be.NilErr(t, err)
+		var contentPath string
+		for _, info := range obj.Manifest {
+			contentPath = path.Join(obj.Path, info.Paths[0])
+			break
+		}
+		obj.Close()
+		_, err = root.FS().Write(ctx, contentPath, strings.NewReader("corrupted"))
+		be.NilErr(t, err)
+		var findings []chaparral.ValidationFinding
+		err = cli.ValidateStorageRoot(ctx, root.ID(), false, func(f chaparral.ValidationFinding) error {
+			findings = append(findings, f)
+			return nil
+		})
+		be.NilErr(t, err)
+		be.True(t, slices.ContainsFunc(findings, func(f chaparral.ValidationFinding) bool {
+			return f.Fatal && f.OCFLCode != "" && f.ObjectPath != ""
+		}))
+	})
+}
+
+func TestAdminServiceDedupReport(t *testing.T) {
+	ctx := context.Background()
+	objID := "ark:123/abc"
+	copyID := "ark:123/copy"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	root := testutil.NewStoreTempDir(t)
+	be.NilErr(t, root.CopyObject(ctx, fixture, objID))
+	mux := server.New(
+		server.WithStorageRoots(root),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	htc := srv.Client()
+	cli := chaparral.NewClient(htc, srv.URL)
+	testutil.SetUserToken(htc, testutil.AdminUser)
+
+	report, err := cli.GetDedupReport(ctx, root.ID(), 0)
+	be.NilErr(t, err)
+	be.Equal(t, 0, report.DuplicateDigests)
+	be.Equal(t, 0, len(report.Items))
+
+	// copy the fixture's content to a new object
+	version, err := cli.GetObjectVersion(ctx, root.ID(), objID, 0)
+	be.NilErr(t, err)
+	be.NilErr(t, cli.Commit(ctx, &chaparral.Commit{
+		To:             chaparral.ObjectRef{StorageRootID: root.ID(), ID: copyID},
+		Message:        "copy content",
+		User:           ocfl.User{Name: "Test"},
+		State:          version.State.PathMap(),
+		Alg:            version.DigestAlgorithm,
+		ContentSources: []any{chaparral.ObjectRef{StorageRootID: root.ID(), ID: objID}},
+	}))
+	report, err = cli.GetDedupReport(ctx, root.ID(), 0)
+	be.NilErr(t, err)
+	be.Equal(t, len(version.State), report.DuplicateDigests)
+	be.Equal(t, len(version.State), len(report.Items))
+	var expectBytes int64
+	for _, item := range report.Items {
+		be.DeepEqual(t, []string{objID, copyID}, item.ObjectIDs)
+		be.True(t, item.Size > 0)
+		expectBytes += item.DuplicateBytes()
+	}
+	be.Equal(t, expectBytes, report.DuplicateBytes)
+	report, err = cli.GetDedupReport(ctx, root.ID(), 1)
+	be.NilErr(t, err)
+	be.Equal(t, len(version.State), report.DuplicateDigests)
+	be.Equal(t, 1, len(report.Items))
+
+	testutil.SetUserToken(htc, testutil.ManagerUser)
+	_, err = cli.GetDedupReport(ctx, root.ID(), 0)
+	isConnectErrCode(t, err, connect.CodePermissionDenied)
+}
+
+func TestAdminServiceListWebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+	objID := "ark:123/abc"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	root := testutil.NewStoreTempDir(t)
+	be.NilErr(t, root.CopyObject(ctx, fixture, objID))
+	var (
+		mx       sync.Mutex
+		received []eventlog.Event
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		be.NilErr(t, err)
+		be.NilErr(t, webhook.Verify("secret", r.Header, body, time.Minute))
+		var event eventlog.Event
+		be.NilErr(t, json.Unmarshal(body, &event))
+		mx.Lock()
+		defer mx.Unlock()
+		received = append(received, event)
+	}))
+	defer receiver.Close()
+	notifier := webhook.NewNotifier(testutil.TestDB(t), webhook.Config{
+		Endpoints: []webhook.Endpoint{{ID: "receiver", URL: receiver.URL, Secret: "secret"}},
+	})
+	mux := server.New(
+		server.WithStorageRoots(root),
+		server.WithNotifier(notifier),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	htc := srv.Client()
+	cli := chaparral.NewClient(htc, srv.URL)
+
+	testutil.SetUserToken(htc, testutil.ManagerUser)
+	be.NilErr(t, cli.DeleteObject(ctx, root.ID(), objID))
+	be.NilErr(t, notifier.DeliverPending(ctx))
+	be.Equal(t, 1, len(received))
+	be.Equal(t, eventlog.ObjectDeleted, received[0].Type)
+	be.Equal(t, root.ID(), received[0].StorageRootID)
+	be.Equal(t, objID, received[0].ObjectID)
+	be.Equal(t, testutil.ManagerUser.ID, received[0].UserID)
+
+	t.Run("unauthorized", func(t *testing.T) {
+		for _, user := range []server.AuthUser{testutil.AnonUser, testutil.ManagerUser} {
+			testutil.SetUserToken(htc, user)
+			_, err := cli.ListWebhookDeliveries(ctx, "", 0, 0)
+			isConnectErrCode(t, err, connect.CodePermissionDenied)
+		}
+	})
+	t.Run("admin", func(t *testing.T) {
+		testutil.SetUserToken(htc, testutil.AdminUser)
+		deliveries, err := cli.ListWebhookDeliveries(ctx, "", 0, 0)
+		be.NilErr(t, err)
+		be.Equal(t, 1, len(deliveries))
+		be.Equal(t, "receiver", deliveries[0].EndpointID)
+		be.Equal(t, received[0].ID, deliveries[0].EventID)
+		be.Equal(t, eventlog.ObjectDeleted, deliveries[0].EventType)
+		be.Equal(t, objID, deliveries[0].ObjectID)
+		be.Equal(t, testutil.ManagerUser.ID, deliveries[0].UserID)
+		be.Equal(t, webhook.StatusDelivered, deliveries[0].Status)
+		be.Equal(t, 1, deliveries[0].Attempts)
+		be.True(t, deliveries[0].NextAttempt.IsZero())
+		deliveries, err = cli.ListWebhookDeliveries(ctx, "other", 0, 0)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(deliveries))
+	})
+}
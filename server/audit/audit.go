@@ -0,0 +1,195 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/srerickson/chaparral/server/internal/lock"
+	"github.com/srerickson/chaparral/server/store"
+	"github.com/srerickson/ocfl-go/ocflv1"
+)
+
+// metrics are published with expvar
+var metrics = expvar.NewMap("chaparral_audit")
+
+// dueTolerance is the fraction of the interval by which an object's audit may
+// be early. Without it, an object checked slightly less than one interval
+// before the next walk would wait for the walk after that.
+const dueTolerance = 0.1
+
+// Result is the outcome of validating an object during an audit.
+type Result struct {
+	StorageRootID string
+	// object's path relative to the storage root
+	Path string
+	// ObjectID may be empty if the object's inventory couldn't be read.
+	ObjectID string
+	// time the object was validated
+	Checked time.Time
+	// fatal validation errors
+	Errors []string
+	// validation warnings
+	Warnings []string
+}
+
+// Valid returns true if the result has no fatal validation errors
+func (r Result) Valid() bool { return len(r.Errors) == 0 }
+
+// Persistence is used to store audit results
+type Persistence interface {
+	SetAuditResult(ctx context.Context, result *Result) error
+
+	// GetAuditResult returns the most recent result for the object path
+	GetAuditResult(ctx context.Context, storeID string, path string) (*Result, error)
+
+	// ListAuditResults returns results for a storage root, most recently
+	// checked first.
+	ListAuditResults(ctx context.Context, storeID string, failedOnly bool, limit int, offset int) ([]Result, error)
+}
+
+// Config is used to configure an Auditor
+type Config struct {
+	// Interval is the time between audits of an object. It is also the time
+	// Run waits between walking the storage roots.
+	Interval time.Duration
+	// Rate is the maximum number of objects validated per second. If zero,
+	// there is no limit.
+	Rate float64
+	// Logger is used to log audit progress and validation failures.
+	Logger *slog.Logger
+}
+
+// Auditor periodically validates the objects in storage roots and saves the
+// results.
+type Auditor struct {
+	roots   []*store.StorageRoot
+	persist Persistence
+	conf    Config
+	now     func() time.Time // current time; replaced in tests
+}
+
+func NewAuditor(persist Persistence, conf Config, roots ...*store.StorageRoot) *Auditor {
+	if conf.Logger == nil {
+		conf.Logger = slog.Default()
+	}
+	return &Auditor{
+		roots:   roots,
+		persist: persist,
+		conf:    conf,
+		now:     time.Now,
+	}
+}
+
+// Run audits all storage roots until ctx is canceled. Storage roots are walked
+// after each interval.
+func (a *Auditor) Run(ctx context.Context) error {
+	if a.conf.Interval <= 0 {
+		return errors.New("audit interval must be greater than zero")
+	}
+	ticker := time.NewTicker(a.conf.Interval)
+	defer ticker.Stop()
+	for {
+		for _, root := range a.roots {
+			if err := a.AuditRoot(ctx, root); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				a.conf.Logger.Error("during audit", "storage_root", root.ID(), "err", err.Error())
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// AuditRoot validates each object in root, saving the results. Objects
+// that were validated more recently than the auditor's interval (less a 10%
+// tolerance) are skipped, as are objects that are being modified.
+func (a *Auditor) AuditRoot(ctx context.Context, root *store.StorageRoot) error {
+	var limit <-chan time.Time
+	if a.conf.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / a.conf.Rate))
+		defer ticker.Stop()
+		limit = ticker.C
+	}
+	logger := a.conf.Logger.With("storage_root", root.ID())
+	logger.Debug("starting audit")
+	minAge := a.conf.Interval - time.Duration(float64(a.conf.Interval)*dueTolerance)
+	var checked, failed int
+	err := root.Objects(ctx, func(obj *ocflv1.Object, objErr error) error {
+		result := &Result{
+			StorageRootID: root.ID(),
+			Path:          strings.TrimPrefix(obj.Path, root.Path()+"/"),
+		}
+		if a.conf.Interval > 0 {
+			prev, err := a.persist.GetAuditResult(ctx, result.StorageRootID, result.Path)
+			if err == nil && a.now().Sub(prev.Checked) < minAge {
+				return nil
+			}
+		}
+		if limit != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-limit:
+			}
+		}
+		switch {
+		case objErr != nil:
+			result.Errors = []string{objErr.Error()}
+		default:
+			result.ObjectID = obj.Inventory.ID
+			vResult, err := root.ValidateObject(ctx, obj)
+			if err != nil {
+				if errors.Is(err, lock.ErrReadLock) || errors.Is(err, lock.ErrCapacity) {
+					// object is busy; check it next time.
+					logger.Debug("skipping object", "object_id", result.ObjectID, "reason", err.Error())
+					return nil
+				}
+				return err
+			}
+			for _, e := range vResult.Fatal() {
+				result.Errors = append(result.Errors, e.Error())
+			}
+			for _, e := range vResult.Warn() {
+				result.Warnings = append(result.Warnings, e.Error())
+			}
+		}
+		if ctx.Err() != nil {
+			// validation was interrupted: don't save the result
+			return ctx.Err()
+		}
+		result.Checked = a.now().UTC()
+		if err := a.persist.SetAuditResult(ctx, result); err != nil {
+			return fmt.Errorf("saving audit result: %w", err)
+		}
+		checked++
+		metrics.Add("objects_checked", 1)
+		if !result.Valid() {
+			failed++
+			metrics.Add("objects_invalid", 1)
+			logger.Warn("object failed validation",
+				"object_path", result.Path,
+				"object_id", result.ObjectID,
+				"errors", strings.Join(result.Errors, "; "))
+		}
+		return nil
+	})
+	metrics.Add("runs", 1)
+	logger.Debug("audit complete", "checked", checked, "failed", failed)
+	return err
+}
+
+// Results returns saved audit results for the storage root with the given
+// id.
+func (a *Auditor) Results(ctx context.Context, storeID string, failedOnly bool, limit int, offset int) ([]Result, error) {
+	return a.persist.ListAuditResults(ctx, storeID, failedOnly, limit, offset)
+}
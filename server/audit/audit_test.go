@@ -0,0 +1,78 @@
+package audit_test
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carlmjohnson/be"
+	"github.com/srerickson/chaparral/internal/testutil"
+	"github.com/srerickson/chaparral/server/audit"
+)
+
+func TestAuditRoot(t *testing.T) {
+	ctx := context.Background()
+	objID := "ark:123/abc"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "..", "testdata"))
+	root := testutil.NewStoreTempDir(t)
+	be.NilErr(t, root.CopyObject(ctx, fixture, objID))
+	db := testutil.TestDB(t)
+	clock := time.Now().UTC().Truncate(time.Second)
+	newAuditor := func(conf audit.Config) *audit.Auditor {
+		auditor := audit.NewAuditor(db, conf, root)
+		audit.SetNow(auditor, func() time.Time { return clock })
+		return auditor
+	}
+
+	auditor := newAuditor(audit.Config{Interval: time.Hour})
+	be.NilErr(t, auditor.AuditRoot(ctx, root))
+	results, err := auditor.Results(ctx, root.ID(), false, 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(results))
+	be.Equal(t, objID, results[0].ObjectID)
+	be.True(t, results[0].Valid())
+	be.True(t, results[0].Checked.Equal(clock))
+	firstCheck := results[0].Checked
+
+	// objects checked within the interval are skipped
+	clock = clock.Add(53 * time.Minute)
+	be.NilErr(t, auditor.AuditRoot(ctx, root))
+	results, err = auditor.Results(ctx, root.ID(), false, 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(results))
+	be.True(t, results[0].Checked.Equal(firstCheck))
+
+	// objects are due slightly before the interval has passed, so they are
+	// checked on every walk of the storage root.
+	clock = clock.Add(2 * time.Minute)
+	be.NilErr(t, auditor.AuditRoot(ctx, root))
+	results, err = auditor.Results(ctx, root.ID(), false, 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(results))
+	be.True(t, results[0].Checked.Equal(clock))
+
+	// corrupt a content file
+	obj, err := root.GetObjectManifest(ctx, objID)
+	be.NilErr(t, err)
+	var contentPath string
+	for _, info := range obj.Manifest {
+		contentPath = path.Join(obj.Path, info.Paths[0])
+		break
+	}
+	obj.Close()
+	_, err = root.FS().Write(ctx, contentPath, strings.NewReader("corrupted"))
+	be.NilErr(t, err)
+
+	// without an interval, all objects are checked
+	auditor = newAuditor(audit.Config{})
+	be.NilErr(t, auditor.AuditRoot(ctx, root))
+	failed, err := auditor.Results(ctx, root.ID(), true, 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(failed))
+	be.Equal(t, objID, failed[0].ObjectID)
+	be.False(t, failed[0].Valid())
+	be.True(t, len(failed[0].Errors) > 0)
+}
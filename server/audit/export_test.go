@@ -0,0 +1,8 @@
+package audit
+
+import "time"
+
+// SetNow sets the function the auditor uses to get the current time.
+func SetNow(a *Auditor, now func() time.Time) {
+	a.now = now
+}
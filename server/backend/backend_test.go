@@ -1 +1,45 @@
 package backend_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/carlmjohnson/be"
+	"github.com/srerickson/chaparral/server/backend"
+	"github.com/srerickson/ocfl-go/backend/local"
+	s3ocfl "github.com/srerickson/ocfl-go/backend/s3"
+)
+
+func TestPresignGet(t *testing.T) {
+	ctx := context.Background()
+	t.Run("s3", func(t *testing.T) {
+		client := s3.New(s3.Options{
+			Region:       "us-east-1",
+			BaseEndpoint: aws.String("http://localhost:9000"),
+			UsePathStyle: true,
+			Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+				return aws.Credentials{AccessKeyID: "key", SecretAccessKey: "secret"}, nil
+			}),
+		})
+		fsys := &s3ocfl.BucketFS{S3: client, Bucket: "bucket"}
+		signed, err := backend.PresignGet(ctx, fsys, "ocfl/obj/v1/content/file.txt", 10*time.Minute)
+		be.NilErr(t, err)
+		u, err := url.Parse(signed)
+		be.NilErr(t, err)
+		be.Equal(t, "localhost:9000", u.Host)
+		be.Equal(t, "/bucket/ocfl/obj/v1/content/file.txt", u.Path)
+		be.Equal(t, "600", u.Query().Get("X-Amz-Expires"))
+		be.Nonzero(t, u.Query().Get("X-Amz-Signature"))
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		fsys, err := local.NewFS(t.TempDir())
+		be.NilErr(t, err)
+		_, err = backend.PresignGet(ctx, fsys, "file.txt", time.Minute)
+		be.True(t, errors.Is(err, backend.ErrPresignUnsupported))
+	})
+}
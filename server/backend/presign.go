@@ -0,0 +1,40 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/srerickson/ocfl-go"
+	s3ocfl "github.com/srerickson/ocfl-go/backend/s3"
+)
+
+// ErrPresignUnsupported is returned by PresignGet if the backend doesn't
+// support presigned URLs.
+var ErrPresignUnsupported = errors.New("backend doesn't support presigned URLs")
+
+// PresignGet returns a URL that can be used to download the file name in fsys
+// directly from the storage service, without credentials, until expires
+// elapses. Only S3 backends are supported: for other backends, it returns
+// ErrPresignUnsupported. The file's existence isn't checked.
+func PresignGet(ctx context.Context, fsys ocfl.FS, name string, expires time.Duration) (string, error) {
+	bucketFS, ok := fsys.(*s3ocfl.BucketFS)
+	if !ok {
+		return "", ErrPresignUnsupported
+	}
+	client, ok := bucketFS.S3.(*s3.Client)
+	if !ok {
+		return "", ErrPresignUnsupported
+	}
+	params := &s3.GetObjectInput{
+		Bucket: aws.String(bucketFS.Bucket),
+		Key:    aws.String(name),
+	}
+	req, err := s3.NewPresignClient(client).PresignGetObject(ctx, params, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", err
+	}
+	return req.URL, nil
+}
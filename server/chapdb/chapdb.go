@@ -0,0 +1,118 @@
+package chapdb
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"github.com/srerickson/chaparral"
+	"github.com/srerickson/chaparral/server/audit"
+	"github.com/srerickson/chaparral/server/eventlog"
+	"github.com/srerickson/chaparral/server/store"
+	"github.com/srerickson/chaparral/server/uploader"
+	"github.com/srerickson/chaparral/server/webhook"
+)
+
+// DB is the persistence layer used by the server. It is implemented by
+// SQLiteDB, PostgresDB, and MemoryDB.
+type DB interface {
+	uploader.Persistence
+	store.ObjectCache
+	store.TagPersistence
+	audit.Persistence
+	webhook.Persistence
+	eventlog.Persistence
+	Close() error
+}
+
+var (
+	_ DB = (*SQLiteDB)(nil)
+	_ DB = (*PostgresDB)(nil)
+	_ DB = (*MemoryDB)(nil)
+)
+
+// IsPostgresDSN returns true if dsn is a PostgreSQL connection URL.
+func IsPostgresDSN(dsn string) bool {
+	return strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://")
+}
+
+// OpenDSN opens the database for dsn. If dsn is a PostgreSQL connection URL
+// (postgres://...), PostgreSQL is used. If dsn is MemoryDSN, a new MemoryDB
+// is returned. Otherwise, dsn is the name of an SQLite3 database file.
+func OpenDSN(dsn string, migrate bool) (DB, error) {
+	var db *sql.DB
+	var err error
+	if dsn == MemoryDSN {
+		return NewMemoryDB(0), nil
+	}
+	if IsPostgresDSN(dsn) {
+		if db, err = Open("postgres", dsn, migrate); err != nil {
+			return nil, err
+		}
+		return (*PostgresDB)(db), nil
+	}
+	if db, err = Open("sqlite3", dsn, migrate); err != nil {
+		return nil, err
+	}
+	return (*SQLiteDB)(db), nil
+}
+
+// encodeMetadata returns meta as a JSON string. It returns an empty string if
+// meta is nil.
+func encodeMetadata(meta *chaparral.ObjectMetadata) (string, error) {
+	if meta == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(meta)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// decodeMetadata is the inverse of encodeMetadata.
+func decodeMetadata(val string) (*chaparral.ObjectMetadata, error) {
+	if val == "" {
+		return nil, nil
+	}
+	meta := &chaparral.ObjectMetadata{}
+	if err := json.Unmarshal([]byte(val), meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// searchTerms splits val into lowercase terms of letters and digits.
+func searchTerms(val string) []string {
+	return strings.FieldsFunc(strings.ToLower(val), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// searchText returns the normalized text used to index obj for search. It
+// includes terms from the object's metadata and its head version's logical
+// paths.
+func searchText(obj *chaparral.ObjectManifest) string {
+	var vals []string
+	if meta := obj.Metadata; meta != nil {
+		vals = append(vals, meta.Title, meta.Description, meta.License)
+		vals = append(vals, meta.Creators...)
+		vals = append(vals, meta.Keywords...)
+	}
+	vals = append(vals, obj.HeadPaths...)
+	return strings.Join(searchTerms(strings.Join(vals, " ")), " ")
+}
+
+// appendDuplicate adds objID to the last entry in dups if it has the same
+// digest; otherwise it appends a new entry. Rows must be sorted by digest.
+func appendDuplicate(dups []chaparral.DuplicateContent, digest string, objID string) []chaparral.DuplicateContent {
+	if last := len(dups) - 1; last >= 0 && dups[last].Digest == digest {
+		dups[last].ObjectIDs = append(dups[last].ObjectIDs, objID)
+		return dups
+	}
+	return append(dups, chaparral.DuplicateContent{
+		Digest:    digest,
+		ObjectIDs: []string{objID},
+	})
+}
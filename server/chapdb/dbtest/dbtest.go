@@ -0,0 +1,885 @@
+// Package dbtest provides conformance tests for implementations of
+// uploader.Persistence, store.ObjectCache, store.TagPersistence,
+// audit.Persistence, webhook.Persistence, and eventlog.Persistence. Use it to
+// verify alternative database backends behave the same as the backends in
+// chapdb.
+package dbtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/carlmjohnson/be"
+	"github.com/srerickson/chaparral"
+	"github.com/srerickson/chaparral/server/audit"
+	"github.com/srerickson/chaparral/server/eventlog"
+	"github.com/srerickson/chaparral/server/store"
+	"github.com/srerickson/chaparral/server/uploader"
+	"github.com/srerickson/chaparral/server/webhook"
+	"github.com/srerickson/ocfl-go"
+)
+
+// concurrency is the number of go routines used in concurrency tests
+const concurrency = 8
+
+// TestPersistence tests an uploader.Persistence implementation. newDB should
+// return a new, empty instance for each call.
+func TestPersistence(t *testing.T, newDB func(*testing.T) uploader.Persistence) {
+	ctx := context.Background()
+	t.Run("round-trip", func(t *testing.T) {
+		db := newDB(t)
+		// check that GetUploader returns what was given to CreateUploader
+		input := &uploader.PersistentUploader{
+			ID:        "uploader",
+			CreatedAt: now(),
+			Config: uploader.Config{
+				UserID:      "user",
+				Algs:        []string{"sha512", "md5"},
+				Description: "description",
+			},
+			Uploads: []uploader.Upload{
+				{Name: "file", Size: 12, Digests: map[string]string{"a": "b"}},
+			},
+			Multiparts: []uploader.PendingMultipart{
+				{Name: "pending", UploadID: "upload-id", Size: 100, Expires: now()},
+			},
+		}
+		be.NilErr(t, db.CreateUploader(ctx, input))
+		output, err := db.GetUploader(ctx, "uploader")
+		be.NilErr(t, err)
+		be.DeepEqual(t, input, output)
+
+		ids, err := db.GetUploaderIDs(ctx)
+		be.NilErr(t, err)
+		be.DeepEqual(t, []string{"uploader"}, ids)
+
+		be.NilErr(t, db.DeleteUploader(ctx, "uploader"))
+		count, err := db.CountUploaders(ctx)
+		be.NilErr(t, err)
+		be.Equal(t, 0, count)
+	})
+	t.Run("uploads", func(t *testing.T) {
+		db := newDB(t)
+		input := &uploader.PersistentUploader{
+			ID:        "uploader",
+			CreatedAt: now(),
+			Config:    uploader.Config{UserID: "user", Algs: []string{"sha256"}},
+		}
+		be.NilErr(t, db.CreateUploader(ctx, input))
+		output, err := db.GetUploader(ctx, input.ID)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(output.Uploads))
+		// uploads added after the uploader is created
+		var wg sync.WaitGroup
+		errs := make([]error, concurrency)
+		for i := 0; i < concurrency; i++ {
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				up := &uploader.Upload{
+					Name:    fmt.Sprintf("upload-%d", i),
+					Size:    int64(i),
+					Digests: ocfl.DigestSet{"sha256": fmt.Sprintf("digest-%d", i)},
+				}
+				errs[i] = db.CreateUpload(ctx, input.ID, up)
+			}()
+		}
+		wg.Wait()
+		for _, err := range errs {
+			be.NilErr(t, err)
+		}
+		output, err = db.GetUploader(ctx, input.ID)
+		be.NilErr(t, err)
+		be.Equal(t, concurrency, len(output.Uploads))
+		sort.Slice(output.Uploads, func(i, j int) bool {
+			return output.Uploads[i].Size < output.Uploads[j].Size
+		})
+		for i, up := range output.Uploads {
+			be.Equal(t, fmt.Sprintf("upload-%d", i), up.Name)
+			be.Equal(t, int64(i), up.Size)
+			be.Equal(t, fmt.Sprintf("digest-%d", i), up.Digests["sha256"])
+		}
+		// deleting the uploader deletes its uploads
+		be.NilErr(t, db.DeleteUploader(ctx, input.ID))
+		be.NilErr(t, db.CreateUploader(ctx, input))
+		output, err = db.GetUploader(ctx, input.ID)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(output.Uploads))
+	})
+	t.Run("multipart uploads", func(t *testing.T) {
+		db := newDB(t)
+		input := &uploader.PersistentUploader{
+			ID:        "uploader",
+			CreatedAt: now(),
+			Config:    uploader.Config{UserID: "user", Algs: []string{"sha256"}},
+		}
+		be.NilErr(t, db.CreateUploader(ctx, input))
+		expires := now()
+		for i := 0; i < 3; i++ {
+			be.NilErr(t, db.CreateMultipartUpload(ctx, input.ID, &uploader.PendingMultipart{
+				Name:     fmt.Sprintf("pending-%d", i),
+				UploadID: fmt.Sprintf("upload-%d", i),
+				Size:     int64(i),
+				Expires:  expires.Add(time.Duration(i) * time.Second),
+			}))
+		}
+		be.NilErr(t, db.DeleteMultipartUpload(ctx, input.ID, "pending-1"))
+		output, err := db.GetUploader(ctx, input.ID)
+		be.NilErr(t, err)
+		be.Equal(t, 2, len(output.Multiparts))
+		be.Equal(t, "pending-0", output.Multiparts[0].Name)
+		be.Equal(t, "upload-0", output.Multiparts[0].UploadID)
+		be.Equal(t, "pending-2", output.Multiparts[1].Name)
+		be.Equal(t, 2, output.Multiparts[1].Size)
+		// pending uploads count toward usage
+		usage, err := db.GetUserUsage(ctx, "user")
+		be.NilErr(t, err)
+		be.Equal(t, uploader.Usage{Bytes: 2, Files: 2}, *usage)
+		// deleting the uploader deletes its pending uploads
+		be.NilErr(t, db.DeleteUploader(ctx, input.ID))
+		be.NilErr(t, db.CreateUploader(ctx, input))
+		output, err = db.GetUploader(ctx, input.ID)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(output.Multiparts))
+	})
+	t.Run("multiple uploaders", func(t *testing.T) {
+		db := newDB(t)
+		created := now()
+		var expectIDs []string
+		for i := 0; i < 3; i++ {
+			id := fmt.Sprintf("uploader-%d", i)
+			expectIDs = append(expectIDs, id)
+			be.NilErr(t, db.CreateUploader(ctx, &uploader.PersistentUploader{
+				ID:        id,
+				CreatedAt: created.Add(time.Duration(i) * time.Second),
+				Config:    uploader.Config{UserID: "user", Algs: []string{"sha512"}},
+			}))
+		}
+		// ids are sorted by creation time
+		ids, err := db.GetUploaderIDs(ctx)
+		be.NilErr(t, err)
+		be.DeepEqual(t, expectIDs, ids)
+		count, err := db.CountUploaders(ctx)
+		be.NilErr(t, err)
+		be.Equal(t, 3, count)
+		// uploader ids are unique
+		err = db.CreateUploader(ctx, &uploader.PersistentUploader{
+			ID:        expectIDs[0],
+			CreatedAt: created,
+			Config:    uploader.Config{UserID: "user", Algs: []string{"sha512"}},
+		})
+		be.Nonzero(t, err)
+		be.NilErr(t, db.DeleteUploader(ctx, expectIDs[1]))
+		ids, err = db.GetUploaderIDs(ctx)
+		be.NilErr(t, err)
+		be.DeepEqual(t, []string{expectIDs[0], expectIDs[2]}, ids)
+	})
+	t.Run("missing", func(t *testing.T) {
+		db := newDB(t)
+		_, err := db.GetUploader(ctx, "missing")
+		be.Nonzero(t, err)
+		// deleting a missing uploader isn't an error
+		be.NilErr(t, db.DeleteUploader(ctx, "missing"))
+		ids, err := db.GetUploaderIDs(ctx)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(ids))
+		count, err := db.CountUploaders(ctx)
+		be.NilErr(t, err)
+		be.Equal(t, 0, count)
+	})
+	t.Run("user usage", func(t *testing.T) {
+		db := newDB(t)
+		// no uploaders
+		usage, err := db.GetUserUsage(ctx, "user-a")
+		be.NilErr(t, err)
+		be.Equal(t, uploader.Usage{}, *usage)
+		for i, userID := range []string{"user-a", "user-a", "user-b"} {
+			id := fmt.Sprintf("uploader-%d", i)
+			be.NilErr(t, db.CreateUploader(ctx, &uploader.PersistentUploader{
+				ID:        id,
+				CreatedAt: now(),
+				Config:    uploader.Config{UserID: userID, Algs: []string{"sha512"}},
+			}))
+			for j := 0; j <= i; j++ {
+				be.NilErr(t, db.CreateUpload(ctx, id, &uploader.Upload{
+					Name:    fmt.Sprintf("%s-upload-%d", id, j),
+					Size:    10,
+					Digests: ocfl.DigestSet{"sha512": fmt.Sprintf("digest-%d", j)},
+				}))
+			}
+		}
+		usage, err = db.GetUserUsage(ctx, "user-a")
+		be.NilErr(t, err)
+		be.Equal(t, uploader.Usage{Files: 3, Bytes: 30}, *usage)
+		usage, err = db.GetUserUsage(ctx, "user-b")
+		be.NilErr(t, err)
+		be.Equal(t, uploader.Usage{Files: 3, Bytes: 30}, *usage)
+		// deleted uploaders don't count
+		be.NilErr(t, db.DeleteUploader(ctx, "uploader-1"))
+		usage, err = db.GetUserUsage(ctx, "user-a")
+		be.NilErr(t, err)
+		be.Equal(t, uploader.Usage{Files: 1, Bytes: 10}, *usage)
+	})
+}
+
+// TestObjectCache tests a store.ObjectCache implementation. newDB should
+// return a new, empty instance for each call.
+func TestObjectCache(t *testing.T, newDB func(*testing.T) store.ObjectCache) {
+	ctx := context.Background()
+	t.Run("round-trip", func(t *testing.T) {
+		db := newDB(t)
+		in := testManifest("store-id", "object-id")
+		be.NilErr(t, db.SetObjectManifest(ctx, in))
+		out, err := db.GetObjectManifest(ctx, in.StorageRootID, in.ID)
+		be.NilErr(t, err)
+		be.DeepEqual(t, in, out)
+		ids, err := db.GetObjectIDs(ctx, in.StorageRootID)
+		be.NilErr(t, err)
+		be.DeepEqual(t, []string{in.ID}, ids)
+	})
+	t.Run("upsert", func(t *testing.T) {
+		db := newDB(t)
+		in := testManifest("store-id", "object-id")
+		be.NilErr(t, db.SetObjectManifest(ctx, in))
+		// existing entries are replaced
+		in.Spec = "1.1"
+		in.Path = "new/place"
+		in.InventoryDigest = "def456"
+		in.Manifest["abc1"] = chaparral.FileInfo{
+			Paths:  []string{"a", "b"},
+			Size:   14,
+			Fixity: ocfl.DigestSet{"md5": "abc124"},
+		}
+		delete(in.Manifest, "abc2")
+		in.Manifest["abc3"] = chaparral.FileInfo{Paths: []string{"dir/b"}, Size: 2}
+		be.NilErr(t, db.SetObjectManifest(ctx, in))
+		out, err := db.GetObjectManifest(ctx, in.StorageRootID, in.ID)
+		be.NilErr(t, err)
+		be.DeepEqual(t, in, out)
+		ids, err := db.GetObjectIDs(ctx, in.StorageRootID)
+		be.NilErr(t, err)
+		be.DeepEqual(t, []string{in.ID}, ids)
+	})
+	t.Run("storage roots", func(t *testing.T) {
+		db := newDB(t)
+		// the same object id in different storage roots
+		inA := testManifest("store-a", "object-id")
+		inB := testManifest("store-b", "object-id")
+		inB.Path = "b/place"
+		be.NilErr(t, db.SetObjectManifest(ctx, inA))
+		be.NilErr(t, db.SetObjectManifest(ctx, inB))
+		outA, err := db.GetObjectManifest(ctx, inA.StorageRootID, inA.ID)
+		be.NilErr(t, err)
+		be.DeepEqual(t, inA, outA)
+		outB, err := db.GetObjectManifest(ctx, inB.StorageRootID, inB.ID)
+		be.NilErr(t, err)
+		be.DeepEqual(t, inB, outB)
+		be.NilErr(t, db.DeleteObject(ctx, inA.StorageRootID, inA.ID))
+		outB, err = db.GetObjectManifest(ctx, inB.StorageRootID, inB.ID)
+		be.NilErr(t, err)
+		be.DeepEqual(t, inB, outB)
+	})
+	t.Run("ids", func(t *testing.T) {
+		db := newDB(t)
+		expect := []string{"a", "b", "c"}
+		for _, id := range []string{"c", "a", "b"} {
+			man := testManifest("store-id", id)
+			man.Path = id
+			be.NilErr(t, db.SetObjectManifest(ctx, man))
+		}
+		be.NilErr(t, db.SetObjectManifest(ctx, testManifest("other-store", "d")))
+		// ids are sorted
+		ids, err := db.GetObjectIDs(ctx, "store-id")
+		be.NilErr(t, err)
+		be.DeepEqual(t, expect, ids)
+	})
+	t.Run("metadata", func(t *testing.T) {
+		db := newDB(t)
+		in := testManifest("store-id", "object-id")
+		in.Metadata = &chaparral.ObjectMetadata{
+			Title:    "title",
+			Creators: []string{"a", "b"},
+			License:  "CC0-1.0",
+			Keywords: []string{"c"},
+		}
+		be.NilErr(t, db.SetObjectManifest(ctx, in))
+		out, err := db.GetObjectManifest(ctx, in.StorageRootID, in.ID)
+		be.NilErr(t, err)
+		be.DeepEqual(t, in, out)
+		// metadata is removed
+		in.Metadata = nil
+		be.NilErr(t, db.SetObjectManifest(ctx, in))
+		out, err = db.GetObjectManifest(ctx, in.StorageRootID, in.ID)
+		be.NilErr(t, err)
+		be.DeepEqual(t, in, out)
+	})
+	t.Run("manifest page", func(t *testing.T) {
+		db := newDB(t)
+		in := testManifest("store-id", "object-id")
+		in.Manifest["abc3"] = chaparral.FileInfo{Paths: []string{"v2/dir/b"}, Size: 2}
+		// prefixes match logical paths in any version
+		in.VersionStates = []ocfl.DigestMap{
+			{"abc1": {"a", "b", "c"}, "abc2": {"dir/a"}},
+			{"abc1": {"a"}, "abc3": {"dir/b"}},
+		}
+		be.NilErr(t, db.SetObjectManifest(ctx, in))
+		page, err := db.GetObjectManifestPage(ctx, in.StorageRootID, in.ID, "", "", 2)
+		be.NilErr(t, err)
+		expect := testManifest("store-id", "object-id")
+		be.DeepEqual(t, expect, page)
+		page, err = db.GetObjectManifestPage(ctx, in.StorageRootID, in.ID, "", "abc2", 2)
+		be.NilErr(t, err)
+		expect.Manifest = chaparral.Manifest{"abc3": in.Manifest["abc3"]}
+		be.DeepEqual(t, expect, page)
+		page, err = db.GetObjectManifestPage(ctx, in.StorageRootID, in.ID, "", "abc3", 2)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(page.Manifest))
+		be.Equal(t, in.InventoryDigest, page.InventoryDigest)
+		_, err = db.GetObjectManifestPage(ctx, in.StorageRootID, "missing", "", "", 2)
+		be.Nonzero(t, err)
+		// only digests with a logical path that has the prefix
+		page, err = db.GetObjectManifestPage(ctx, in.StorageRootID, in.ID, "dir/", "", 10)
+		be.NilErr(t, err)
+		be.DeepEqual(t, chaparral.Manifest{"abc2": in.Manifest["abc2"], "abc3": in.Manifest["abc3"]}, page.Manifest)
+		page, err = db.GetObjectManifestPage(ctx, in.StorageRootID, in.ID, "dir/", "abc2", 10)
+		be.NilErr(t, err)
+		be.DeepEqual(t, chaparral.Manifest{"abc3": in.Manifest["abc3"]}, page.Manifest)
+		page, err = db.GetObjectManifestPage(ctx, in.StorageRootID, in.ID, "b", "", 10)
+		be.NilErr(t, err)
+		be.DeepEqual(t, chaparral.Manifest{"abc1": in.Manifest["abc1"]}, page.Manifest)
+		page, err = db.GetObjectManifestPage(ctx, in.StorageRootID, in.ID, "v2/", "", 10)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(page.Manifest))
+		page, err = db.GetObjectManifestPage(ctx, in.StorageRootID, in.ID, "none", "", 10)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(page.Manifest))
+	})
+	t.Run("version paths", func(t *testing.T) {
+		db := newDB(t)
+		in := testManifest("store-id", "object-id")
+		in.VersionStates = []ocfl.DigestMap{
+			{"abc1": {"a"}},
+			{"abc1": {"a", "b", "c"}, "abc2": {"dir/a"}},
+		}
+		be.NilErr(t, db.SetObjectManifest(ctx, in))
+		items, err := db.ListVersionPaths(ctx, in.StorageRootID, in.ID, 2, "", "", 10)
+		be.NilErr(t, err)
+		be.DeepEqual(t, []chaparral.StateItem{
+			{Path: "a", Digest: "abc1", Size: 13},
+			{Path: "b", Digest: "abc1", Size: 13},
+			{Path: "c", Digest: "abc1", Size: 13},
+			{Path: "dir/a", Digest: "abc2", Size: 1},
+		}, items)
+		items, err = db.ListVersionPaths(ctx, in.StorageRootID, in.ID, 2, "", "a", 2)
+		be.NilErr(t, err)
+		be.DeepEqual(t, []chaparral.StateItem{
+			{Path: "b", Digest: "abc1", Size: 13},
+			{Path: "c", Digest: "abc1", Size: 13},
+		}, items)
+		items, err = db.ListVersionPaths(ctx, in.StorageRootID, in.ID, 2, "dir/", "", 10)
+		be.NilErr(t, err)
+		be.DeepEqual(t, []chaparral.StateItem{{Path: "dir/a", Digest: "abc2", Size: 1}}, items)
+		items, err = db.ListVersionPaths(ctx, in.StorageRootID, in.ID, 1, "", "", 10)
+		be.NilErr(t, err)
+		be.DeepEqual(t, []chaparral.StateItem{{Path: "a", Digest: "abc1", Size: 13}}, items)
+		items, err = db.ListVersionPaths(ctx, in.StorageRootID, in.ID, 3, "", "", 10)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(items))
+		// version states aren't returned with the manifest
+		out, err := db.GetObjectManifest(ctx, in.StorageRootID, in.ID)
+		be.NilErr(t, err)
+		be.Equal(t, 2, out.Head)
+		be.Zero(t, len(out.VersionStates))
+		// new versions are added
+		in.VersionStates = append(in.VersionStates, ocfl.DigestMap{"abc2": {"dir/a"}})
+		in.Head = 3
+		be.NilErr(t, db.SetObjectManifest(ctx, in))
+		items, err = db.ListVersionPaths(ctx, in.StorageRootID, in.ID, 3, "", "", 10)
+		be.NilErr(t, err)
+		be.DeepEqual(t, []chaparral.StateItem{{Path: "dir/a", Digest: "abc2", Size: 1}}, items)
+		items, err = db.ListVersionPaths(ctx, in.StorageRootID, in.ID, 2, "", "", 10)
+		be.NilErr(t, err)
+		be.Equal(t, 4, len(items))
+		// version states are replaced if the head is earlier
+		in.VersionStates = in.VersionStates[:1]
+		in.Head = 1
+		be.NilErr(t, db.SetObjectManifest(ctx, in))
+		items, err = db.ListVersionPaths(ctx, in.StorageRootID, in.ID, 2, "", "", 10)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(items))
+		// or if the object's path changed
+		in.Path = "new/place"
+		in.VersionStates = []ocfl.DigestMap{{"abc2": {"dir/a"}}}
+		be.NilErr(t, db.SetObjectManifest(ctx, in))
+		items, err = db.ListVersionPaths(ctx, in.StorageRootID, in.ID, 1, "", "", 10)
+		be.NilErr(t, err)
+		be.DeepEqual(t, []chaparral.StateItem{{Path: "dir/a", Digest: "abc2", Size: 1}}, items)
+		// and removed with the object
+		be.NilErr(t, db.DeleteObject(ctx, in.StorageRootID, in.ID))
+		be.NilErr(t, db.SetObjectManifest(ctx, testManifest(in.StorageRootID, in.ID)))
+		items, err = db.ListVersionPaths(ctx, in.StorageRootID, in.ID, 1, "", "", 10)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(items))
+	})
+	t.Run("list", func(t *testing.T) {
+		db := newDB(t)
+		var expect []chaparral.ObjectListItem
+		for _, id := range []string{"c", "a", "b"} {
+			man := testManifest("store-id", id)
+			man.Path = id
+			man.Metadata = &chaparral.ObjectMetadata{Title: "object " + id}
+			be.NilErr(t, db.SetObjectManifest(ctx, man))
+		}
+		for _, id := range []string{"a", "b", "c"} {
+			expect = append(expect, chaparral.ObjectListItem{
+				ObjectRef: chaparral.ObjectRef{StorageRootID: "store-id", ID: id},
+				Path:      id,
+				Metadata:  &chaparral.ObjectMetadata{Title: "object " + id},
+			})
+		}
+		be.NilErr(t, db.SetObjectManifest(ctx, testManifest("other-store", "d")))
+		items, err := db.ListObjects(ctx, "store-id", 10, 0)
+		be.NilErr(t, err)
+		be.DeepEqual(t, expect, items)
+		items, err = db.ListObjects(ctx, "store-id", 1, 1)
+		be.NilErr(t, err)
+		be.DeepEqual(t, expect[1:2], items)
+		items, err = db.ListObjects(ctx, "store-id", 10, 3)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(items))
+	})
+	t.Run("search", func(t *testing.T) {
+		db := newDB(t)
+		climate := testManifest("store-id", "climate")
+		climate.Path = "climate"
+		climate.Metadata = &chaparral.ObjectMetadata{
+			Title:    "Climate Observations",
+			Keywords: []string{"temperature"},
+		}
+		climate.HeadPaths = []string{"data/rainfall_2020.csv"}
+		climate.Manifest = chaparral.Manifest{"abc3": chaparral.FileInfo{Paths: []string{"v1/content/data/rainfall_2020.csv"}}}
+		other := testManifest("store-id", "other")
+		other.Path = "other"
+		other.Metadata = &chaparral.ObjectMetadata{Title: "Other Data"}
+		otherRoot := testManifest("other-store", "climate")
+		otherRoot.Metadata = climate.Metadata
+		for _, man := range []*chaparral.ObjectManifest{climate, other, otherRoot} {
+			be.NilErr(t, db.SetObjectManifest(ctx, man))
+		}
+		search := func(query, digest string) []string {
+			items, err := db.SearchObjects(ctx, "store-id", query, digest, 10, 0)
+			be.NilErr(t, err)
+			ids := make([]string, len(items))
+			for i, item := range items {
+				ids[i] = item.ID
+			}
+			return ids
+		}
+		be.DeepEqual(t, []string{"climate"}, search("climate", ""))
+		be.DeepEqual(t, []string{"climate"}, search("CLIMATE observations", ""))
+		be.DeepEqual(t, []string{"climate"}, search("temperature", ""))
+		be.DeepEqual(t, []string{"climate"}, search("rainfall", ""))
+		be.DeepEqual(t, []string{"climate", "other"}, search("data", ""))
+		be.DeepEqual(t, []string{"climate"}, search("", "abc3"))
+		be.DeepEqual(t, []string{"other"}, search("", "abc1"))
+		be.DeepEqual(t, []string{"other"}, search("data", "abc1"))
+		be.Equal(t, 0, len(search("climate", "abc1")))
+		be.Equal(t, 0, len(search("missing", "")))
+		be.Equal(t, 0, len(search("!!", "")))
+		be.DeepEqual(t, []string{"climate", "other"}, search("", ""))
+		items, err := db.SearchObjects(ctx, "store-id", "data", "", 1, 1)
+		be.NilErr(t, err)
+		be.Equal(t, 1, len(items))
+		be.Equal(t, "other", items[0].ID)
+		be.DeepEqual(t, other.Metadata, items[0].Metadata)
+		// index is updated with the object
+		climate.Metadata = nil
+		be.NilErr(t, db.SetObjectManifest(ctx, climate))
+		be.Equal(t, 0, len(search("climate", "")))
+		be.DeepEqual(t, []string{"climate"}, search("rainfall", ""))
+		be.NilErr(t, db.DeleteObject(ctx, "store-id", "climate"))
+		be.Equal(t, 0, len(search("rainfall", "")))
+	})
+	t.Run("duplicates", func(t *testing.T) {
+		db := newDB(t)
+		obj1 := testManifest("store-id", "obj1")
+		obj2 := testManifest("store-id", "obj2")
+		obj2.Manifest = chaparral.Manifest{"abc1": obj1.Manifest["abc1"]}
+		obj3 := testManifest("store-id", "obj3")
+		obj3.Manifest = chaparral.Manifest{
+			"abc2": obj1.Manifest["abc2"],
+			"abc3": chaparral.FileInfo{Paths: []string{"a"}},
+		}
+		otherRoot := testManifest("other-store", "obj1")
+		for _, man := range []*chaparral.ObjectManifest{obj3, obj2, obj1, otherRoot} {
+			man.Path = man.ID
+			be.NilErr(t, db.SetObjectManifest(ctx, man))
+		}
+		dups, err := db.GetDuplicateContents(ctx, "store-id")
+		be.NilErr(t, err)
+		be.DeepEqual(t, []chaparral.DuplicateContent{
+			{Digest: "abc1", ObjectIDs: []string{"obj1", "obj2"}},
+			{Digest: "abc2", ObjectIDs: []string{"obj1", "obj3"}},
+		}, dups)
+		dups, err = db.GetDuplicateContents(ctx, "other-store")
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(dups))
+		be.NilErr(t, db.DeleteObject(ctx, "store-id", "obj1"))
+		dups, err = db.GetDuplicateContents(ctx, "store-id")
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(dups))
+	})
+	t.Run("delete", func(t *testing.T) {
+		db := newDB(t)
+		in := testManifest("store-id", "object-id")
+		be.NilErr(t, db.SetObjectManifest(ctx, in))
+		be.NilErr(t, db.DeleteObject(ctx, in.StorageRootID, in.ID))
+		_, err := db.GetObjectManifest(ctx, in.StorageRootID, in.ID)
+		be.Nonzero(t, err)
+		ids, err := db.GetObjectIDs(ctx, in.StorageRootID)
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(ids))
+		// re-creating the object doesn't restore old manifest entries
+		in.Manifest = chaparral.Manifest{
+			"abc3": chaparral.FileInfo{Paths: []string{"c"}, Size: 3},
+		}
+		be.NilErr(t, db.SetObjectManifest(ctx, in))
+		out, err := db.GetObjectManifest(ctx, in.StorageRootID, in.ID)
+		be.NilErr(t, err)
+		be.DeepEqual(t, in, out)
+	})
+	t.Run("missing", func(t *testing.T) {
+		db := newDB(t)
+		_, err := db.GetObjectManifest(ctx, "store-id", "missing")
+		be.Nonzero(t, err)
+		// deleting a missing object isn't an error
+		be.NilErr(t, db.DeleteObject(ctx, "store-id", "missing"))
+		ids, err := db.GetObjectIDs(ctx, "store-id")
+		be.NilErr(t, err)
+		be.Equal(t, 0, len(ids))
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		db := newDB(t)
+		var wg sync.WaitGroup
+		errs := make([]error, concurrency*2)
+		for i := 0; i < concurrency; i++ {
+			i := i
+			wg.Add(2)
+			// different objects
+			go func() {
+				defer wg.Done()
+				man := testManifest("store-id", fmt.Sprintf("object-%d", i))
+				man.Path = man.ID
+				errs[i] = db.SetObjectManifest(ctx, man)
+			}()
+			// same object
+			go func() {
+				defer wg.Done()
+				errs[concurrency+i] = db.SetObjectManifest(ctx, testManifest("store-id", "shared"))
+			}()
+		}
+		wg.Wait()
+		for _, err := range errs {
+			be.NilErr(t, err)
+		}
+		ids, err := db.GetObjectIDs(ctx, "store-id")
+		be.NilErr(t, err)
+		be.Equal(t, concurrency+1, len(ids))
+		out, err := db.GetObjectManifest(ctx, "store-id", "shared")
+		be.NilErr(t, err)
+		be.DeepEqual(t, testManifest("store-id", "shared"), out)
+	})
+}
+
+// TestAuditPersistence tests an audit.Persistence implementation. newDB
+// should return a new, empty instance for each call.
+func TestAuditPersistence(t *testing.T, newDB func(*testing.T) audit.Persistence) {
+	ctx := context.Background()
+	db := newDB(t)
+	valid := &audit.Result{
+		StorageRootID: "store-id",
+		Path:          "a/place",
+		ObjectID:      "object-1",
+		Checked:       now().Add(-time.Minute),
+		Warnings:      []string{"a warning"},
+	}
+	invalid := &audit.Result{
+		StorageRootID: "store-id",
+		Path:          "b/place",
+		ObjectID:      "object-2",
+		Checked:       now(),
+		Errors:        []string{"an error"},
+	}
+	be.NilErr(t, db.SetAuditResult(ctx, valid))
+	be.NilErr(t, db.SetAuditResult(ctx, invalid))
+	out, err := db.GetAuditResult(ctx, valid.StorageRootID, valid.Path)
+	be.NilErr(t, err)
+	be.DeepEqual(t, valid, out)
+	_, err = db.GetAuditResult(ctx, valid.StorageRootID, "missing")
+	be.Nonzero(t, err)
+
+	all, err := db.ListAuditResults(ctx, "store-id", false, 10, 0)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []audit.Result{*invalid, *valid}, all)
+	failed, err := db.ListAuditResults(ctx, "store-id", true, 10, 0)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []audit.Result{*invalid}, failed)
+	paged, err := db.ListAuditResults(ctx, "store-id", false, 10, 1)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []audit.Result{*valid}, paged)
+	other, err := db.ListAuditResults(ctx, "other-store", false, 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 0, len(other))
+
+	// results are replaced
+	valid.Checked = now()
+	valid.Warnings = nil
+	be.NilErr(t, db.SetAuditResult(ctx, valid))
+	out, err = db.GetAuditResult(ctx, valid.StorageRootID, valid.Path)
+	be.NilErr(t, err)
+	be.DeepEqual(t, valid, out)
+}
+
+// TestTagPersistence runs conformance tests for store.TagPersistence
+// implementations. newDB should return a new, empty TagPersistence.
+func TestTagPersistence(t *testing.T, newDB func(*testing.T) store.TagPersistence) {
+	ctx := context.Background()
+	db := newDB(t)
+	newTag := func(storeID, objID, name string, version int) *chaparral.VersionTag {
+		return &chaparral.VersionTag{
+			ObjectRef: chaparral.ObjectRef{StorageRootID: storeID, ID: objID},
+			Name:      name,
+			Version:   version,
+			Created:   now(),
+		}
+	}
+	published := newTag("store-id", "object-1", "published", 2)
+	draft := newTag("store-id", "object-1", "draft", 1)
+	other := newTag("store-id", "object-2", "published", 1)
+	otherRoot := newTag("other-store", "object-1", "published", 3)
+	for _, tag := range []*chaparral.VersionTag{published, draft, other, otherRoot} {
+		be.NilErr(t, db.SetVersionTag(ctx, tag))
+	}
+	out, err := db.GetVersionTag(ctx, "store-id", "object-1", "published")
+	be.NilErr(t, err)
+	be.DeepEqual(t, published, out)
+	_, err = db.GetVersionTag(ctx, "store-id", "object-1", "missing")
+	be.True(t, errors.Is(err, fs.ErrNotExist))
+	tags, err := db.ListVersionTags(ctx, "store-id", "object-1")
+	be.NilErr(t, err)
+	be.DeepEqual(t, []chaparral.VersionTag{*draft, *published}, tags)
+	tags, err = db.ListVersionTags(ctx, "store-id", "missing")
+	be.NilErr(t, err)
+	be.Equal(t, 0, len(tags))
+
+	// tags are moved
+	published.Version = 3
+	published.Created = now()
+	be.NilErr(t, db.SetVersionTag(ctx, published))
+	out, err = db.GetVersionTag(ctx, "store-id", "object-1", "published")
+	be.NilErr(t, err)
+	be.DeepEqual(t, published, out)
+
+	// delete one tag
+	be.NilErr(t, db.DeleteVersionTag(ctx, "store-id", "object-1", "draft"))
+	be.NilErr(t, db.DeleteVersionTag(ctx, "store-id", "object-1", "draft"))
+	tags, err = db.ListVersionTags(ctx, "store-id", "object-1")
+	be.NilErr(t, err)
+	be.DeepEqual(t, []chaparral.VersionTag{*published}, tags)
+
+	// delete all tags for an object
+	be.NilErr(t, db.DeleteVersionTags(ctx, "store-id", "object-1"))
+	tags, err = db.ListVersionTags(ctx, "store-id", "object-1")
+	be.NilErr(t, err)
+	be.Equal(t, 0, len(tags))
+	out, err = db.GetVersionTag(ctx, "store-id", "object-2", "published")
+	be.NilErr(t, err)
+	be.DeepEqual(t, other, out)
+	out, err = db.GetVersionTag(ctx, "other-store", "object-1", "published")
+	be.NilErr(t, err)
+	be.DeepEqual(t, otherRoot, out)
+}
+
+// TestWebhookPersistence runs conformance tests for webhook.Persistence
+// implementations. newDB should return a new, empty Persistence.
+func TestWebhookPersistence(t *testing.T, newDB func(*testing.T) webhook.Persistence) {
+	ctx := context.Background()
+	db := newDB(t)
+	created := now().Add(-time.Minute)
+	newDelivery := func(id, endpointID string, created time.Time) webhook.Delivery {
+		return webhook.Delivery{
+			ID:         id,
+			EndpointID: endpointID,
+			Event: eventlog.Event{
+				ID:            "event-" + id,
+				Type:          eventlog.ObjectCommitted,
+				Created:       created,
+				StorageRootID: "store-id",
+				ObjectID:      "object-1",
+				UserID:        "user-1",
+			},
+			Status:      webhook.StatusPending,
+			NextAttempt: created,
+			Created:     created,
+			Updated:     created,
+		}
+	}
+	first := newDelivery("delivery-1", "endpoint-1", created)
+	second := newDelivery("delivery-2", "endpoint-2", created.Add(time.Second))
+	later := newDelivery("delivery-3", "endpoint-1", created.Add(2*time.Second))
+	later.NextAttempt = now().Add(time.Hour)
+	be.NilErr(t, db.CreateDeliveries(ctx, []webhook.Delivery{first, second}))
+	be.NilErr(t, db.CreateDeliveries(ctx, []webhook.Delivery{later}))
+
+	// pending deliveries that are due are claimed, oldest first
+	claim := func(before, until time.Time, limit int) []webhook.Delivery {
+		t.Helper()
+		pending, err := db.ClaimPendingDeliveries(ctx, before, until, limit)
+		be.NilErr(t, err)
+		sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+		return pending
+	}
+	until := now().Add(time.Minute)
+	first.NextAttempt = until
+	be.DeepEqual(t, []webhook.Delivery{first}, claim(now(), until, 1))
+	second.NextAttempt = until
+	be.DeepEqual(t, []webhook.Delivery{second}, claim(now(), until, 10))
+	// claimed deliveries aren't claimed again until the claim expires
+	be.Equal(t, 0, len(claim(now(), until, 10)))
+	until2 := until.Add(time.Minute)
+	first.NextAttempt = until2
+	second.NextAttempt = until2
+	be.DeepEqual(t, []webhook.Delivery{first, second}, claim(until, until2, 10))
+
+	// delivered and failed deliveries aren't pending
+	first.Status = webhook.StatusDelivered
+	first.Attempts = 1
+	first.LastStatus = 200
+	first.Updated = now()
+	be.NilErr(t, db.UpdateDelivery(ctx, &first))
+	second.Status = webhook.StatusFailed
+	second.Attempts = 3
+	second.LastStatus = 500
+	second.LastError = "an error"
+	second.Updated = now()
+	be.NilErr(t, db.UpdateDelivery(ctx, &second))
+	be.Equal(t, 0, len(claim(until2, until2, 10)))
+	before := later.NextAttempt
+	later.NextAttempt = before.Add(time.Minute)
+	be.DeepEqual(t, []webhook.Delivery{later}, claim(before, later.NextAttempt, 10))
+
+	// delivery log, most recent first
+	all, err := db.ListDeliveries(ctx, "", 10, 0)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []webhook.Delivery{later, second, first}, all)
+	paged, err := db.ListDeliveries(ctx, "", 1, 1)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []webhook.Delivery{second}, paged)
+	endpoint, err := db.ListDeliveries(ctx, "endpoint-1", 10, 0)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []webhook.Delivery{later, first}, endpoint)
+	endpoint, err = db.ListDeliveries(ctx, "missing", 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 0, len(endpoint))
+}
+
+// TestEventPersistence runs conformance tests for eventlog.Persistence
+// implementations. newDB should return a new, empty Persistence.
+func TestEventPersistence(t *testing.T, newDB func(*testing.T) eventlog.Persistence) {
+	ctx := context.Background()
+	db := newDB(t)
+	last, err := db.LastEventSeq(ctx)
+	be.NilErr(t, err)
+	be.Equal(t, 0, last)
+	old := &eventlog.Event{
+		ID:            "event-1",
+		Type:          eventlog.ObjectCommitted,
+		Created:       now().Add(-time.Hour),
+		StorageRootID: "store-id",
+		ObjectID:      "object-1",
+		UserID:        "user-1",
+	}
+	deleted := &eventlog.Event{
+		ID:            "event-2",
+		Type:          eventlog.ObjectDeleted,
+		Created:       now(),
+		StorageRootID: "store-id",
+		ObjectID:      "object-1",
+	}
+	created := &eventlog.Event{
+		ID:         "event-3",
+		Type:       eventlog.UploaderCreated,
+		Created:    now(),
+		UploaderID: "uploader-1",
+		UserID:     "user-1",
+	}
+	for _, e := range []*eventlog.Event{old, deleted, created} {
+		be.NilErr(t, db.AppendEvent(ctx, e))
+	}
+	be.True(t, old.Seq > 0)
+	be.True(t, deleted.Seq > old.Seq)
+	be.True(t, created.Seq > deleted.Seq)
+	last, err = db.LastEventSeq(ctx)
+	be.NilErr(t, err)
+	be.Equal(t, created.Seq, last)
+
+	events, err := db.ListEvents(ctx, 0, 10)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []eventlog.Event{*old, *deleted, *created}, events)
+	events, err = db.ListEvents(ctx, old.Seq, 1)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []eventlog.Event{*deleted}, events)
+	events, err = db.ListEvents(ctx, created.Seq, 10)
+	be.NilErr(t, err)
+	be.Equal(t, 0, len(events))
+
+	// remove old events
+	be.NilErr(t, db.DeleteEvents(ctx, now().Add(-time.Minute)))
+	events, err = db.ListEvents(ctx, 0, 10)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []eventlog.Event{*deleted, *created}, events)
+
+	// sequence numbers increase after events are removed
+	be.NilErr(t, db.DeleteEvents(ctx, now().Add(time.Minute)))
+	next := &eventlog.Event{ID: "event-4", Type: eventlog.ObjectCommitted, Created: now()}
+	be.NilErr(t, db.AppendEvent(ctx, next))
+	be.True(t, next.Seq > created.Seq)
+}
+
+// now returns the current UTC time with microsecond precision, which all
+// backends are expected to support.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+func testManifest(storeID, objID string) *chaparral.ObjectManifest {
+	return &chaparral.ObjectManifest{
+		ObjectRef: chaparral.ObjectRef{
+			StorageRootID: storeID,
+			ID:            objID,
+		},
+		DigestAlgorithm: "sha512",
+		Spec:            "1.0",
+		Path:            "a/place",
+		InventoryDigest: "abc123",
+		Head:            2,
+		Manifest: chaparral.Manifest{
+			"abc1": chaparral.FileInfo{
+				Paths: []string{"a", "b", "c"},
+				Size:  13,
+			},
+			"abc2": chaparral.FileInfo{
+				Paths:  []string{"dir/a"},
+				Size:   1,
+				Fixity: ocfl.DigestSet{"md5": "abc123"},
+			},
+		},
+	}
+}
@@ -0,0 +1,715 @@
+package chapdb
+
+import (
+	"cmp"
+	"container/list"
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/srerickson/chaparral"
+	"github.com/srerickson/chaparral/server/audit"
+	"github.com/srerickson/chaparral/server/eventlog"
+	"github.com/srerickson/chaparral/server/uploader"
+	"github.com/srerickson/chaparral/server/webhook"
+	"github.com/srerickson/ocfl-go"
+)
+
+// MemoryDSN is the DSN used with OpenDSN for a MemoryDB.
+const MemoryDSN = "memory"
+
+// DefaultMemoryCacheSize is the default maximum number of object manifests
+// held by a MemoryDB.
+const DefaultMemoryCacheSize = 1024
+
+// memoryLogSize is the maximum number of events and completed webhook
+// deliveries held by a MemoryDB. The oldest are removed first.
+const memoryLogSize = 100_000
+
+// MemoryDB is an in-memory implementation of DB. Full object manifests (with
+// version states) are cached in a bounded LRU cache. Every object also has a
+// smaller index entry (its path, metadata, search terms, and digests) that is
+// not evicted, so listing, search, and content lookups include all objects.
+// Events and completed webhook deliveries are limited to the most recent
+// 100,000. Uploaders, audit results, and version tags are not bounded. Nothing
+// is persisted: all values are lost when the process exits.
+type MemoryDB struct {
+	mx        sync.Mutex
+	size      int
+	objects   map[objectKey]*list.Element // values are *chaparral.ObjectManifest
+	lru       *list.List                  // most recently used first
+	index     map[objectKey]*objectEntry  // all objects, including evicted ones
+	uploaders map[string]*uploader.PersistentUploader
+	audits    map[objectKey]*audit.Result // keyed by store id and path
+	tags      map[objectKey]map[string]chaparral.VersionTag
+	hooks     map[string]webhook.Delivery // keyed by delivery id
+	events    []eventlog.Event            // in seq order
+	lastSeq   int64
+}
+
+type objectKey struct {
+	storeID string
+	id      string
+}
+
+// objectEntry is the part of an object's manifest that is kept for listing
+// and search after the manifest is evicted.
+type objectEntry struct {
+	item    chaparral.ObjectListItem
+	terms   []string // search terms
+	digests map[string]struct{}
+}
+
+func newObjectEntry(obj *chaparral.ObjectManifest) *objectEntry {
+	entry := &objectEntry{
+		item: chaparral.ObjectListItem{
+			ObjectRef: obj.ObjectRef,
+			Path:      obj.Path,
+			Metadata:  copyMetadata(obj.Metadata),
+		},
+		terms:   strings.Fields(searchText(obj)),
+		digests: make(map[string]struct{}, len(obj.Manifest)),
+	}
+	for digest := range obj.Manifest {
+		entry.digests[digest] = struct{}{}
+	}
+	return entry
+}
+
+// NewMemoryDB returns a new MemoryDB that caches up to cacheSize object
+// manifests. If cacheSize is less than 1, DefaultMemoryCacheSize is used.
+func NewMemoryDB(cacheSize int) *MemoryDB {
+	if cacheSize < 1 {
+		cacheSize = DefaultMemoryCacheSize
+	}
+	return &MemoryDB{
+		size:      cacheSize,
+		objects:   map[objectKey]*list.Element{},
+		lru:       list.New(),
+		index:     map[objectKey]*objectEntry{},
+		uploaders: map[string]*uploader.PersistentUploader{},
+		audits:    map[objectKey]*audit.Result{},
+		tags:      map[objectKey]map[string]chaparral.VersionTag{},
+		hooks:     map[string]webhook.Delivery{},
+	}
+}
+
+func (db *MemoryDB) Close() error { return nil }
+
+func (db *MemoryDB) CreateUploader(_ context.Context, vals *uploader.PersistentUploader) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	if _, exists := db.uploaders[vals.ID]; exists {
+		return fmt.Errorf("uploader %q already exists", vals.ID)
+	}
+	db.uploaders[vals.ID] = copyUploader(vals)
+	return nil
+}
+
+func (db *MemoryDB) CreateUpload(_ context.Context, upID string, vals *uploader.Upload) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	upper, exists := db.uploaders[upID]
+	if !exists {
+		return fmt.Errorf("uploader %q: %w", upID, fs.ErrNotExist)
+	}
+	upper.Uploads = append(upper.Uploads, copyUpload(*vals))
+	return nil
+}
+
+func (db *MemoryDB) CreateMultipartUpload(_ context.Context, upID string, vals *uploader.PendingMultipart) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	upper, exists := db.uploaders[upID]
+	if !exists {
+		return fmt.Errorf("uploader %q: %w", upID, fs.ErrNotExist)
+	}
+	upper.Multiparts = append(upper.Multiparts, *vals)
+	return nil
+}
+
+func (db *MemoryDB) DeleteMultipartUpload(_ context.Context, upID string, name string) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	upper, exists := db.uploaders[upID]
+	if !exists {
+		return nil
+	}
+	upper.Multiparts = slices.DeleteFunc(upper.Multiparts, func(p uploader.PendingMultipart) bool {
+		return p.Name == name
+	})
+	return nil
+}
+
+func (db *MemoryDB) GetUploaderIDs(_ context.Context) ([]string, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	uppers := make([]*uploader.PersistentUploader, 0, len(db.uploaders))
+	for _, upper := range db.uploaders {
+		uppers = append(uppers, upper)
+	}
+	sort.Slice(uppers, func(i, j int) bool {
+		return uppers[i].CreatedAt.Before(uppers[j].CreatedAt)
+	})
+	ids := make([]string, len(uppers))
+	for i, upper := range uppers {
+		ids[i] = upper.ID
+	}
+	return ids, nil
+}
+
+func (db *MemoryDB) GetUploader(_ context.Context, id string) (*uploader.PersistentUploader, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	upper, exists := db.uploaders[id]
+	if !exists {
+		return nil, fmt.Errorf("uploader %q: %w", id, fs.ErrNotExist)
+	}
+	return copyUploader(upper), nil
+}
+
+func (db *MemoryDB) DeleteUploader(_ context.Context, id string) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	delete(db.uploaders, id)
+	return nil
+}
+
+func (db *MemoryDB) CountUploaders(_ context.Context) (int, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	return len(db.uploaders), nil
+}
+
+func (db *MemoryDB) GetUserUsage(_ context.Context, userID string) (*uploader.Usage, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	usage := &uploader.Usage{}
+	for _, upper := range db.uploaders {
+		if upper.Config.UserID != userID {
+			continue
+		}
+		for _, u := range upper.Uploads {
+			usage.Files++
+			usage.Bytes += u.Size
+		}
+		for _, p := range upper.Multiparts {
+			usage.Files++
+			usage.Bytes += p.Size
+		}
+	}
+	return usage, nil
+}
+
+func (db *MemoryDB) SetObjectManifest(_ context.Context, obj *chaparral.ObjectManifest) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	key := objectKey{storeID: obj.StorageRootID, id: obj.ID}
+	db.index[key] = newObjectEntry(obj)
+	cached := copyObjectManifest(obj)
+	cached.VersionStates = make([]ocfl.DigestMap, len(obj.VersionStates))
+	for i, state := range obj.VersionStates {
+		cached.VersionStates[i] = state.Clone()
+	}
+	if elem, exists := db.objects[key]; exists {
+		elem.Value = cached
+		db.lru.MoveToFront(elem)
+		return nil
+	}
+	db.objects[key] = db.lru.PushFront(cached)
+	for db.lru.Len() > db.size {
+		oldest := db.lru.Back()
+		oldObj := db.lru.Remove(oldest).(*chaparral.ObjectManifest)
+		delete(db.objects, objectKey{storeID: oldObj.StorageRootID, id: oldObj.ID})
+	}
+	return nil
+}
+
+func (db *MemoryDB) GetObjectManifest(_ context.Context, storeID string, objID string) (*chaparral.ObjectManifest, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	elem, exists := db.objects[objectKey{storeID: storeID, id: objID}]
+	if !exists {
+		return nil, fmt.Errorf("object %q: %w", objID, fs.ErrNotExist)
+	}
+	db.lru.MoveToFront(elem)
+	return copyObjectManifest(elem.Value.(*chaparral.ObjectManifest)), nil
+}
+
+func (db *MemoryDB) GetObjectManifestPage(_ context.Context, storeID string, objID string, prefix string, after string, limit int) (*chaparral.ObjectManifest, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	elem, exists := db.objects[objectKey{storeID: storeID, id: objID}]
+	if !exists {
+		return nil, fmt.Errorf("object %q: %w", objID, fs.ErrNotExist)
+	}
+	db.lru.MoveToFront(elem)
+	obj := elem.Value.(*chaparral.ObjectManifest)
+	// digests with a logical path that has the prefix, in any version
+	var matches map[string]struct{}
+	if prefix != "" {
+		hasPrefix := func(p string) bool { return strings.HasPrefix(p, prefix) }
+		matches = map[string]struct{}{}
+		for _, state := range obj.VersionStates {
+			for digest, paths := range state {
+				if slices.ContainsFunc(paths, hasPrefix) {
+					matches[digest] = struct{}{}
+				}
+			}
+		}
+	}
+	var digests []string
+	for digest := range obj.Manifest {
+		if digest <= after {
+			continue
+		}
+		if _, match := matches[digest]; matches != nil && !match {
+			continue
+		}
+		digests = append(digests, digest)
+	}
+	slices.Sort(digests)
+	if len(digests) > limit {
+		digests = digests[:limit]
+	}
+	page := copyObjectHeader(obj)
+	page.Manifest = make(chaparral.Manifest, len(digests))
+	for _, digest := range digests {
+		page.Manifest[digest] = copyFileInfo(obj.Manifest[digest])
+	}
+	return page, nil
+}
+
+func (db *MemoryDB) ListVersionPaths(_ context.Context, storeID string, objID string, version int, prefix string, after string, limit int) ([]chaparral.StateItem, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	elem, exists := db.objects[objectKey{storeID: storeID, id: objID}]
+	if !exists {
+		return nil, fmt.Errorf("object %q: %w", objID, fs.ErrNotExist)
+	}
+	db.lru.MoveToFront(elem)
+	obj := elem.Value.(*chaparral.ObjectManifest)
+	if version < 1 || version > len(obj.VersionStates) {
+		return nil, nil
+	}
+	var items []chaparral.StateItem
+	for digest, paths := range obj.VersionStates[version-1] {
+		for _, p := range paths {
+			if p <= after || !strings.HasPrefix(p, prefix) {
+				continue
+			}
+			items = append(items, chaparral.StateItem{
+				Path:   p,
+				Digest: digest,
+				Size:   obj.Manifest[digest].Size,
+			})
+		}
+	}
+	slices.SortFunc(items, func(a, b chaparral.StateItem) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (db *MemoryDB) GetObjectIDs(_ context.Context, storeID string) ([]string, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	var ids []string
+	for key := range db.index {
+		if key.storeID == storeID {
+			ids = append(ids, key.id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (db *MemoryDB) ListObjects(_ context.Context, storeID string, limit int, offset int) ([]chaparral.ObjectListItem, error) {
+	return db.listObjects(storeID, nil, limit, offset), nil
+}
+
+func (db *MemoryDB) SearchObjects(_ context.Context, storeID string, query string, digest string, limit int, offset int) ([]chaparral.ObjectListItem, error) {
+	terms := searchTerms(query)
+	if query != "" && len(terms) == 0 {
+		return nil, nil
+	}
+	match := func(entry *objectEntry) bool {
+		if digest != "" {
+			if _, exists := entry.digests[digest]; !exists {
+				return false
+			}
+		}
+		for _, t := range terms {
+			if !slices.Contains(entry.terms, t) {
+				return false
+			}
+		}
+		return true
+	}
+	return db.listObjects(storeID, match, limit, offset), nil
+}
+
+// GetDuplicateContents returns content digests found in more than one object
+// in the storage root, sorted by digest. Sizes are not set.
+func (db *MemoryDB) GetDuplicateContents(_ context.Context, storeID string) ([]chaparral.DuplicateContent, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	objIDs := map[string][]string{}
+	for key, entry := range db.index {
+		if key.storeID != storeID {
+			continue
+		}
+		for digest := range entry.digests {
+			objIDs[digest] = append(objIDs[digest], key.id)
+		}
+	}
+	var dups []chaparral.DuplicateContent
+	for digest, ids := range objIDs {
+		if len(ids) < 2 {
+			continue
+		}
+		sort.Strings(ids)
+		dups = append(dups, chaparral.DuplicateContent{Digest: digest, ObjectIDs: ids})
+	}
+	sort.Slice(dups, func(i, j int) bool { return dups[i].Digest < dups[j].Digest })
+	return dups, nil
+}
+
+// listObjects returns objects in the storage root for which match returns
+// true, sorted by id. If match is nil, all objects are included.
+func (db *MemoryDB) listObjects(storeID string, match func(*objectEntry) bool, limit int, offset int) []chaparral.ObjectListItem {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	var items []chaparral.ObjectListItem
+	for key, entry := range db.index {
+		if key.storeID != storeID || (match != nil && !match(entry)) {
+			continue
+		}
+		item := entry.item
+		item.Metadata = copyMetadata(entry.item.Metadata)
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+func (db *MemoryDB) DeleteObject(_ context.Context, storeID string, objID string) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	key := objectKey{storeID: storeID, id: objID}
+	delete(db.index, key)
+	if elem, exists := db.objects[key]; exists {
+		db.lru.Remove(elem)
+		delete(db.objects, key)
+	}
+	return nil
+}
+
+func (db *MemoryDB) SetAuditResult(_ context.Context, result *audit.Result) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	db.audits[objectKey{storeID: result.StorageRootID, id: result.Path}] = copyAuditResult(result)
+	return nil
+}
+
+func (db *MemoryDB) GetAuditResult(_ context.Context, storeID string, path string) (*audit.Result, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	result, exists := db.audits[objectKey{storeID: storeID, id: path}]
+	if !exists {
+		return nil, fmt.Errorf("audit result for %q: %w", path, fs.ErrNotExist)
+	}
+	return copyAuditResult(result), nil
+}
+
+func (db *MemoryDB) ListAuditResults(_ context.Context, storeID string, failedOnly bool, limit int, offset int) ([]audit.Result, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	var results []audit.Result
+	for key, result := range db.audits {
+		if key.storeID != storeID || (failedOnly && result.Valid()) {
+			continue
+		}
+		results = append(results, *copyAuditResult(result))
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Checked.After(results[j].Checked)
+	})
+	if offset >= len(results) {
+		return nil, nil
+	}
+	results = results[offset:]
+	if limit < len(results) {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+func (db *MemoryDB) SetVersionTag(_ context.Context, tag *chaparral.VersionTag) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	key := objectKey{storeID: tag.StorageRootID, id: tag.ID}
+	if db.tags[key] == nil {
+		db.tags[key] = map[string]chaparral.VersionTag{}
+	}
+	db.tags[key][tag.Name] = *tag
+	return nil
+}
+
+func (db *MemoryDB) GetVersionTag(_ context.Context, storeID string, objID string, name string) (*chaparral.VersionTag, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	tag, exists := db.tags[objectKey{storeID: storeID, id: objID}][name]
+	if !exists {
+		return nil, fmt.Errorf("version tag %q: %w", name, fs.ErrNotExist)
+	}
+	return &tag, nil
+}
+
+func (db *MemoryDB) ListVersionTags(_ context.Context, storeID string, objID string) ([]chaparral.VersionTag, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	objTags := db.tags[objectKey{storeID: storeID, id: objID}]
+	tags := make([]chaparral.VersionTag, 0, len(objTags))
+	for _, tag := range objTags {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+func (db *MemoryDB) DeleteVersionTag(_ context.Context, storeID string, objID string, name string) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	delete(db.tags[objectKey{storeID: storeID, id: objID}], name)
+	return nil
+}
+
+func (db *MemoryDB) DeleteVersionTags(_ context.Context, storeID string, objID string) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	delete(db.tags, objectKey{storeID: storeID, id: objID})
+	return nil
+}
+
+func (db *MemoryDB) CreateDeliveries(_ context.Context, deliveries []webhook.Delivery) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	for _, d := range deliveries {
+		if _, exists := db.hooks[d.ID]; exists {
+			return fmt.Errorf("webhook delivery %q already exists", d.ID)
+		}
+	}
+	for _, d := range deliveries {
+		db.hooks[d.ID] = d
+	}
+	db.trimDeliveries()
+	return nil
+}
+
+// trimDeliveries removes the oldest completed deliveries if there are more
+// than memoryLogSize. Pending deliveries are kept. The caller must hold the
+// lock.
+func (db *MemoryDB) trimDeliveries() {
+	if len(db.hooks) <= memoryLogSize {
+		return
+	}
+	var done []webhook.Delivery
+	for _, d := range db.hooks {
+		if d.Status != webhook.StatusPending {
+			done = append(done, d)
+		}
+	}
+	sort.Slice(done, func(i, j int) bool { return done[i].Created.Before(done[j].Created) })
+	for _, d := range done {
+		if len(db.hooks) <= memoryLogSize {
+			break
+		}
+		delete(db.hooks, d.ID)
+	}
+}
+
+func (db *MemoryDB) ClaimPendingDeliveries(_ context.Context, before time.Time, until time.Time, limit int) ([]webhook.Delivery, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	var pending []webhook.Delivery
+	for _, d := range db.hooks {
+		if d.Status == webhook.StatusPending && !d.NextAttempt.After(before) {
+			pending = append(pending, d)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].NextAttempt.Before(pending[j].NextAttempt)
+	})
+	if limit < len(pending) {
+		pending = pending[:limit]
+	}
+	for i := range pending {
+		pending[i].NextAttempt = until
+		db.hooks[pending[i].ID] = pending[i]
+	}
+	return pending, nil
+}
+
+func (db *MemoryDB) UpdateDelivery(_ context.Context, d *webhook.Delivery) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	existing, exists := db.hooks[d.ID]
+	if !exists {
+		return nil
+	}
+	existing.Status = d.Status
+	existing.Attempts = d.Attempts
+	existing.NextAttempt = d.NextAttempt
+	existing.LastStatus = d.LastStatus
+	existing.LastError = d.LastError
+	existing.Updated = d.Updated
+	db.hooks[d.ID] = existing
+	return nil
+}
+
+func (db *MemoryDB) ListDeliveries(_ context.Context, endpointID string, limit int, offset int) ([]webhook.Delivery, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	var deliveries []webhook.Delivery
+	for _, d := range db.hooks {
+		if endpointID != "" && d.EndpointID != endpointID {
+			continue
+		}
+		deliveries = append(deliveries, d)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		a, b := deliveries[i], deliveries[j]
+		if !a.Created.Equal(b.Created) {
+			return a.Created.After(b.Created)
+		}
+		return a.ID < b.ID
+	})
+	if offset >= len(deliveries) {
+		return nil, nil
+	}
+	deliveries = deliveries[offset:]
+	if limit < len(deliveries) {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (db *MemoryDB) AppendEvent(_ context.Context, event *eventlog.Event) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	db.lastSeq++
+	event.Seq = db.lastSeq
+	db.events = append(db.events, *event)
+	if extra := len(db.events) - memoryLogSize; extra > 0 {
+		db.events = slices.Delete(db.events, 0, extra)
+	}
+	return nil
+}
+
+func (db *MemoryDB) ListEvents(_ context.Context, after int64, limit int) ([]eventlog.Event, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	start, _ := slices.BinarySearchFunc(db.events, after+1, func(e eventlog.Event, seq int64) int {
+		return cmp.Compare(e.Seq, seq)
+	})
+	events := db.events[start:]
+	if limit < len(events) {
+		events = events[:limit]
+	}
+	return slices.Clone(events), nil
+}
+
+func (db *MemoryDB) LastEventSeq(_ context.Context) (int64, error) {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	if len(db.events) == 0 {
+		return 0, nil
+	}
+	return db.events[len(db.events)-1].Seq, nil
+}
+
+func (db *MemoryDB) DeleteEvents(_ context.Context, before time.Time) error {
+	db.mx.Lock()
+	defer db.mx.Unlock()
+	db.events = slices.DeleteFunc(db.events, func(e eventlog.Event) bool {
+		return e.Created.Before(before)
+	})
+	return nil
+}
+
+func copyUploader(upper *uploader.PersistentUploader) *uploader.PersistentUploader {
+	cp := *upper
+	cp.Config.Algs = slices.Clone(upper.Config.Algs)
+	cp.Uploads = make([]uploader.Upload, len(upper.Uploads))
+	for i, up := range upper.Uploads {
+		cp.Uploads[i] = copyUpload(up)
+	}
+	cp.Multiparts = slices.Clone(upper.Multiparts)
+	return &cp
+}
+
+func copyUpload(up uploader.Upload) uploader.Upload {
+	up.Digests = maps.Clone(up.Digests)
+	return up
+}
+
+func copyObjectManifest(obj *chaparral.ObjectManifest) *chaparral.ObjectManifest {
+	cp := copyObjectHeader(obj)
+	cp.Manifest = make(chaparral.Manifest, len(obj.Manifest))
+	for digest, info := range obj.Manifest {
+		cp.Manifest[digest] = copyFileInfo(info)
+	}
+	return cp
+}
+
+// copyObjectHeader returns a copy of obj without its manifest.
+func copyObjectHeader(obj *chaparral.ObjectManifest) *chaparral.ObjectManifest {
+	cp := *obj
+	cp.Manifest = nil
+	cp.Metadata = copyMetadata(obj.Metadata)
+	// head paths are only used to index the object for search, and version
+	// states are only read with ListVersionPaths.
+	cp.HeadPaths = nil
+	cp.VersionStates = nil
+	return &cp
+}
+
+func copyFileInfo(info chaparral.FileInfo) chaparral.FileInfo {
+	info.Paths = slices.Clone(info.Paths)
+	info.Fixity = maps.Clone(info.Fixity)
+	return info
+}
+
+func copyMetadata(meta *chaparral.ObjectMetadata) *chaparral.ObjectMetadata {
+	if meta == nil {
+		return nil
+	}
+	cp := *meta
+	cp.Creators = slices.Clone(meta.Creators)
+	cp.Keywords = slices.Clone(meta.Keywords)
+	return &cp
+}
+
+func copyAuditResult(result *audit.Result) *audit.Result {
+	cp := *result
+	cp.Errors = slices.Clone(result.Errors)
+	cp.Warnings = slices.Clone(result.Warnings)
+	return &cp
+}
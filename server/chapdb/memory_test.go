@@ -0,0 +1,62 @@
+package chapdb_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/carlmjohnson/be"
+	"github.com/srerickson/chaparral"
+	"github.com/srerickson/chaparral/server/chapdb"
+)
+
+func TestMemory(t *testing.T) {
+	testConformance(t, func(t *testing.T) chapdb.DB {
+		db, err := chapdb.OpenDSN(chapdb.MemoryDSN, true)
+		be.NilErr(t, err)
+		return db
+	})
+}
+
+func TestMemoryEviction(t *testing.T) {
+	ctx := context.Background()
+	db := chapdb.NewMemoryDB(2)
+	setObj := func(id string) error {
+		return db.SetObjectManifest(ctx, &chaparral.ObjectManifest{
+			ObjectRef: chaparral.ObjectRef{StorageRootID: "store", ID: id},
+			Manifest:  chaparral.Manifest{"digest-" + id: {Paths: []string{"file"}}},
+			HeadPaths: []string{id + ".txt"},
+		})
+	}
+	for i := 0; i < 3; i++ {
+		be.NilErr(t, setObj(fmt.Sprintf("obj-%d", i)))
+		if i == 1 {
+			// obj-0 is now the most recently used
+			_, err := db.GetObjectManifest(ctx, "store", "obj-0")
+			be.NilErr(t, err)
+		}
+	}
+	_, err := db.GetObjectManifest(ctx, "store", "obj-1")
+	be.Nonzero(t, err)
+	_, err = db.GetObjectManifest(ctx, "store", "obj-2")
+	be.NilErr(t, err)
+
+	// evicted objects are still listed and searchable
+	ids, err := db.GetObjectIDs(ctx, "store")
+	be.NilErr(t, err)
+	be.DeepEqual(t, []string{"obj-0", "obj-1", "obj-2"}, ids)
+	items, err := db.ListObjects(ctx, "store", 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 3, len(items))
+	items, err = db.SearchObjects(ctx, "store", "", "digest-obj-1", 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(items))
+	be.Equal(t, "obj-1", items[0].ID)
+	items, err = db.SearchObjects(ctx, "store", "obj", "", 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 3, len(items))
+	be.NilErr(t, db.DeleteObject(ctx, "store", "obj-1"))
+	ids, err = db.GetObjectIDs(ctx, "store")
+	be.NilErr(t, err)
+	be.DeepEqual(t, []string{"obj-0", "obj-2"}, ids)
+}
@@ -5,6 +5,7 @@ import (
 	"embed"
 	"errors"
 
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
 	"modernc.org/sqlite"
 )
@@ -12,15 +13,24 @@ import (
 //go:embed sqlite/migrations/*.sql
 var sqliteMigrations embed.FS
 
+//go:embed postgres/migrations/*.sql
+var postgresMigrations embed.FS
+
 func Migrate(db *sql.DB) error {
 	goose.SetLogger(goose.NopLogger())
-	goose.SetBaseFS(sqliteMigrations)
 	switch db.Driver().(type) {
 	case *sqlite.Driver:
+		goose.SetBaseFS(sqliteMigrations)
 		if err := goose.SetDialect("sqlite3"); err != nil {
 			return err
 		}
 		return goose.Up(db, "sqlite/migrations")
+	case *stdlib.Driver:
+		goose.SetBaseFS(postgresMigrations)
+		if err := goose.SetDialect("postgres"); err != nil {
+			return err
+		}
+		return goose.Up(db, "postgres/migrations")
 	}
 	return errors.New("unsupported database type")
 }
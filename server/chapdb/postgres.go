@@ -0,0 +1,840 @@
+package chapdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/srerickson/chaparral"
+	"github.com/srerickson/chaparral/server/audit"
+	postgres "github.com/srerickson/chaparral/server/chapdb/postgres_gen"
+	"github.com/srerickson/chaparral/server/eventlog"
+	"github.com/srerickson/chaparral/server/uploader"
+	"github.com/srerickson/chaparral/server/webhook"
+	"github.com/srerickson/ocfl-go"
+)
+
+// PostgresDB implements uploader.Persistence, store.ObjectCache, and
+// audit.Persistence using PostgreSQL.
+type PostgresDB sql.DB
+
+func (db *PostgresDB) Close() error {
+	return (*sql.DB)(db).Close()
+}
+
+func (db *PostgresDB) sqlDB() *sql.DB {
+	return (*sql.DB)(db)
+}
+
+func (db *PostgresDB) CreateUploader(ctx context.Context, upper *uploader.PersistentUploader) error {
+	qry := postgres.New(db.sqlDB())
+	_, err := qry.CreateUploader(ctx, postgres.CreateUploaderParams{
+		ID:          upper.ID,
+		UserID:      upper.Config.UserID,
+		Algs:        strings.Join(upper.Config.Algs, ","),
+		Description: upper.Config.Description,
+		CreatedAt:   upper.CreatedAt.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	for _, up := range upper.Uploads {
+		if err := db.CreateUpload(ctx, upper.ID, &up); err != nil {
+			return err
+		}
+	}
+	for _, mp := range upper.Multiparts {
+		if err := db.CreateMultipartUpload(ctx, upper.ID, &mp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *PostgresDB) CreateUpload(ctx context.Context, upID string, up *uploader.Upload) error {
+	qry := postgres.New(db.sqlDB())
+	digBytes, err := json.Marshal(up.Digests)
+	if err != nil {
+		return err
+	}
+	_, err = qry.CreateUpload(ctx, postgres.CreateUploadParams{
+		ID:         up.Name,
+		UploaderID: upID,
+		Size:       up.Size,
+		Digests:    digBytes,
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *PostgresDB) CreateMultipartUpload(ctx context.Context, upID string, mp *uploader.PendingMultipart) error {
+	qry := postgres.New(db.sqlDB())
+	return qry.CreateMultipartUpload(ctx, postgres.CreateMultipartUploadParams{
+		ID:         mp.Name,
+		UploaderID: upID,
+		UploadID:   mp.UploadID,
+		Size:       mp.Size,
+		ExpiresAt:  mp.Expires.UTC(),
+	})
+}
+
+func (db *PostgresDB) DeleteMultipartUpload(ctx context.Context, upID string, name string) error {
+	qry := postgres.New(db.sqlDB())
+	return qry.DeleteMultipartUpload(ctx, postgres.DeleteMultipartUploadParams{
+		UploaderID: upID,
+		ID:         name,
+	})
+}
+
+// list of all uploaderIDs
+func (db *PostgresDB) GetUploaderIDs(ctx context.Context) ([]string, error) {
+	qry := postgres.New(db.sqlDB())
+	return qry.GetUploaderIDs(ctx)
+}
+
+// GetUploader with all it's uploads
+func (db *PostgresDB) GetUploader(ctx context.Context, id string) (*uploader.PersistentUploader, error) {
+	qry := postgres.New(db.sqlDB())
+	sqlUpper, err := qry.GetUploader(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	upper := &uploader.PersistentUploader{
+		ID:        sqlUpper.ID,
+		CreatedAt: sqlUpper.CreatedAt.UTC(),
+		Config: uploader.Config{
+			UserID:      sqlUpper.UserID,
+			Algs:        strings.Split(sqlUpper.Algs, ","),
+			Description: sqlUpper.Description,
+		},
+	}
+	sqlUps, err := qry.GetUploads(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	upper.Uploads = make([]uploader.Upload, len(sqlUps))
+	for i, u := range sqlUps {
+		var digests ocfl.DigestSet
+		if err := json.Unmarshal(u.Digests, &digests); err != nil {
+			return nil, err
+		}
+		upper.Uploads[i] = uploader.Upload{
+			Name:    u.ID,
+			Size:    u.Size,
+			Digests: digests,
+		}
+	}
+	sqlMultiparts, err := qry.GetMultipartUploads(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, mp := range sqlMultiparts {
+		upper.Multiparts = append(upper.Multiparts, uploader.PendingMultipart{
+			Name:     mp.ID,
+			UploadID: mp.UploadID,
+			Size:     mp.Size,
+			Expires:  mp.ExpiresAt.UTC(),
+		})
+	}
+	return upper, nil
+}
+
+// Delete the uploader and all its uploads and pending multipart uploads
+func (db *PostgresDB) DeleteUploader(ctx context.Context, id string) error {
+	qry := postgres.New(db.sqlDB())
+
+	if err := qry.DeleteUploads(ctx, id); err != nil {
+		return err
+	}
+
+	if err := qry.DeleteMultipartUploads(ctx, id); err != nil {
+		return err
+	}
+
+	if err := qry.DeleteUploader(ctx, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+// number of uploaders
+func (db *PostgresDB) CountUploaders(ctx context.Context) (int, error) {
+	qry := postgres.New(db.sqlDB())
+	n, err := qry.CountUploaders(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+// total size and number of uploads in the user's uploaders
+func (db *PostgresDB) GetUserUsage(ctx context.Context, userID string) (*uploader.Usage, error) {
+	qry := postgres.New(db.sqlDB())
+	row, err := qry.GetUserUsage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &uploader.Usage{Files: int(row.Files), Bytes: row.Bytes}, nil
+}
+
+func (db *PostgresDB) SetObjectManifest(ctx context.Context, obj *chaparral.ObjectManifest) (err error) {
+	var tx *sql.Tx
+	tx, err = db.sqlDB().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if rbErr := tx.Rollback(); rbErr != nil {
+			err = errors.Join(err, rbErr)
+		}
+	}()
+	qry := postgres.New(db.sqlDB()).WithTx(tx)
+	var meta string
+	meta, err = encodeMetadata(obj.Metadata)
+	if err != nil {
+		return
+	}
+	// versions that are already cached are kept: the states of an object's
+	// existing versions don't change.
+	var keep int64
+	prev, err := qry.GetObjectHead(ctx, postgres.GetObjectHeadParams{
+		StoreID: obj.StorageRootID,
+		OcflID:  obj.ID,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return
+	}
+	if err == nil && prev.Path == obj.Path && prev.Head <= int64(obj.Head) {
+		keep = prev.Head
+	}
+	objID, err := qry.CreateObject(ctx, postgres.CreateObjectParams{
+		StoreID:         obj.StorageRootID,
+		OcflID:          obj.ID,
+		Path:            obj.Path,
+		Spec:            obj.Spec,
+		Alg:             obj.DigestAlgorithm,
+		InventoryDigest: obj.InventoryDigest,
+		Metadata:        meta,
+		SearchText:      searchText(obj),
+		Head:            int64(obj.Head),
+	})
+	if err != nil {
+		return
+	}
+	// replace existing manifest entries
+	err = qry.DeleteObjectContents(ctx, postgres.DeleteObjectContentsParams{
+		StoreID: obj.StorageRootID,
+		OcflID:  obj.ID,
+	})
+	if err != nil {
+		return
+	}
+	for digest, info := range obj.Manifest {
+		var fixBytes, pathBytes []byte
+		fixBytes, err = json.Marshal(info.Fixity)
+		if err != nil {
+			return
+		}
+		pathBytes, err = json.Marshal(info.Paths)
+		if err != nil {
+			return
+		}
+		_, err = qry.CreateObjectContent(ctx, postgres.CreateObjectContentParams{
+			ObjectID: objID,
+			Digest:   digest,
+			Paths:    pathBytes,
+			Fixity:   fixBytes,
+			Size:     info.Size,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// replace version states that aren't kept
+	err = qry.DeleteVersionPathsAfter(ctx, postgres.DeleteVersionPathsAfterParams{
+		ObjectID: objID,
+		Num:      keep,
+	})
+	if err != nil {
+		return
+	}
+	for i := int(keep); i < len(obj.VersionStates); i++ {
+		for digest, paths := range obj.VersionStates[i] {
+			for _, p := range paths {
+				err = qry.CreateVersionPath(ctx, postgres.CreateVersionPathParams{
+					ObjectID: objID,
+					Num:      int64(i + 1),
+					Path:     p,
+					Digest:   digest,
+				})
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+	err = tx.Commit()
+	return
+}
+
+func (db *PostgresDB) GetObjectManifest(ctx context.Context, storeID, objID string) (*chaparral.ObjectManifest, error) {
+	return db.getObjectManifest(ctx, storeID, objID, func(qry *postgres.Queries, id int64) ([]postgres.ObjectContent, error) {
+		return qry.GetObjectContents(ctx, id)
+	})
+}
+
+// GetObjectManifestPage is like GetObjectManifest, but the manifest only
+// includes up to limit digests that sort after the given digest. If prefix
+// isn't empty, only digests with a logical path that has the prefix, in any
+// cached version state, are included.
+func (db *PostgresDB) GetObjectManifestPage(ctx context.Context, storeID, objID string, prefix string, after string, limit int) (*chaparral.ObjectManifest, error) {
+	return db.getObjectManifest(ctx, storeID, objID, func(qry *postgres.Queries, id int64) ([]postgres.ObjectContent, error) {
+		return qry.ListObjectContents(ctx, postgres.ListObjectContentsParams{
+			ObjectID: id,
+			Digest:   after,
+			Prefix:   prefix,
+			Limit:    int32(limit),
+		})
+	})
+}
+
+// ListVersionPaths returns up to limit logical paths, sorted by path, from
+// the cached state of the object version. Only paths with the prefix that
+// sort after the given path are included.
+func (db *PostgresDB) ListVersionPaths(ctx context.Context, storeID, objID string, version int, prefix string, after string, limit int) ([]chaparral.StateItem, error) {
+	qry := postgres.New(db.sqlDB())
+	objDB, err := qry.GetObjectHead(ctx, postgres.GetObjectHeadParams{
+		StoreID: storeID,
+		OcflID:  objID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := qry.ListVersionPaths(ctx, postgres.ListVersionPathsParams{
+		ObjectID: objDB.ID,
+		Num:      int64(version),
+		After:    after,
+		Prefix:   prefix,
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]chaparral.StateItem, len(rows))
+	for i, row := range rows {
+		items[i] = chaparral.StateItem{
+			Path:   row.Path,
+			Digest: row.Digest,
+			Size:   row.Size,
+		}
+	}
+	return items, nil
+}
+
+// getObjectManifest returns the cached object with the contents returned by
+// contents. The object and its contents are read in the same snapshot so
+// they are consistent with concurrent calls to SetObjectManifest.
+func (db *PostgresDB) getObjectManifest(ctx context.Context, storeID, objID string, contents func(*postgres.Queries, int64) ([]postgres.ObjectContent, error)) (*chaparral.ObjectManifest, error) {
+	tx, err := db.sqlDB().BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	qry := postgres.New(db.sqlDB()).WithTx(tx)
+	objDB, err := qry.GetObject(ctx, postgres.GetObjectParams{
+		StoreID: storeID,
+		OcflID:  objID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	obj := &chaparral.ObjectManifest{
+		ObjectRef: chaparral.ObjectRef{
+			ID:            objDB.OcflID,
+			StorageRootID: objDB.StoreID,
+		},
+		Path:            objDB.Path,
+		DigestAlgorithm: objDB.Alg,
+		Spec:            objDB.Spec,
+		InventoryDigest: objDB.InventoryDigest,
+		Manifest:        chaparral.Manifest{},
+		Head:            int(objDB.Head),
+	}
+	obj.Metadata, err = decodeMetadata(objDB.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	conts, err := contents(qry, objDB.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conts {
+		info := chaparral.FileInfo{Size: c.Size}
+		if err := json.Unmarshal(c.Paths, &info.Paths); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(c.Fixity, &info.Fixity); err != nil {
+			return nil, err
+		}
+		obj.Manifest[c.Digest] = info
+	}
+
+	return obj, nil
+}
+
+// GetObjectIDs returns the ids of all cached objects for the storage root.
+func (db *PostgresDB) GetObjectIDs(ctx context.Context, storeID string) ([]string, error) {
+	return postgres.New(db.sqlDB()).GetObjectIDs(ctx, storeID)
+}
+
+// ListObjects returns cached objects for the storage root, sorted by id.
+func (db *PostgresDB) ListObjects(ctx context.Context, storeID string, limit int, offset int) ([]chaparral.ObjectListItem, error) {
+	rows, err := postgres.New(db.sqlDB()).ListObjects(ctx, postgres.ListObjectsParams{
+		StoreID: storeID,
+		Limit:   int32(limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return postgresObjectListItems(rows)
+}
+
+// SearchObjects returns cached objects for the storage root that match query
+// and digest, sorted by id.
+func (db *PostgresDB) SearchObjects(ctx context.Context, storeID string, query string, digest string, limit int, offset int) ([]chaparral.ObjectListItem, error) {
+	terms := searchTerms(query)
+	if query != "" && len(terms) == 0 {
+		return nil, nil
+	}
+	rows, err := postgres.New(db.sqlDB()).SearchObjects(ctx, postgres.SearchObjectsParams{
+		StoreID: storeID,
+		Query:   strings.Join(terms, " "),
+		Digest:  digest,
+		Limit:   int32(limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]postgres.ListObjectsRow, len(rows))
+	for i, row := range rows {
+		items[i] = postgres.ListObjectsRow(row)
+	}
+	return postgresObjectListItems(items)
+}
+
+// GetDuplicateContents returns content digests found in more than one cached
+// object in the storage root, sorted by digest. Sizes are not set.
+func (db *PostgresDB) GetDuplicateContents(ctx context.Context, storeID string) ([]chaparral.DuplicateContent, error) {
+	rows, err := postgres.New(db.sqlDB()).GetDuplicateContents(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	var dups []chaparral.DuplicateContent
+	for _, row := range rows {
+		dups = appendDuplicate(dups, row.Digest, row.OcflID)
+	}
+	return dups, nil
+}
+
+func postgresObjectListItems(rows []postgres.ListObjectsRow) ([]chaparral.ObjectListItem, error) {
+	items := make([]chaparral.ObjectListItem, len(rows))
+	for i, row := range rows {
+		meta, err := decodeMetadata(row.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = chaparral.ObjectListItem{
+			ObjectRef: chaparral.ObjectRef{StorageRootID: row.StoreID, ID: row.OcflID},
+			Path:      row.Path,
+			Metadata:  meta,
+		}
+	}
+	return items, nil
+}
+
+func (db *PostgresDB) DeleteObject(ctx context.Context, storeID, objectID string) (err error) {
+	var tx *sql.Tx
+	tx, err = db.sqlDB().BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if rbErr := tx.Rollback(); rbErr != nil {
+			err = errors.Join(err, rbErr)
+		}
+	}()
+	qry := postgres.New(db.sqlDB()).WithTx(tx)
+	err = qry.DeleteObjectContents(ctx, postgres.DeleteObjectContentsParams{
+		StoreID: storeID,
+		OcflID:  objectID,
+	})
+	if err != nil {
+		return
+	}
+	err = qry.DeleteVersionPaths(ctx, postgres.DeleteVersionPathsParams{
+		StoreID: storeID,
+		OcflID:  objectID,
+	})
+	if err != nil {
+		return
+	}
+	err = qry.DeleteObject(ctx, postgres.DeleteObjectParams{
+		StoreID: storeID,
+		OcflID:  objectID,
+	})
+	if err != nil {
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+func (db *PostgresDB) SetVersionTag(ctx context.Context, tag *chaparral.VersionTag) error {
+	return postgres.New(db.sqlDB()).SetVersionTag(ctx, postgres.SetVersionTagParams{
+		StoreID:   tag.StorageRootID,
+		OcflID:    tag.ID,
+		Name:      tag.Name,
+		Version:   int32(tag.Version),
+		CreatedAt: tag.Created.UTC(),
+	})
+}
+
+func (db *PostgresDB) GetVersionTag(ctx context.Context, storeID string, objID string, name string) (*chaparral.VersionTag, error) {
+	row, err := postgres.New(db.sqlDB()).GetVersionTag(ctx, postgres.GetVersionTagParams{
+		StoreID: storeID,
+		OcflID:  objID,
+		Name:    name,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("version tag %q: %w", name, fs.ErrNotExist)
+		}
+		return nil, err
+	}
+	tag := postgresVersionTag(row)
+	return &tag, nil
+}
+
+func (db *PostgresDB) ListVersionTags(ctx context.Context, storeID string, objID string) ([]chaparral.VersionTag, error) {
+	rows, err := postgres.New(db.sqlDB()).ListVersionTags(ctx, postgres.ListVersionTagsParams{
+		StoreID: storeID,
+		OcflID:  objID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]chaparral.VersionTag, len(rows))
+	for i, row := range rows {
+		tags[i] = postgresVersionTag(row)
+	}
+	return tags, nil
+}
+
+func (db *PostgresDB) DeleteVersionTag(ctx context.Context, storeID string, objID string, name string) error {
+	return postgres.New(db.sqlDB()).DeleteVersionTag(ctx, postgres.DeleteVersionTagParams{
+		StoreID: storeID,
+		OcflID:  objID,
+		Name:    name,
+	})
+}
+
+func (db *PostgresDB) DeleteVersionTags(ctx context.Context, storeID string, objID string) error {
+	return postgres.New(db.sqlDB()).DeleteVersionTags(ctx, postgres.DeleteVersionTagsParams{
+		StoreID: storeID,
+		OcflID:  objID,
+	})
+}
+
+func postgresVersionTag(row postgres.VersionTag) chaparral.VersionTag {
+	return chaparral.VersionTag{
+		ObjectRef: chaparral.ObjectRef{StorageRootID: row.StoreID, ID: row.OcflID},
+		Name:      row.Name,
+		Version:   int(row.Version),
+		Created:   row.CreatedAt.UTC(),
+	}
+}
+
+func (db *PostgresDB) SetAuditResult(ctx context.Context, result *audit.Result) error {
+	qry := postgres.New(db.sqlDB())
+	errBytes, err := json.Marshal(result.Errors)
+	if err != nil {
+		return err
+	}
+	warnBytes, err := json.Marshal(result.Warnings)
+	if err != nil {
+		return err
+	}
+	return qry.SetObjectAudit(ctx, postgres.SetObjectAuditParams{
+		StoreID:   result.StorageRootID,
+		Path:      result.Path,
+		OcflID:    result.ObjectID,
+		CheckedAt: result.Checked.UTC(),
+		Valid:     result.Valid(),
+		Errors:    errBytes,
+		Warnings:  warnBytes,
+	})
+}
+
+func (db *PostgresDB) GetAuditResult(ctx context.Context, storeID string, path string) (*audit.Result, error) {
+	qry := postgres.New(db.sqlDB())
+	row, err := qry.GetObjectAudit(ctx, postgres.GetObjectAuditParams{
+		StoreID: storeID,
+		Path:    path,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pgAuditResult(row)
+}
+
+func (db *PostgresDB) ListAuditResults(ctx context.Context, storeID string, failedOnly bool, limit int, offset int) ([]audit.Result, error) {
+	qry := postgres.New(db.sqlDB())
+	var rows []postgres.ObjectAudit
+	var err error
+	switch {
+	case failedOnly:
+		rows, err = qry.ListFailedObjectAudits(ctx, postgres.ListFailedObjectAuditsParams{
+			StoreID: storeID,
+			Limit:   int32(limit),
+			Offset:  int32(offset),
+		})
+	default:
+		rows, err = qry.ListObjectAudits(ctx, postgres.ListObjectAuditsParams{
+			StoreID: storeID,
+			Limit:   int32(limit),
+			Offset:  int32(offset),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	results := make([]audit.Result, len(rows))
+	for i, row := range rows {
+		r, err := pgAuditResult(row)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = *r
+	}
+	return results, nil
+}
+
+func pgAuditResult(row postgres.ObjectAudit) (*audit.Result, error) {
+	result := &audit.Result{
+		StorageRootID: row.StoreID,
+		Path:          row.Path,
+		ObjectID:      row.OcflID,
+		Checked:       row.CheckedAt.UTC(),
+	}
+	if err := json.Unmarshal(row.Errors, &result.Errors); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(row.Warnings, &result.Warnings); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (db *PostgresDB) CreateDeliveries(ctx context.Context, deliveries []webhook.Delivery) (err error) {
+	var tx *sql.Tx
+	tx, err = db.sqlDB().BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if rbErr := tx.Rollback(); rbErr != nil {
+			err = errors.Join(err, rbErr)
+		}
+	}()
+	qry := postgres.New(db.sqlDB()).WithTx(tx)
+	for _, d := range deliveries {
+		var payload []byte
+		payload, err = json.Marshal(d.Event)
+		if err != nil {
+			return
+		}
+		err = qry.CreateWebhookDelivery(ctx, postgres.CreateWebhookDeliveryParams{
+			ID:            d.ID,
+			EndpointID:    d.EndpointID,
+			EventID:       d.Event.ID,
+			EventType:     d.Event.Type,
+			Payload:       payload,
+			Status:        d.Status,
+			Attempts:      int32(d.Attempts),
+			NextAttemptAt: d.NextAttempt.UTC(),
+			LastStatus:    int32(d.LastStatus),
+			LastError:     d.LastError,
+			CreatedAt:     d.Created.UTC(),
+			UpdatedAt:     d.Updated.UTC(),
+		})
+		if err != nil {
+			return
+		}
+	}
+	err = tx.Commit()
+	return
+}
+
+func (db *PostgresDB) ClaimPendingDeliveries(ctx context.Context, before time.Time, until time.Time, limit int) ([]webhook.Delivery, error) {
+	rows, err := postgres.New(db.sqlDB()).ClaimPendingWebhookDeliveries(ctx, postgres.ClaimPendingWebhookDeliveriesParams{
+		Until:  until.UTC(),
+		Before: before.UTC(),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pgDeliveries(rows)
+}
+
+func (db *PostgresDB) UpdateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	return postgres.New(db.sqlDB()).UpdateWebhookDelivery(ctx, postgres.UpdateWebhookDeliveryParams{
+		ID:            d.ID,
+		Status:        d.Status,
+		Attempts:      int32(d.Attempts),
+		NextAttemptAt: d.NextAttempt.UTC(),
+		LastStatus:    int32(d.LastStatus),
+		LastError:     d.LastError,
+		UpdatedAt:     d.Updated.UTC(),
+	})
+}
+
+func (db *PostgresDB) ListDeliveries(ctx context.Context, endpointID string, limit int, offset int) ([]webhook.Delivery, error) {
+	qry := postgres.New(db.sqlDB())
+	var rows []postgres.WebhookDelivery
+	var err error
+	switch {
+	case endpointID != "":
+		rows, err = qry.ListEndpointWebhookDeliveries(ctx, postgres.ListEndpointWebhookDeliveriesParams{
+			EndpointID: endpointID,
+			Limit:      int32(limit),
+			Offset:     int32(offset),
+		})
+	default:
+		rows, err = qry.ListWebhookDeliveries(ctx, postgres.ListWebhookDeliveriesParams{
+			Limit:  int32(limit),
+			Offset: int32(offset),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pgDeliveries(rows)
+}
+
+func pgDeliveries(rows []postgres.WebhookDelivery) ([]webhook.Delivery, error) {
+	deliveries := make([]webhook.Delivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = webhook.Delivery{
+			ID:          row.ID,
+			EndpointID:  row.EndpointID,
+			Status:      row.Status,
+			Attempts:    int(row.Attempts),
+			NextAttempt: row.NextAttemptAt.UTC(),
+			LastStatus:  int(row.LastStatus),
+			LastError:   row.LastError,
+			Created:     row.CreatedAt.UTC(),
+			Updated:     row.UpdatedAt.UTC(),
+		}
+		if err := json.Unmarshal(row.Payload, &deliveries[i].Event); err != nil {
+			return nil, fmt.Errorf("decoding webhook event for delivery %q: %w", row.ID, err)
+		}
+	}
+	return deliveries, nil
+}
+
+// AppendEvent inserts the event while holding a transaction-level advisory
+// lock. Without the lock, concurrent transactions could commit events out of
+// seq order, and a reader that has seen a later seq would skip the earlier
+// one.
+func (db *PostgresDB) AppendEvent(ctx context.Context, event *eventlog.Event) (err error) {
+	var tx *sql.Tx
+	tx, err = db.sqlDB().BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if rbErr := tx.Rollback(); rbErr != nil {
+			err = errors.Join(err, rbErr)
+		}
+	}()
+	qry := postgres.New(db.sqlDB()).WithTx(tx)
+	if err = qry.LockEvents(ctx); err != nil {
+		return
+	}
+	var seq int64
+	seq, err = qry.AppendEvent(ctx, postgres.AppendEventParams{
+		ID:         event.ID,
+		Type:       event.Type,
+		StoreID:    event.StorageRootID,
+		OcflID:     event.ObjectID,
+		UploaderID: event.UploaderID,
+		UserID:     event.UserID,
+		CreatedAt:  event.Created.UTC(),
+	})
+	if err != nil {
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
+	event.Seq = seq
+	return
+}
+
+func (db *PostgresDB) ListEvents(ctx context.Context, after int64, limit int) ([]eventlog.Event, error) {
+	rows, err := postgres.New(db.sqlDB()).ListEvents(ctx, postgres.ListEventsParams{
+		Seq:   after,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]eventlog.Event, len(rows))
+	for i, row := range rows {
+		events[i] = eventlog.Event{
+			Seq:           row.Seq,
+			ID:            row.ID,
+			Type:          row.Type,
+			Created:       row.CreatedAt.UTC(),
+			StorageRootID: row.StoreID,
+			ObjectID:      row.OcflID,
+			UploaderID:    row.UploaderID,
+			UserID:        row.UserID,
+		}
+	}
+	return events, nil
+}
+
+func (db *PostgresDB) LastEventSeq(ctx context.Context) (int64, error) {
+	return postgres.New(db.sqlDB()).LastEventSeq(ctx)
+}
+
+func (db *PostgresDB) DeleteEvents(ctx context.Context, before time.Time) error {
+	return postgres.New(db.sqlDB()).DeleteEvents(ctx, before.UTC())
+}
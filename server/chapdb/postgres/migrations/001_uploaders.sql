@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE uploaders (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    algs TEXT NOT NULL,
+    description TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+-- +goose Down
+DROP TABLE uploaders;
@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE uploads (
+    id TEXT PRIMARY KEY,
+    size BIGINT NOT NULL,
+    uploader_id TEXT NOT NULL,
+    digests BYTEA NOT NULL
+);
+
+-- +goose Down
+DROP TABLE uploads;
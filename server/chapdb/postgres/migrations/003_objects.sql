@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE objects (
+    id BIGSERIAL PRIMARY KEY,  -- internal db ID
+    store_id TEXT NOT NULL, -- storage root ID
+    ocfl_id TEXT NOT NULL, -- object id from inventory
+    path TEXT NOT NULL, -- object path relative to FS
+    alg TEXT NOT NULL, -- object's primary digest algorithm
+    spec TEXT NOT NULL, -- OCFL spec
+    UNIQUE(store_id, path),
+    UNIQUE(store_id, ocfl_id)
+);
+
+CREATE TABLE versions (
+    object_id BIGINT NOT NULL, -- objects table FK
+    num BIGINT NOT NULL, -- version num (1,2,3,...)
+    state BYTEA NOT NULL, -- json object: {digest: [path1, path2]}
+    message TEXT NOT NULL, -- message saved with object version
+    user_name TEXT, -- user name saved with object version
+    user_address TEXT, -- user address saved with object version
+    created TIMESTAMPTZ NOT NULL, -- create timestamp for object version
+
+    PRIMARY KEY(object_id, num)
+);
+
+CREATE TABLE object_contents (
+    object_id BIGINT NOT NULL, -- objects table FK
+    digest TEXT NOT NULL, -- digest bytes for the object_contents
+    paths BYTEA NOT NULL, -- array of paths
+    fixity BYTEA NOT NULL, -- json object with alternate digests {alg: digest}
+    size BIGINT NOT NULL, -- may not be available
+
+    PRIMARY KEY(object_id, digest)
+);
+
+-- +goose Down
+DROP TABLE objects;
+DROP TABLE versions;
+DROP TABLE object_contents;
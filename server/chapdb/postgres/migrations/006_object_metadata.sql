@@ -0,0 +1,6 @@
+-- +goose Up
+-- JSON-encoded metadata from the object's metadata file (if any)
+ALTER TABLE objects ADD COLUMN metadata TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE objects DROP COLUMN metadata;
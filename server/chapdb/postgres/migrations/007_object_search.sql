@@ -0,0 +1,11 @@
+-- +goose Up
+-- logical paths in the object's most recent version (JSON array)
+ALTER TABLE objects ADD COLUMN head_paths TEXT NOT NULL DEFAULT '';
+-- normalized object metadata and logical paths used for full-text search
+ALTER TABLE objects ADD COLUMN search_text TEXT NOT NULL DEFAULT '';
+CREATE INDEX objects_search_idx ON objects USING GIN (to_tsvector('simple', search_text));
+
+-- +goose Down
+DROP INDEX objects_search_idx;
+ALTER TABLE objects DROP COLUMN search_text;
+ALTER TABLE objects DROP COLUMN head_paths;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE version_tags (
+    store_id TEXT NOT NULL, -- storage root ID
+    ocfl_id TEXT NOT NULL, -- object id
+    name TEXT NOT NULL, -- tag name
+    version INTEGER NOT NULL, -- tagged version number
+    created_at TIMESTAMPTZ NOT NULL, -- time the tag was set
+    PRIMARY KEY(store_id, ocfl_id, name)
+);
+
+-- +goose Down
+DROP TABLE version_tags;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE events (
+    seq BIGSERIAL PRIMARY KEY, -- position in the event log
+    id TEXT NOT NULL, -- event id
+    type TEXT NOT NULL, -- event type
+    store_id TEXT NOT NULL, -- storage root ID (object events)
+    ocfl_id TEXT NOT NULL, -- object id (object events)
+    uploader_id TEXT NOT NULL, -- uploader id (uploader events)
+    user_id TEXT NOT NULL, -- user whose request caused the event
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX events_created_idx ON events (created_at);
+
+-- +goose Down
+DROP INDEX events_created_idx;
+DROP TABLE events;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- the object's most recent version number; zero if version_paths haven't
+-- been cached for the object.
+ALTER TABLE objects ADD COLUMN head BIGINT NOT NULL DEFAULT 0;
+CREATE TABLE version_paths (
+    object_id BIGINT NOT NULL, -- objects table FK
+    num BIGINT NOT NULL, -- version num (1,2,3,...)
+    path TEXT COLLATE "C" NOT NULL, -- logical path in the version state (sorted by byte value)
+    digest TEXT NOT NULL, -- digest of the path's content
+    PRIMARY KEY(object_id, num, path)
+);
+
+-- +goose Down
+DROP TABLE version_paths;
+ALTER TABLE objects DROP COLUMN head;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- head paths are read from version_paths.
+ALTER TABLE objects DROP COLUMN head_paths;
+
+-- +goose Down
+ALTER TABLE objects ADD COLUMN head_paths TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,310 @@
+-- name: CreateUploader :one
+INSERT INTO uploaders (
+    id, 
+    user_id,
+    algs,
+    description,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+
+-- name: GetUploader :one
+SELECT * FROM uploaders WHERE id = $1 LIMIT 1;
+
+-- name: GetUploaderIDs :many
+SELECT id FROM uploaders ORDER BY created_at;
+
+-- name: CountUploaders :one
+SELECT COUNT(*) FROM uploaders;
+
+-- name: DeleteUploader :exec
+DELETE FROM uploaders WHERE id = $1;
+
+-- name: CreateUpload :one
+INSERT INTO uploads (
+    id, 
+    uploader_id,
+    size,
+    digests
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+
+-- name: GetUploads :many
+SELECT * FROM uploads WHERE uploader_id = $1;
+
+-- name: DeleteUploads :exec
+DELETE FROM uploads WHERE uploader_id = $1;
+
+-- name: CreateMultipartUpload :exec
+INSERT INTO multipart_uploads (
+    id,
+    uploader_id,
+    upload_id,
+    size,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+);
+
+-- name: GetMultipartUploads :many
+SELECT * FROM multipart_uploads WHERE uploader_id = $1 ORDER BY expires_at;
+
+-- name: DeleteMultipartUpload :exec
+DELETE FROM multipart_uploads WHERE uploader_id = $1 AND id = $2;
+
+-- name: DeleteMultipartUploads :exec
+DELETE FROM multipart_uploads WHERE uploader_id = $1;
+
+-- name: GetUserUsage :one
+SELECT COUNT(*) AS files, COALESCE(SUM(size), 0)::BIGINT AS bytes
+FROM (
+    SELECT uploads.size FROM uploads
+    JOIN uploaders ON uploads.uploader_id = uploaders.id
+    WHERE uploaders.user_id = $1
+    UNION ALL
+    SELECT multipart_uploads.size FROM multipart_uploads
+    JOIN uploaders ON multipart_uploads.uploader_id = uploaders.id
+    WHERE uploaders.user_id = $1
+) AS usage;
+
+
+-- name: GetObject :one
+SELECT id, store_id, ocfl_id, path, alg, spec, inventory_digest, metadata, head
+FROM objects WHERE store_id = $1 AND ocfl_id = $2;
+
+-- name: GetObjectHead :one
+SELECT id, path, head FROM objects WHERE store_id = $1 AND ocfl_id = $2;
+
+-- name: CreateObject :one
+INSERT INTO objects (
+    store_id,
+    ocfl_id,
+    path,
+    spec,
+    alg,
+    inventory_digest,
+    metadata,
+    search_text,
+    head
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT(store_id, ocfl_id) DO UPDATE SET
+    path=$3,
+    spec=$4,
+    alg=$5,
+    inventory_digest=$6,
+    metadata=$7,
+    search_text=$8,
+    head=$9
+RETURNING id;
+
+-- name: GetObjectIDs :many
+SELECT ocfl_id FROM objects WHERE store_id = $1 ORDER BY ocfl_id;
+
+-- name: ListObjects :many
+SELECT store_id, ocfl_id, path, metadata FROM objects WHERE store_id = $1
+ORDER BY ocfl_id LIMIT $2 OFFSET $3;
+
+-- name: SearchObjects :many
+SELECT store_id, ocfl_id, path, metadata FROM objects
+WHERE store_id = sqlc.arg(store_id)
+AND (sqlc.arg(query)::text = '' OR
+    to_tsvector('simple', search_text) @@ plainto_tsquery('simple', sqlc.arg(query)::text))
+AND (sqlc.arg(digest)::text = '' OR id IN (
+    SELECT object_id FROM object_contents WHERE digest = sqlc.arg(digest)::text
+))
+ORDER BY ocfl_id LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: GetDuplicateContents :many
+SELECT object_contents.digest, objects.ocfl_id FROM object_contents
+JOIN objects ON objects.id = object_contents.object_id
+WHERE objects.store_id = $1 AND object_contents.digest IN (
+    SELECT c.digest FROM object_contents c
+    JOIN objects o ON o.id = c.object_id
+    WHERE o.store_id = $1
+    GROUP BY c.digest HAVING COUNT(*) > 1
+)
+ORDER BY object_contents.digest, objects.ocfl_id;
+
+-- name: DeleteObject :exec
+DELETE FROM objects WHERE store_id = $1 AND ocfl_id = $2;
+
+-- name: GetObjectContent :one
+SELECT * FROM object_contents WHERE object_id = $1 AND digest = $2;
+
+-- name: GetObjectContents :many
+SELECT * FROM object_contents WHERE object_id = $1;
+
+-- name: ListObjectContents :many
+SELECT * FROM object_contents
+WHERE object_id = sqlc.arg(object_id) AND digest > sqlc.arg(digest)
+AND (sqlc.arg(prefix)::TEXT = '' OR digest IN (
+    SELECT version_paths.digest FROM version_paths
+    WHERE version_paths.object_id = sqlc.arg(object_id)
+    AND starts_with(version_paths.path, sqlc.arg(prefix))
+))
+ORDER BY digest LIMIT sqlc.arg(limit);
+
+-- name: CreateObjectContent :one 
+INSERT INTO object_contents (
+    object_id,
+    digest,
+    paths,
+    fixity,
+    size
+) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT(object_id, digest) DO UPDATE SET
+    paths=$3,
+    fixity=$4,
+    size=$5
+RETURNING *;    
+
+-- name: DeleteObjectContents :exec
+DELETE FROM object_contents WHERE object_id = (
+    SELECT id FROM objects WHERE store_id = $1 AND ocfl_id = $2
+);
+
+-- name: CreateVersionPath :exec
+INSERT INTO version_paths (object_id, num, path, digest) VALUES ($1, $2, $3, $4);
+
+-- name: ListVersionPaths :many
+SELECT version_paths.path, version_paths.digest, object_contents.size
+FROM version_paths JOIN object_contents
+ON object_contents.object_id = version_paths.object_id
+AND object_contents.digest = version_paths.digest
+WHERE version_paths.object_id = sqlc.arg(object_id)
+AND version_paths.num = sqlc.arg(num)
+AND version_paths.path > sqlc.arg(after)
+AND starts_with(version_paths.path, sqlc.arg(prefix))
+ORDER BY version_paths.path LIMIT sqlc.arg(limit);
+
+-- name: DeleteVersionPaths :exec
+DELETE FROM version_paths WHERE object_id = (
+    SELECT id FROM objects WHERE store_id = $1 AND ocfl_id = $2
+);
+
+-- name: DeleteVersionPathsAfter :exec
+DELETE FROM version_paths WHERE object_id = $1 AND num > $2;
+
+-- name: SetObjectAudit :exec
+INSERT INTO object_audits (
+    store_id,
+    path,
+    ocfl_id,
+    checked_at,
+    valid,
+    errors,
+    warnings
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT(store_id, path) DO UPDATE SET
+    ocfl_id=$3,
+    checked_at=$4,
+    valid=$5,
+    errors=$6,
+    warnings=$7;
+
+-- name: GetObjectAudit :one
+SELECT * FROM object_audits WHERE store_id = $1 AND path = $2;
+
+-- name: ListObjectAudits :many
+SELECT * FROM object_audits WHERE store_id = $1
+ORDER BY checked_at DESC LIMIT $2 OFFSET $3;
+
+-- name: ListFailedObjectAudits :many
+SELECT * FROM object_audits WHERE store_id = $1 AND valid = FALSE
+ORDER BY checked_at DESC LIMIT $2 OFFSET $3;
+
+-- name: SetVersionTag :exec
+INSERT INTO version_tags (
+    store_id,
+    ocfl_id,
+    name,
+    version,
+    created_at
+) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT(store_id, ocfl_id, name) DO UPDATE SET
+    version=$4,
+    created_at=$5;
+
+-- name: GetVersionTag :one
+SELECT * FROM version_tags WHERE store_id = $1 AND ocfl_id = $2 AND name = $3;
+
+-- name: ListVersionTags :many
+SELECT * FROM version_tags WHERE store_id = $1 AND ocfl_id = $2 ORDER BY name;
+
+-- name: DeleteVersionTag :exec
+DELETE FROM version_tags WHERE store_id = $1 AND ocfl_id = $2 AND name = $3;
+
+-- name: DeleteVersionTags :exec
+DELETE FROM version_tags WHERE store_id = $1 AND ocfl_id = $2;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+    id,
+    endpoint_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    attempts,
+    next_attempt_at,
+    last_status,
+    last_error,
+    created_at,
+    updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+
+-- name: ClaimPendingWebhookDeliveries :many
+UPDATE webhook_deliveries SET next_attempt_at = sqlc.arg(until)
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= sqlc.arg(before)
+    ORDER BY next_attempt_at LIMIT sqlc.arg(limit)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE webhook_deliveries SET
+    status = $1,
+    attempts = $2,
+    next_attempt_at = $3,
+    last_status = $4,
+    last_error = $5,
+    updated_at = $6
+WHERE id = $7;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+ORDER BY created_at DESC, id LIMIT $1 OFFSET $2;
+
+-- name: ListEndpointWebhookDeliveries :many
+SELECT * FROM webhook_deliveries WHERE endpoint_id = $1
+ORDER BY created_at DESC, id LIMIT $2 OFFSET $3;
+
+-- name: LockEvents :exec
+SELECT pg_advisory_xact_lock(7321001);
+
+-- name: AppendEvent :one
+INSERT INTO events (
+    id,
+    type,
+    store_id,
+    ocfl_id,
+    uploader_id,
+    user_id,
+    created_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING seq;
+
+-- name: ListEvents :many
+SELECT * FROM events WHERE seq > $1 ORDER BY seq LIMIT $2;
+
+-- name: LastEventSeq :one
+SELECT COALESCE(MAX(seq), 0)::BIGINT FROM events;
+
+-- name: DeleteEvents :exec
+DELETE FROM events WHERE created_at < $1;
@@ -0,0 +1,59 @@
+package chapdb_test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/carlmjohnson/be"
+	"github.com/srerickson/chaparral/server/chapdb"
+)
+
+// postgresEnv is a PostgreSQL connection URL used for testing. If it isn't
+// set, PostgreSQL tests are skipped.
+const postgresEnv = "CHAPARRAL_TEST_POSTGRES"
+
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv(postgresEnv)
+	if dsn == "" {
+		t.Skip(postgresEnv + " not set")
+	}
+	testConformance(t, func(t *testing.T) chapdb.DB {
+		return newPostgresSchemaDB(t, dsn)
+	})
+}
+
+// newPostgresSchemaDB returns a DB that uses a new schema, which is dropped
+// when the test completes.
+func newPostgresSchemaDB(t *testing.T, dsn string) chapdb.DB {
+	t.Helper()
+	admin, err := chapdb.Open("postgres", dsn, false)
+	be.NilErr(t, err)
+	t.Cleanup(func() { admin.Close() })
+	schema := randSchemaName(t)
+	_, err = admin.Exec("CREATE SCHEMA " + schema)
+	be.NilErr(t, err)
+	t.Cleanup(func() {
+		if _, err := admin.Exec("DROP SCHEMA " + schema + " CASCADE"); err != nil {
+			t.Error(err)
+		}
+	})
+	u, err := url.Parse(dsn)
+	be.NilErr(t, err)
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	db, err := chapdb.OpenDSN(u.String(), true)
+	be.NilErr(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func randSchemaName(t *testing.T) string {
+	b := make([]byte, 6)
+	_, err := rand.Read(b)
+	be.NilErr(t, err)
+	return "test_" + hex.EncodeToString(b)
+}
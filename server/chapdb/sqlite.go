@@ -6,12 +6,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/srerickson/chaparral"
+	"github.com/srerickson/chaparral/server/audit"
 	sqlite "github.com/srerickson/chaparral/server/chapdb/sqlite_gen"
+	"github.com/srerickson/chaparral/server/eventlog"
 	"github.com/srerickson/chaparral/server/uploader"
+	"github.com/srerickson/chaparral/server/webhook"
 	"github.com/srerickson/ocfl-go"
 	_ "modernc.org/sqlite"
 )
@@ -42,6 +47,13 @@ func Open(driver string, file string, migrate bool) (*sql.DB, error) {
 			return nil, err
 		}
 		db.SetMaxOpenConns(1)
+	case "postgres":
+		var err error
+		// file is a connection string (DSN)
+		db, err = sql.Open("pgx", file)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unsupported driver %q", driver)
 	}
@@ -80,6 +92,11 @@ func (db *SQLiteDB) CreateUploader(ctx context.Context, upper *uploader.Persiste
 			return err
 		}
 	}
+	for _, mp := range upper.Multiparts {
+		if err := db.CreateMultipartUpload(ctx, upper.ID, &mp); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -101,6 +118,25 @@ func (db *SQLiteDB) CreateUpload(ctx context.Context, upID string, up *uploader.
 	return nil
 }
 
+func (db *SQLiteDB) CreateMultipartUpload(ctx context.Context, upID string, mp *uploader.PendingMultipart) error {
+	qry := sqlite.New(db.sqlDB())
+	return qry.CreateMultipartUpload(ctx, sqlite.CreateMultipartUploadParams{
+		ID:         mp.Name,
+		UploaderID: upID,
+		UploadID:   mp.UploadID,
+		Size:       mp.Size,
+		ExpiresAt:  mp.Expires.UTC(),
+	})
+}
+
+func (db *SQLiteDB) DeleteMultipartUpload(ctx context.Context, upID string, name string) error {
+	qry := sqlite.New(db.sqlDB())
+	return qry.DeleteMultipartUpload(ctx, sqlite.DeleteMultipartUploadParams{
+		UploaderID: upID,
+		ID:         name,
+	})
+}
+
 // list of all uploaderIDs
 func (db *SQLiteDB) GetUploaderIDs(ctx context.Context) ([]string, error) {
 	qry := sqlite.New(db.sqlDB())
@@ -139,10 +175,22 @@ func (db *SQLiteDB) GetUploader(ctx context.Context, id string) (*uploader.Persi
 			Digests: digests,
 		}
 	}
+	sqlMultiparts, err := qry.GetMultipartUploads(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, mp := range sqlMultiparts {
+		upper.Multiparts = append(upper.Multiparts, uploader.PendingMultipart{
+			Name:     mp.ID,
+			UploadID: mp.UploadID,
+			Size:     mp.Size,
+			Expires:  mp.ExpiresAt.UTC(),
+		})
+	}
 	return upper, nil
 }
 
-// Delete the uploader and all its uploads
+// Delete the uploader and all its uploads and pending multipart uploads
 func (db *SQLiteDB) DeleteUploader(ctx context.Context, id string) error {
 	qry := sqlite.New(db.sqlDB())
 
@@ -150,6 +198,10 @@ func (db *SQLiteDB) DeleteUploader(ctx context.Context, id string) error {
 		return err
 	}
 
+	if err := qry.DeleteMultipartUploads(ctx, id); err != nil {
+		return err
+	}
+
 	if err := qry.DeleteUploader(ctx, id); err != nil {
 		return err
 	}
@@ -166,6 +218,16 @@ func (db *SQLiteDB) CountUploaders(ctx context.Context) (int, error) {
 	return int(n), nil
 }
 
+// total size and number of uploads in the user's uploaders
+func (db *SQLiteDB) GetUserUsage(ctx context.Context, userID string) (*uploader.Usage, error) {
+	qry := sqlite.New(db.sqlDB())
+	row, err := qry.GetUserUsage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &uploader.Usage{Files: int(row.Files), Bytes: row.Bytes}, nil
+}
+
 func (sqdb *SQLiteDB) SetObjectManifest(ctx context.Context, obj *chaparral.ObjectManifest) (err error) {
 	var tx *sql.Tx
 	tx, err = sqdb.sqlDB().BeginTx(ctx, nil)
@@ -181,12 +243,56 @@ func (sqdb *SQLiteDB) SetObjectManifest(ctx context.Context, obj *chaparral.Obje
 		}
 	}()
 	qry := sqlite.New(sqdb.sqlDB()).WithTx(tx)
-	dbObj, err := qry.CreateObject(ctx, sqlite.CreateObjectParams{
+	var meta string
+	meta, err = encodeMetadata(obj.Metadata)
+	if err != nil {
+		return
+	}
+	// versions that are already cached are kept: the states of an object's
+	// existing versions don't change.
+	var keep int64
+	prev, err := qry.GetObjectHead(ctx, sqlite.GetObjectHeadParams{
+		StoreID: obj.StorageRootID,
+		OcflID:  obj.ID,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return
+	}
+	if err == nil && prev.Path == obj.Path && prev.Head <= int64(obj.Head) {
+		keep = prev.Head
+	}
+	objID, err := qry.CreateObject(ctx, sqlite.CreateObjectParams{
+		StoreID:         obj.StorageRootID,
+		OcflID:          obj.ID,
+		Path:            obj.Path,
+		Spec:            obj.Spec,
+		Alg:             obj.DigestAlgorithm,
+		InventoryDigest: obj.InventoryDigest,
+		Metadata:        meta,
+		Head:            int64(obj.Head),
+	})
+	if err != nil {
+		return
+	}
+	// replace existing search index entry
+	err = qry.DeleteObjectSearch(ctx, sqlite.DeleteObjectSearchParams{
+		StoreID: obj.StorageRootID,
+		OcflID:  obj.ID,
+	})
+	if err != nil {
+		return
+	}
+	err = qry.CreateObjectSearch(ctx, sqlite.CreateObjectSearchParams{
+		Rowid: objID,
+		Text:  searchText(obj),
+	})
+	if err != nil {
+		return
+	}
+	// replace existing manifest entries
+	err = qry.DeleteObjectContents(ctx, sqlite.DeleteObjectContentsParams{
 		StoreID: obj.StorageRootID,
 		OcflID:  obj.ID,
-		Path:    obj.Path,
-		Spec:    obj.Spec,
-		Alg:     obj.DigestAlgorithm,
 	})
 	if err != nil {
 		return
@@ -202,7 +308,7 @@ func (sqdb *SQLiteDB) SetObjectManifest(ctx context.Context, obj *chaparral.Obje
 			return
 		}
 		_, err = qry.CreateObjectContent(ctx, sqlite.CreateObjectContentParams{
-			ObjectID: dbObj.ID,
+			ObjectID: objID,
 			Digest:   digest,
 			Paths:    pathBytes,
 			Fixity:   fixBytes,
@@ -212,11 +318,90 @@ func (sqdb *SQLiteDB) SetObjectManifest(ctx context.Context, obj *chaparral.Obje
 			return err
 		}
 	}
+	// replace version states that aren't kept
+	err = qry.DeleteVersionPathsAfter(ctx, sqlite.DeleteVersionPathsAfterParams{
+		ObjectID: objID,
+		Num:      keep,
+	})
+	if err != nil {
+		return
+	}
+	for i := int(keep); i < len(obj.VersionStates); i++ {
+		for digest, paths := range obj.VersionStates[i] {
+			for _, p := range paths {
+				err = qry.CreateVersionPath(ctx, sqlite.CreateVersionPathParams{
+					ObjectID: objID,
+					Num:      int64(i + 1),
+					Path:     p,
+					Digest:   digest,
+				})
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
 	err = tx.Commit()
 	return
 }
 
 func (db *SQLiteDB) GetObjectManifest(ctx context.Context, storeID, objID string) (*chaparral.ObjectManifest, error) {
+	return db.getObjectManifest(ctx, storeID, objID, func(qry *sqlite.Queries, id int64) ([]sqlite.ObjectContent, error) {
+		return qry.GetObjectContents(ctx, id)
+	})
+}
+
+// GetObjectManifestPage is like GetObjectManifest, but the manifest only
+// includes up to limit digests that sort after the given digest. If prefix
+// isn't empty, only digests with a logical path that has the prefix, in any
+// cached version state, are included.
+func (db *SQLiteDB) GetObjectManifestPage(ctx context.Context, storeID, objID string, prefix string, after string, limit int) (*chaparral.ObjectManifest, error) {
+	return db.getObjectManifest(ctx, storeID, objID, func(qry *sqlite.Queries, id int64) ([]sqlite.ObjectContent, error) {
+		return qry.ListObjectContents(ctx, sqlite.ListObjectContentsParams{
+			ObjectID: id,
+			Digest:   after,
+			Prefix:   prefix,
+			Limit:    int64(limit),
+		})
+	})
+}
+
+// ListVersionPaths returns up to limit logical paths, sorted by path, from
+// the cached state of the object version. Only paths with the prefix that
+// sort after the given path are included.
+func (db *SQLiteDB) ListVersionPaths(ctx context.Context, storeID, objID string, version int, prefix string, after string, limit int) ([]chaparral.StateItem, error) {
+	qry := sqlite.New(db.sqlDB())
+	objDB, err := qry.GetObjectHead(ctx, sqlite.GetObjectHeadParams{
+		StoreID: storeID,
+		OcflID:  objID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := qry.ListVersionPaths(ctx, sqlite.ListVersionPathsParams{
+		ObjectID: objDB.ID,
+		Num:      int64(version),
+		After:    after,
+		Prefix:   prefix,
+		Limit:    int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]chaparral.StateItem, len(rows))
+	for i, row := range rows {
+		items[i] = chaparral.StateItem{
+			Path:   row.Path,
+			Digest: row.Digest,
+			Size:   row.Size,
+		}
+	}
+	return items, nil
+}
+
+// getObjectManifest returns the cached object with the contents returned by
+// contents.
+func (db *SQLiteDB) getObjectManifest(ctx context.Context, storeID, objID string, contents func(*sqlite.Queries, int64) ([]sqlite.ObjectContent, error)) (*chaparral.ObjectManifest, error) {
 	qry := sqlite.New(db.sqlDB())
 	objDB, err := qry.GetObject(ctx, sqlite.GetObjectParams{
 		StoreID: storeID,
@@ -233,9 +418,15 @@ func (db *SQLiteDB) GetObjectManifest(ctx context.Context, storeID, objID string
 		Path:            objDB.Path,
 		DigestAlgorithm: objDB.Alg,
 		Spec:            objDB.Spec,
+		InventoryDigest: objDB.InventoryDigest,
 		Manifest:        chaparral.Manifest{},
+		Head:            int(objDB.Head),
+	}
+	obj.Metadata, err = decodeMetadata(objDB.Metadata)
+	if err != nil {
+		return nil, err
 	}
-	conts, err := qry.GetObjectContents(ctx, objDB.ID)
+	conts, err := contents(qry, objDB.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -253,6 +444,78 @@ func (db *SQLiteDB) GetObjectManifest(ctx context.Context, storeID, objID string
 	return obj, nil
 }
 
+// GetObjectIDs returns the ids of all cached objects for the storage root.
+func (db *SQLiteDB) GetObjectIDs(ctx context.Context, storeID string) ([]string, error) {
+	return sqlite.New(db.sqlDB()).GetObjectIDs(ctx, storeID)
+}
+
+// ListObjects returns cached objects for the storage root, sorted by id.
+func (db *SQLiteDB) ListObjects(ctx context.Context, storeID string, limit int, offset int) ([]chaparral.ObjectListItem, error) {
+	rows, err := sqlite.New(db.sqlDB()).ListObjects(ctx, sqlite.ListObjectsParams{
+		StoreID: storeID,
+		Limit:   int64(limit),
+		Offset:  int64(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sqliteObjectListItems(rows)
+}
+
+// SearchObjects returns cached objects for the storage root that match query
+// and digest, sorted by id.
+func (db *SQLiteDB) SearchObjects(ctx context.Context, storeID string, query string, digest string, limit int, offset int) ([]chaparral.ObjectListItem, error) {
+	terms := searchTerms(query)
+	if query != "" && len(terms) == 0 {
+		return nil, nil
+	}
+	rows, err := sqlite.New(db.sqlDB()).SearchObjects(ctx, sqlite.SearchObjectsParams{
+		StoreID: storeID,
+		Query:   ftsQuery(terms),
+		Digest:  digest,
+		Limit:   int64(limit),
+		Offset:  int64(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]sqlite.ListObjectsRow, len(rows))
+	for i, row := range rows {
+		items[i] = sqlite.ListObjectsRow(row)
+	}
+	return sqliteObjectListItems(items)
+}
+
+// GetDuplicateContents returns content digests found in more than one cached
+// object in the storage root, sorted by digest. Sizes are not set.
+func (db *SQLiteDB) GetDuplicateContents(ctx context.Context, storeID string) ([]chaparral.DuplicateContent, error) {
+	rows, err := sqlite.New(db.sqlDB()).GetDuplicateContents(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	var dups []chaparral.DuplicateContent
+	for _, row := range rows {
+		dups = appendDuplicate(dups, row.Digest, row.OcflID)
+	}
+	return dups, nil
+}
+
+func sqliteObjectListItems(rows []sqlite.ListObjectsRow) ([]chaparral.ObjectListItem, error) {
+	items := make([]chaparral.ObjectListItem, len(rows))
+	for i, row := range rows {
+		meta, err := decodeMetadata(row.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = chaparral.ObjectListItem{
+			ObjectRef: chaparral.ObjectRef{StorageRootID: row.StoreID, ID: row.OcflID},
+			Path:      row.Path,
+			Metadata:  meta,
+		}
+	}
+	return items, nil
+}
+
 func (db *SQLiteDB) DeleteObject(ctx context.Context, storeID, objectID string) (err error) {
 	var tx *sql.Tx
 	tx, err = db.sqlDB().BeginTx(ctx, nil)
@@ -275,6 +538,20 @@ func (db *SQLiteDB) DeleteObject(ctx context.Context, storeID, objectID string)
 	if err != nil {
 		return
 	}
+	err = qry.DeleteObjectSearch(ctx, sqlite.DeleteObjectSearchParams{
+		StoreID: storeID,
+		OcflID:  objectID,
+	})
+	if err != nil {
+		return
+	}
+	err = qry.DeleteVersionPaths(ctx, sqlite.DeleteVersionPathsParams{
+		StoreID: storeID,
+		OcflID:  objectID,
+	})
+	if err != nil {
+		return
+	}
 	err = qry.DeleteObject(ctx, sqlite.DeleteObjectParams{
 		StoreID: storeID,
 		OcflID:  objectID,
@@ -285,3 +562,318 @@ func (db *SQLiteDB) DeleteObject(ctx context.Context, storeID, objectID string)
 	err = tx.Commit()
 	return
 }
+
+func (db *SQLiteDB) SetVersionTag(ctx context.Context, tag *chaparral.VersionTag) error {
+	return sqlite.New(db.sqlDB()).SetVersionTag(ctx, sqlite.SetVersionTagParams{
+		StoreID:   tag.StorageRootID,
+		OcflID:    tag.ID,
+		Name:      tag.Name,
+		Version:   int64(tag.Version),
+		CreatedAt: tag.Created.UTC(),
+	})
+}
+
+func (db *SQLiteDB) GetVersionTag(ctx context.Context, storeID string, objID string, name string) (*chaparral.VersionTag, error) {
+	row, err := sqlite.New(db.sqlDB()).GetVersionTag(ctx, sqlite.GetVersionTagParams{
+		StoreID: storeID,
+		OcflID:  objID,
+		Name:    name,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("version tag %q: %w", name, fs.ErrNotExist)
+		}
+		return nil, err
+	}
+	tag := sqliteVersionTag(row)
+	return &tag, nil
+}
+
+func (db *SQLiteDB) ListVersionTags(ctx context.Context, storeID string, objID string) ([]chaparral.VersionTag, error) {
+	rows, err := sqlite.New(db.sqlDB()).ListVersionTags(ctx, sqlite.ListVersionTagsParams{
+		StoreID: storeID,
+		OcflID:  objID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]chaparral.VersionTag, len(rows))
+	for i, row := range rows {
+		tags[i] = sqliteVersionTag(row)
+	}
+	return tags, nil
+}
+
+func (db *SQLiteDB) DeleteVersionTag(ctx context.Context, storeID string, objID string, name string) error {
+	return sqlite.New(db.sqlDB()).DeleteVersionTag(ctx, sqlite.DeleteVersionTagParams{
+		StoreID: storeID,
+		OcflID:  objID,
+		Name:    name,
+	})
+}
+
+func (db *SQLiteDB) DeleteVersionTags(ctx context.Context, storeID string, objID string) error {
+	return sqlite.New(db.sqlDB()).DeleteVersionTags(ctx, sqlite.DeleteVersionTagsParams{
+		StoreID: storeID,
+		OcflID:  objID,
+	})
+}
+
+func sqliteVersionTag(row sqlite.VersionTag) chaparral.VersionTag {
+	return chaparral.VersionTag{
+		ObjectRef: chaparral.ObjectRef{StorageRootID: row.StoreID, ID: row.OcflID},
+		Name:      row.Name,
+		Version:   int(row.Version),
+		Created:   row.CreatedAt.UTC(),
+	}
+}
+
+func (db *SQLiteDB) SetAuditResult(ctx context.Context, result *audit.Result) error {
+	qry := sqlite.New(db.sqlDB())
+	errBytes, err := json.Marshal(result.Errors)
+	if err != nil {
+		return err
+	}
+	warnBytes, err := json.Marshal(result.Warnings)
+	if err != nil {
+		return err
+	}
+	return qry.SetObjectAudit(ctx, sqlite.SetObjectAuditParams{
+		StoreID:   result.StorageRootID,
+		Path:      result.Path,
+		OcflID:    result.ObjectID,
+		CheckedAt: result.Checked.UTC(),
+		Valid:     result.Valid(),
+		Errors:    errBytes,
+		Warnings:  warnBytes,
+	})
+}
+
+func (db *SQLiteDB) GetAuditResult(ctx context.Context, storeID string, path string) (*audit.Result, error) {
+	qry := sqlite.New(db.sqlDB())
+	row, err := qry.GetObjectAudit(ctx, sqlite.GetObjectAuditParams{
+		StoreID: storeID,
+		Path:    path,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return auditResult(row)
+}
+
+func (db *SQLiteDB) ListAuditResults(ctx context.Context, storeID string, failedOnly bool, limit int, offset int) ([]audit.Result, error) {
+	qry := sqlite.New(db.sqlDB())
+	var rows []sqlite.ObjectAudit
+	var err error
+	switch {
+	case failedOnly:
+		rows, err = qry.ListFailedObjectAudits(ctx, sqlite.ListFailedObjectAuditsParams{
+			StoreID: storeID,
+			Limit:   int64(limit),
+			Offset:  int64(offset),
+		})
+	default:
+		rows, err = qry.ListObjectAudits(ctx, sqlite.ListObjectAuditsParams{
+			StoreID: storeID,
+			Limit:   int64(limit),
+			Offset:  int64(offset),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	results := make([]audit.Result, len(rows))
+	for i, row := range rows {
+		r, err := auditResult(row)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = *r
+	}
+	return results, nil
+}
+
+func auditResult(row sqlite.ObjectAudit) (*audit.Result, error) {
+	result := &audit.Result{
+		StorageRootID: row.StoreID,
+		Path:          row.Path,
+		ObjectID:      row.OcflID,
+		Checked:       row.CheckedAt.UTC(),
+	}
+	if err := json.Unmarshal(row.Errors, &result.Errors); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(row.Warnings, &result.Warnings); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ftsQuery returns an FTS5 query matching objects with all the terms.
+func ftsQuery(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = `"` + t + `"`
+	}
+	return strings.Join(quoted, " ")
+}
+
+func (db *SQLiteDB) CreateDeliveries(ctx context.Context, deliveries []webhook.Delivery) (err error) {
+	var tx *sql.Tx
+	tx, err = db.sqlDB().BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if rbErr := tx.Rollback(); rbErr != nil {
+			err = errors.Join(err, rbErr)
+		}
+	}()
+	qry := sqlite.New(db.sqlDB()).WithTx(tx)
+	for _, d := range deliveries {
+		var payload []byte
+		payload, err = json.Marshal(d.Event)
+		if err != nil {
+			return
+		}
+		err = qry.CreateWebhookDelivery(ctx, sqlite.CreateWebhookDeliveryParams{
+			ID:            d.ID,
+			EndpointID:    d.EndpointID,
+			EventID:       d.Event.ID,
+			EventType:     d.Event.Type,
+			Payload:       payload,
+			Status:        d.Status,
+			Attempts:      int64(d.Attempts),
+			NextAttemptAt: d.NextAttempt.UTC(),
+			LastStatus:    int64(d.LastStatus),
+			LastError:     d.LastError,
+			CreatedAt:     d.Created.UTC(),
+			UpdatedAt:     d.Updated.UTC(),
+		})
+		if err != nil {
+			return
+		}
+	}
+	err = tx.Commit()
+	return
+}
+
+func (db *SQLiteDB) ClaimPendingDeliveries(ctx context.Context, before time.Time, until time.Time, limit int) ([]webhook.Delivery, error) {
+	rows, err := sqlite.New(db.sqlDB()).ClaimPendingWebhookDeliveries(ctx, sqlite.ClaimPendingWebhookDeliveriesParams{
+		Until:  until.UTC(),
+		Before: before.UTC(),
+		Limit:  int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sqliteDeliveries(rows)
+}
+
+func (db *SQLiteDB) UpdateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	return sqlite.New(db.sqlDB()).UpdateWebhookDelivery(ctx, sqlite.UpdateWebhookDeliveryParams{
+		ID:            d.ID,
+		Status:        d.Status,
+		Attempts:      int64(d.Attempts),
+		NextAttemptAt: d.NextAttempt.UTC(),
+		LastStatus:    int64(d.LastStatus),
+		LastError:     d.LastError,
+		UpdatedAt:     d.Updated.UTC(),
+	})
+}
+
+func (db *SQLiteDB) ListDeliveries(ctx context.Context, endpointID string, limit int, offset int) ([]webhook.Delivery, error) {
+	qry := sqlite.New(db.sqlDB())
+	var rows []sqlite.WebhookDelivery
+	var err error
+	switch {
+	case endpointID != "":
+		rows, err = qry.ListEndpointWebhookDeliveries(ctx, sqlite.ListEndpointWebhookDeliveriesParams{
+			EndpointID: endpointID,
+			Limit:      int64(limit),
+			Offset:     int64(offset),
+		})
+	default:
+		rows, err = qry.ListWebhookDeliveries(ctx, sqlite.ListWebhookDeliveriesParams{
+			Limit:  int64(limit),
+			Offset: int64(offset),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sqliteDeliveries(rows)
+}
+
+func sqliteDeliveries(rows []sqlite.WebhookDelivery) ([]webhook.Delivery, error) {
+	deliveries := make([]webhook.Delivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = webhook.Delivery{
+			ID:          row.ID,
+			EndpointID:  row.EndpointID,
+			Status:      row.Status,
+			Attempts:    int(row.Attempts),
+			NextAttempt: row.NextAttemptAt.UTC(),
+			LastStatus:  int(row.LastStatus),
+			LastError:   row.LastError,
+			Created:     row.CreatedAt.UTC(),
+			Updated:     row.UpdatedAt.UTC(),
+		}
+		if err := json.Unmarshal(row.Payload, &deliveries[i].Event); err != nil {
+			return nil, fmt.Errorf("decoding webhook event for delivery %q: %w", row.ID, err)
+		}
+	}
+	return deliveries, nil
+}
+
+func (db *SQLiteDB) AppendEvent(ctx context.Context, event *eventlog.Event) error {
+	seq, err := sqlite.New(db.sqlDB()).AppendEvent(ctx, sqlite.AppendEventParams{
+		ID:         event.ID,
+		Type:       event.Type,
+		StoreID:    event.StorageRootID,
+		OcflID:     event.ObjectID,
+		UploaderID: event.UploaderID,
+		UserID:     event.UserID,
+		CreatedAt:  event.Created.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	event.Seq = seq
+	return nil
+}
+
+func (db *SQLiteDB) ListEvents(ctx context.Context, after int64, limit int) ([]eventlog.Event, error) {
+	rows, err := sqlite.New(db.sqlDB()).ListEvents(ctx, sqlite.ListEventsParams{
+		Seq:   after,
+		Limit: int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]eventlog.Event, len(rows))
+	for i, row := range rows {
+		events[i] = eventlog.Event{
+			Seq:           row.Seq,
+			ID:            row.ID,
+			Type:          row.Type,
+			Created:       row.CreatedAt.UTC(),
+			StorageRootID: row.StoreID,
+			ObjectID:      row.OcflID,
+			UploaderID:    row.UploaderID,
+			UserID:        row.UserID,
+		}
+	}
+	return events, nil
+}
+
+func (db *SQLiteDB) LastEventSeq(ctx context.Context) (int64, error) {
+	return sqlite.New(db.sqlDB()).LastEventSeq(ctx)
+}
+
+func (db *SQLiteDB) DeleteEvents(ctx context.Context, before time.Time) error {
+	return sqlite.New(db.sqlDB()).DeleteEvents(ctx, before.UTC())
+}
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE object_audits (
+    store_id TEXT NOT NULL, -- storage root ID
+    path TEXT NOT NULL, -- object path relative to the storage root
+    ocfl_id TEXT NOT NULL, -- object id (empty if the inventory is unreadable)
+    checked_at DATETIME NOT NULL, -- time of last validation
+    valid BOOLEAN NOT NULL, -- result of last validation
+    errors BLOB NOT NULL, -- json array of fatal validation errors
+    warnings BLOB NOT NULL, -- json array of validation warnings
+    PRIMARY KEY(store_id, path)
+);
+
+-- +goose Down
+DROP TABLE object_audits;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- digest of the object's root inventory.json (from the sidecar)
+ALTER TABLE objects ADD COLUMN inventory_digest TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE objects DROP COLUMN inventory_digest;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- logical paths in the object's most recent version (JSON array)
+ALTER TABLE objects ADD COLUMN head_paths TEXT NOT NULL DEFAULT '';
+-- full-text index of object metadata and logical paths. The rowid is the
+-- object's id.
+CREATE VIRTUAL TABLE object_search USING fts5(text);
+
+-- +goose Down
+DROP TABLE object_search;
+ALTER TABLE objects DROP COLUMN head_paths;
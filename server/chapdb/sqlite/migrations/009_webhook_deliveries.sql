@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE webhook_deliveries (
+    id TEXT PRIMARY KEY, -- delivery id
+    endpoint_id TEXT NOT NULL, -- configured endpoint id
+    event_id TEXT NOT NULL, -- event id
+    event_type TEXT NOT NULL, -- event type
+    payload BLOB NOT NULL, -- event as json
+    status TEXT NOT NULL, -- pending, delivered, or failed
+    attempts INTEGER NOT NULL, -- number of delivery attempts
+    next_attempt_at DATETIME NOT NULL, -- time of next attempt for pending deliveries
+    last_status INTEGER NOT NULL, -- http status from last attempt
+    last_error TEXT NOT NULL, -- error from last attempt
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (status, next_attempt_at);
+
+-- +goose Down
+DROP INDEX webhook_deliveries_pending_idx;
+DROP TABLE webhook_deliveries;
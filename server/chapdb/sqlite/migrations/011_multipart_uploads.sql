@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE multipart_uploads (
+    id TEXT PRIMARY KEY, -- file name in the uploader
+    uploader_id TEXT NOT NULL,
+    upload_id TEXT NOT NULL, -- backend's id for the upload
+    size INTEGER NOT NULL, -- declared file size
+    expires_at DATETIME NOT NULL -- when the part URLs expire
+);
+CREATE INDEX multipart_uploads_uploader_idx ON multipart_uploads (uploader_id);
+
+-- +goose Down
+DROP INDEX multipart_uploads_uploader_idx;
+DROP TABLE multipart_uploads;
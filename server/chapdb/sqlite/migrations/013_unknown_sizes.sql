@@ -0,0 +1,7 @@
+-- +goose Up
+-- content sizes that haven't been read are -1, so that zero-byte content can
+-- be cached. Previously, unknown sizes were 0.
+UPDATE object_contents SET size = -1 WHERE size = 0;
+
+-- +goose Down
+UPDATE object_contents SET size = 0 WHERE size = -1;
@@ -39,9 +39,45 @@ SELECT * FROM uploads WHERE uploader_id = ?;
 -- name: DeleteUploads :exec
 DELETE FROM uploads WHERE uploader_id = ?;
 
+-- name: CreateMultipartUpload :exec
+INSERT INTO multipart_uploads (
+    id,
+    uploader_id,
+    upload_id,
+    size,
+    expires_at
+) VALUES (
+    ?, ?, ?, ?, ?
+);
+
+-- name: GetMultipartUploads :many
+SELECT * FROM multipart_uploads WHERE uploader_id = ? ORDER BY expires_at;
+
+-- name: DeleteMultipartUpload :exec
+DELETE FROM multipart_uploads WHERE uploader_id = ? AND id = ?;
+
+-- name: DeleteMultipartUploads :exec
+DELETE FROM multipart_uploads WHERE uploader_id = ?;
+
+-- name: GetUserUsage :one
+SELECT COUNT(*) AS files, CAST(COALESCE(SUM(size), 0) AS INTEGER) AS bytes
+FROM (
+    SELECT uploads.size FROM uploads
+    JOIN uploaders ON uploads.uploader_id = uploaders.id
+    WHERE uploaders.user_id = ?1
+    UNION ALL
+    SELECT multipart_uploads.size FROM multipart_uploads
+    JOIN uploaders ON multipart_uploads.uploader_id = uploaders.id
+    WHERE uploaders.user_id = ?1
+) AS usage;
+
 
 -- name: GetObject :one
-SELECT * FROM objects WHERE store_id = ? AND ocfl_id = ?;
+SELECT id, store_id, ocfl_id, path, alg, spec, inventory_digest, metadata, head
+FROM objects WHERE store_id = ? AND ocfl_id = ?;
+
+-- name: GetObjectHead :one
+SELECT id, path, head FROM objects WHERE store_id = ? AND ocfl_id = ?;
 
 -- name: CreateObject :one
 INSERT INTO objects (
@@ -49,13 +85,56 @@ INSERT INTO objects (
     ocfl_id,
     path,
     spec,
-    alg
-) VALUES (?1, ?2, ?3, ?4, ?5)
+    alg,
+    inventory_digest,
+    metadata,
+    head
+) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)
 ON CONFLICT(store_id, ocfl_id) DO UPDATE SET
     path=?3,
     spec=?4,
-    alg=?5
-RETURNING *;
+    alg=?5,
+    inventory_digest=?6,
+    metadata=?7,
+    head=?8
+RETURNING id;
+
+-- name: GetObjectIDs :many
+SELECT ocfl_id FROM objects WHERE store_id = ? ORDER BY ocfl_id;
+
+-- name: ListObjects :many
+SELECT store_id, ocfl_id, path, metadata FROM objects WHERE store_id = ?
+ORDER BY ocfl_id LIMIT ? OFFSET ?;
+
+-- name: SearchObjects :many
+SELECT store_id, ocfl_id, path, metadata FROM objects
+WHERE store_id = sqlc.arg(store_id)
+AND (sqlc.arg(query) = '' OR id IN (
+    SELECT rowid FROM object_search WHERE object_search MATCH sqlc.arg(query)
+))
+AND (sqlc.arg(digest) = '' OR id IN (
+    SELECT object_id FROM object_contents WHERE digest = sqlc.arg(digest)
+))
+ORDER BY ocfl_id LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CreateObjectSearch :exec
+INSERT INTO object_search (rowid, text) VALUES (?, ?);
+
+-- name: DeleteObjectSearch :exec
+DELETE FROM object_search WHERE rowid = (
+    SELECT id FROM objects WHERE store_id = ? AND ocfl_id = ?
+);
+
+-- name: GetDuplicateContents :many
+SELECT object_contents.digest, objects.ocfl_id FROM object_contents
+JOIN objects ON objects.id = object_contents.object_id
+WHERE objects.store_id = ?1 AND object_contents.digest IN (
+    SELECT c.digest FROM object_contents c
+    JOIN objects o ON o.id = c.object_id
+    WHERE o.store_id = ?1
+    GROUP BY c.digest HAVING COUNT(*) > 1
+)
+ORDER BY object_contents.digest, objects.ocfl_id;
 
 -- name: DeleteObject :exec
 DELETE FROM objects WHERE store_id = ? AND ocfl_id = ?;
@@ -66,6 +145,16 @@ SELECT * FROM object_contents WHERE object_id = ? AND digest = ?;
 -- name: GetObjectContents :many
 SELECT * FROM object_contents WHERE object_id = ?;
 
+-- name: ListObjectContents :many
+SELECT * FROM object_contents
+WHERE object_id = sqlc.arg(object_id) AND digest > sqlc.arg(digest)
+AND (sqlc.arg(prefix) = '' OR digest IN (
+    SELECT version_paths.digest FROM version_paths
+    WHERE version_paths.object_id = sqlc.arg(object_id)
+    AND substr(version_paths.path, 1, length(sqlc.arg(prefix))) = sqlc.arg(prefix)
+))
+ORDER BY digest LIMIT sqlc.arg(limit);
+
 -- name: CreateObjectContent :one 
 INSERT INTO object_contents (
     object_id,
@@ -84,3 +173,142 @@ RETURNING *;
 DELETE FROM object_contents WHERE object_id = (
     SELECT id FROM objects WHERE store_id = ? AND ocfl_id = ?
 );
+
+-- name: CreateVersionPath :exec
+INSERT INTO version_paths (object_id, num, path, digest) VALUES (?, ?, ?, ?);
+
+-- name: ListVersionPaths :many
+SELECT version_paths.path, version_paths.digest, object_contents.size
+FROM version_paths JOIN object_contents
+ON object_contents.object_id = version_paths.object_id
+AND object_contents.digest = version_paths.digest
+WHERE version_paths.object_id = sqlc.arg(object_id)
+AND version_paths.num = sqlc.arg(num)
+AND version_paths.path > sqlc.arg(after)
+AND version_paths.path >= sqlc.arg(prefix)
+AND substr(version_paths.path, 1, length(sqlc.arg(prefix))) = sqlc.arg(prefix)
+ORDER BY version_paths.path LIMIT sqlc.arg(limit);
+
+-- name: DeleteVersionPaths :exec
+DELETE FROM version_paths WHERE object_id = (
+    SELECT id FROM objects WHERE store_id = ? AND ocfl_id = ?
+);
+
+-- name: DeleteVersionPathsAfter :exec
+DELETE FROM version_paths WHERE object_id = ? AND num > ?;
+
+-- name: SetObjectAudit :exec
+INSERT INTO object_audits (
+    store_id,
+    path,
+    ocfl_id,
+    checked_at,
+    valid,
+    errors,
+    warnings
+) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+ON CONFLICT(store_id, path) DO UPDATE SET
+    ocfl_id=?3,
+    checked_at=?4,
+    valid=?5,
+    errors=?6,
+    warnings=?7;
+
+-- name: GetObjectAudit :one
+SELECT * FROM object_audits WHERE store_id = ? AND path = ?;
+
+-- name: ListObjectAudits :many
+SELECT * FROM object_audits WHERE store_id = ?
+ORDER BY checked_at DESC LIMIT ? OFFSET ?;
+
+-- name: ListFailedObjectAudits :many
+SELECT * FROM object_audits WHERE store_id = ? AND valid = FALSE
+ORDER BY checked_at DESC LIMIT ? OFFSET ?;
+
+-- name: SetVersionTag :exec
+INSERT INTO version_tags (
+    store_id,
+    ocfl_id,
+    name,
+    version,
+    created_at
+) VALUES (?1, ?2, ?3, ?4, ?5)
+ON CONFLICT(store_id, ocfl_id, name) DO UPDATE SET
+    version=?4,
+    created_at=?5;
+
+-- name: GetVersionTag :one
+SELECT * FROM version_tags WHERE store_id = ? AND ocfl_id = ? AND name = ?;
+
+-- name: ListVersionTags :many
+SELECT * FROM version_tags WHERE store_id = ? AND ocfl_id = ? ORDER BY name;
+
+-- name: DeleteVersionTag :exec
+DELETE FROM version_tags WHERE store_id = ? AND ocfl_id = ? AND name = ?;
+
+-- name: DeleteVersionTags :exec
+DELETE FROM version_tags WHERE store_id = ? AND ocfl_id = ?;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+    id,
+    endpoint_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    attempts,
+    next_attempt_at,
+    last_status,
+    last_error,
+    created_at,
+    updated_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ClaimPendingWebhookDeliveries :many
+UPDATE webhook_deliveries SET next_attempt_at = sqlc.arg(until)
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= sqlc.arg(before)
+    ORDER BY next_attempt_at LIMIT sqlc.arg(limit)
+)
+RETURNING *;
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE webhook_deliveries SET
+    status = ?,
+    attempts = ?,
+    next_attempt_at = ?,
+    last_status = ?,
+    last_error = ?,
+    updated_at = ?
+WHERE id = ?;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+ORDER BY created_at DESC, id LIMIT ? OFFSET ?;
+
+-- name: ListEndpointWebhookDeliveries :many
+SELECT * FROM webhook_deliveries WHERE endpoint_id = ?
+ORDER BY created_at DESC, id LIMIT ? OFFSET ?;
+
+-- name: AppendEvent :one
+INSERT INTO events (
+    id,
+    type,
+    store_id,
+    ocfl_id,
+    uploader_id,
+    user_id,
+    created_at
+) VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING seq;
+
+-- name: ListEvents :many
+SELECT * FROM events WHERE seq > ? ORDER BY seq LIMIT ?;
+
+-- name: LastEventSeq :one
+SELECT CAST(COALESCE(MAX(seq), 0) AS INTEGER) FROM events;
+
+-- name: DeleteEvents :exec
+DELETE FROM events WHERE created_at < ?;
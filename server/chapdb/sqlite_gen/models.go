@@ -9,13 +9,45 @@ import (
 	"time"
 )
 
+type Event struct {
+	Seq        int64
+	ID         string
+	Type       string
+	StoreID    string
+	OcflID     string
+	UploaderID string
+	UserID     string
+	CreatedAt  time.Time
+}
+
+type MultipartUpload struct {
+	ID         string
+	UploaderID string
+	UploadID   string
+	Size       int64
+	ExpiresAt  time.Time
+}
+
 type Object struct {
-	ID      int64
-	StoreID string
-	OcflID  string
-	Path    string
-	Alg     string
-	Spec    string
+	ID              int64
+	StoreID         string
+	OcflID          string
+	Path            string
+	Alg             string
+	Spec            string
+	InventoryDigest string
+	Metadata        string
+	Head            int64
+}
+
+type ObjectAudit struct {
+	StoreID   string
+	Path      string
+	OcflID    string
+	CheckedAt time.Time
+	Valid     bool
+	Errors    []byte
+	Warnings  []byte
 }
 
 type ObjectContent struct {
@@ -50,3 +82,33 @@ type Version struct {
 	UserAddress sql.NullString
 	Created     time.Time
 }
+
+type VersionPath struct {
+	ObjectID int64
+	Num      int64
+	Path     string
+	Digest   string
+}
+
+type VersionTag struct {
+	StoreID   string
+	OcflID    string
+	Name      string
+	Version   int64
+	CreatedAt time.Time
+}
+
+type WebhookDelivery struct {
+	ID            string
+	EndpointID    string
+	EventID       string
+	EventType     string
+	Payload       []byte
+	Status        string
+	Attempts      int64
+	NextAttemptAt time.Time
+	LastStatus    int64
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
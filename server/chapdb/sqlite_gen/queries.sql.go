@@ -10,6 +10,96 @@ import (
 	"time"
 )
 
+const appendEvent = `-- name: AppendEvent :one
+INSERT INTO events (
+    id,
+    type,
+    store_id,
+    ocfl_id,
+    uploader_id,
+    user_id,
+    created_at
+) VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING seq
+`
+
+type AppendEventParams struct {
+	ID         string
+	Type       string
+	StoreID    string
+	OcflID     string
+	UploaderID string
+	UserID     string
+	CreatedAt  time.Time
+}
+
+func (q *Queries) AppendEvent(ctx context.Context, arg AppendEventParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, appendEvent,
+		arg.ID,
+		arg.Type,
+		arg.StoreID,
+		arg.OcflID,
+		arg.UploaderID,
+		arg.UserID,
+		arg.CreatedAt,
+	)
+	var seq int64
+	err := row.Scan(&seq)
+	return seq, err
+}
+
+const claimPendingWebhookDeliveries = `-- name: ClaimPendingWebhookDeliveries :many
+UPDATE webhook_deliveries SET next_attempt_at = ?1
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= ?2
+    ORDER BY next_attempt_at LIMIT ?3
+)
+RETURNING id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status, last_error, created_at, updated_at
+`
+
+type ClaimPendingWebhookDeliveriesParams struct {
+	Until  time.Time
+	Before time.Time
+	Limit  int64
+}
+
+func (q *Queries) ClaimPendingWebhookDeliveries(ctx context.Context, arg ClaimPendingWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, claimPendingWebhookDeliveries, arg.Until, arg.Before, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.EndpointID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastStatus,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countUploaders = `-- name: CountUploaders :one
 SELECT COUNT(*) FROM uploaders
 `
@@ -21,47 +111,83 @@ func (q *Queries) CountUploaders(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const createMultipartUpload = `-- name: CreateMultipartUpload :exec
+INSERT INTO multipart_uploads (
+    id,
+    uploader_id,
+    upload_id,
+    size,
+    expires_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+`
+
+type CreateMultipartUploadParams struct {
+	ID         string
+	UploaderID string
+	UploadID   string
+	Size       int64
+	ExpiresAt  time.Time
+}
+
+func (q *Queries) CreateMultipartUpload(ctx context.Context, arg CreateMultipartUploadParams) error {
+	_, err := q.db.ExecContext(ctx, createMultipartUpload,
+		arg.ID,
+		arg.UploaderID,
+		arg.UploadID,
+		arg.Size,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
 const createObject = `-- name: CreateObject :one
 INSERT INTO objects (
     store_id,
     ocfl_id,
     path,
     spec,
-    alg
-) VALUES (?1, ?2, ?3, ?4, ?5)
+    alg,
+    inventory_digest,
+    metadata,
+    head
+) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)
 ON CONFLICT(store_id, ocfl_id) DO UPDATE SET
     path=?3,
     spec=?4,
-    alg=?5
-RETURNING id, store_id, ocfl_id, path, alg, spec
+    alg=?5,
+    inventory_digest=?6,
+    metadata=?7,
+    head=?8
+RETURNING id
 `
 
 type CreateObjectParams struct {
-	StoreID string
-	OcflID  string
-	Path    string
-	Spec    string
-	Alg     string
+	StoreID         string
+	OcflID          string
+	Path            string
+	Spec            string
+	Alg             string
+	InventoryDigest string
+	Metadata        string
+	Head            int64
 }
 
-func (q *Queries) CreateObject(ctx context.Context, arg CreateObjectParams) (Object, error) {
+func (q *Queries) CreateObject(ctx context.Context, arg CreateObjectParams) (int64, error) {
 	row := q.db.QueryRowContext(ctx, createObject,
 		arg.StoreID,
 		arg.OcflID,
 		arg.Path,
 		arg.Spec,
 		arg.Alg,
+		arg.InventoryDigest,
+		arg.Metadata,
+		arg.Head,
 	)
-	var i Object
-	err := row.Scan(
-		&i.ID,
-		&i.StoreID,
-		&i.OcflID,
-		&i.Path,
-		&i.Alg,
-		&i.Spec,
-	)
-	return i, err
+	var id int64
+	err := row.Scan(&id)
+	return id, err
 }
 
 const createObjectContent = `-- name: CreateObjectContent :one
@@ -106,6 +232,20 @@ func (q *Queries) CreateObjectContent(ctx context.Context, arg CreateObjectConte
 	return i, err
 }
 
+const createObjectSearch = `-- name: CreateObjectSearch :exec
+INSERT INTO object_search (rowid, text) VALUES (?, ?)
+`
+
+type CreateObjectSearchParams struct {
+	Rowid int64
+	Text  string
+}
+
+func (q *Queries) CreateObjectSearch(ctx context.Context, arg CreateObjectSearchParams) error {
+	_, err := q.db.ExecContext(ctx, createObjectSearch, arg.Rowid, arg.Text)
+	return err
+}
+
 const createUpload = `-- name: CreateUpload :one
 INSERT INTO uploads (
     id, 
@@ -180,6 +320,109 @@ func (q *Queries) CreateUploader(ctx context.Context, arg CreateUploaderParams)
 	return i, err
 }
 
+const createVersionPath = `-- name: CreateVersionPath :exec
+INSERT INTO version_paths (object_id, num, path, digest) VALUES (?, ?, ?, ?)
+`
+
+type CreateVersionPathParams struct {
+	ObjectID int64
+	Num      int64
+	Path     string
+	Digest   string
+}
+
+func (q *Queries) CreateVersionPath(ctx context.Context, arg CreateVersionPathParams) error {
+	_, err := q.db.ExecContext(ctx, createVersionPath,
+		arg.ObjectID,
+		arg.Num,
+		arg.Path,
+		arg.Digest,
+	)
+	return err
+}
+
+const createWebhookDelivery = `-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+    id,
+    endpoint_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    attempts,
+    next_attempt_at,
+    last_status,
+    last_error,
+    created_at,
+    updated_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateWebhookDeliveryParams struct {
+	ID            string
+	EndpointID    string
+	EventID       string
+	EventType     string
+	Payload       []byte
+	Status        string
+	Attempts      int64
+	NextAttemptAt time.Time
+	LastStatus    int64
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (q *Queries) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, createWebhookDelivery,
+		arg.ID,
+		arg.EndpointID,
+		arg.EventID,
+		arg.EventType,
+		arg.Payload,
+		arg.Status,
+		arg.Attempts,
+		arg.NextAttemptAt,
+		arg.LastStatus,
+		arg.LastError,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const deleteEvents = `-- name: DeleteEvents :exec
+DELETE FROM events WHERE created_at < ?
+`
+
+func (q *Queries) DeleteEvents(ctx context.Context, createdAt time.Time) error {
+	_, err := q.db.ExecContext(ctx, deleteEvents, createdAt)
+	return err
+}
+
+const deleteMultipartUpload = `-- name: DeleteMultipartUpload :exec
+DELETE FROM multipart_uploads WHERE uploader_id = ? AND id = ?
+`
+
+type DeleteMultipartUploadParams struct {
+	UploaderID string
+	ID         string
+}
+
+func (q *Queries) DeleteMultipartUpload(ctx context.Context, arg DeleteMultipartUploadParams) error {
+	_, err := q.db.ExecContext(ctx, deleteMultipartUpload, arg.UploaderID, arg.ID)
+	return err
+}
+
+const deleteMultipartUploads = `-- name: DeleteMultipartUploads :exec
+DELETE FROM multipart_uploads WHERE uploader_id = ?
+`
+
+func (q *Queries) DeleteMultipartUploads(ctx context.Context, uploaderID string) error {
+	_, err := q.db.ExecContext(ctx, deleteMultipartUploads, uploaderID)
+	return err
+}
+
 const deleteObject = `-- name: DeleteObject :exec
 DELETE FROM objects WHERE store_id = ? AND ocfl_id = ?
 `
@@ -210,6 +453,22 @@ func (q *Queries) DeleteObjectContents(ctx context.Context, arg DeleteObjectCont
 	return err
 }
 
+const deleteObjectSearch = `-- name: DeleteObjectSearch :exec
+DELETE FROM object_search WHERE rowid = (
+    SELECT id FROM objects WHERE store_id = ? AND ocfl_id = ?
+)
+`
+
+type DeleteObjectSearchParams struct {
+	StoreID string
+	OcflID  string
+}
+
+func (q *Queries) DeleteObjectSearch(ctx context.Context, arg DeleteObjectSearchParams) error {
+	_, err := q.db.ExecContext(ctx, deleteObjectSearch, arg.StoreID, arg.OcflID)
+	return err
+}
+
 const deleteUploader = `-- name: DeleteUploader :exec
 DELETE FROM uploaders WHERE id = ?
 `
@@ -228,8 +487,141 @@ func (q *Queries) DeleteUploads(ctx context.Context, uploaderID string) error {
 	return err
 }
 
+const deleteVersionPaths = `-- name: DeleteVersionPaths :exec
+DELETE FROM version_paths WHERE object_id = (
+    SELECT id FROM objects WHERE store_id = ? AND ocfl_id = ?
+)
+`
+
+type DeleteVersionPathsParams struct {
+	StoreID string
+	OcflID  string
+}
+
+func (q *Queries) DeleteVersionPaths(ctx context.Context, arg DeleteVersionPathsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteVersionPaths, arg.StoreID, arg.OcflID)
+	return err
+}
+
+const deleteVersionPathsAfter = `-- name: DeleteVersionPathsAfter :exec
+DELETE FROM version_paths WHERE object_id = ? AND num > ?
+`
+
+type DeleteVersionPathsAfterParams struct {
+	ObjectID int64
+	Num      int64
+}
+
+func (q *Queries) DeleteVersionPathsAfter(ctx context.Context, arg DeleteVersionPathsAfterParams) error {
+	_, err := q.db.ExecContext(ctx, deleteVersionPathsAfter, arg.ObjectID, arg.Num)
+	return err
+}
+
+const deleteVersionTag = `-- name: DeleteVersionTag :exec
+DELETE FROM version_tags WHERE store_id = ? AND ocfl_id = ? AND name = ?
+`
+
+type DeleteVersionTagParams struct {
+	StoreID string
+	OcflID  string
+	Name    string
+}
+
+func (q *Queries) DeleteVersionTag(ctx context.Context, arg DeleteVersionTagParams) error {
+	_, err := q.db.ExecContext(ctx, deleteVersionTag, arg.StoreID, arg.OcflID, arg.Name)
+	return err
+}
+
+const deleteVersionTags = `-- name: DeleteVersionTags :exec
+DELETE FROM version_tags WHERE store_id = ? AND ocfl_id = ?
+`
+
+type DeleteVersionTagsParams struct {
+	StoreID string
+	OcflID  string
+}
+
+func (q *Queries) DeleteVersionTags(ctx context.Context, arg DeleteVersionTagsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteVersionTags, arg.StoreID, arg.OcflID)
+	return err
+}
+
+const getDuplicateContents = `-- name: GetDuplicateContents :many
+SELECT object_contents.digest, objects.ocfl_id FROM object_contents
+JOIN objects ON objects.id = object_contents.object_id
+WHERE objects.store_id = ?1 AND object_contents.digest IN (
+    SELECT c.digest FROM object_contents c
+    JOIN objects o ON o.id = c.object_id
+    WHERE o.store_id = ?1
+    GROUP BY c.digest HAVING COUNT(*) > 1
+)
+ORDER BY object_contents.digest, objects.ocfl_id
+`
+
+type GetDuplicateContentsRow struct {
+	Digest string
+	OcflID string
+}
+
+func (q *Queries) GetDuplicateContents(ctx context.Context, storeID string) ([]GetDuplicateContentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDuplicateContents, storeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDuplicateContentsRow
+	for rows.Next() {
+		var i GetDuplicateContentsRow
+		if err := rows.Scan(&i.Digest, &i.OcflID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMultipartUploads = `-- name: GetMultipartUploads :many
+SELECT id, uploader_id, upload_id, size, expires_at FROM multipart_uploads WHERE uploader_id = ? ORDER BY expires_at
+`
+
+func (q *Queries) GetMultipartUploads(ctx context.Context, uploaderID string) ([]MultipartUpload, error) {
+	rows, err := q.db.QueryContext(ctx, getMultipartUploads, uploaderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MultipartUpload
+	for rows.Next() {
+		var i MultipartUpload
+		if err := rows.Scan(
+			&i.ID,
+			&i.UploaderID,
+			&i.UploadID,
+			&i.Size,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getObject = `-- name: GetObject :one
-SELECT id, store_id, ocfl_id, path, alg, spec FROM objects WHERE store_id = ? AND ocfl_id = ?
+SELECT id, store_id, ocfl_id, path, alg, spec, inventory_digest, metadata, head
+FROM objects WHERE store_id = ? AND ocfl_id = ?
 `
 
 type GetObjectParams struct {
@@ -237,9 +629,21 @@ type GetObjectParams struct {
 	OcflID  string
 }
 
-func (q *Queries) GetObject(ctx context.Context, arg GetObjectParams) (Object, error) {
+type GetObjectRow struct {
+	ID              int64
+	StoreID         string
+	OcflID          string
+	Path            string
+	Alg             string
+	Spec            string
+	InventoryDigest string
+	Metadata        string
+	Head            int64
+}
+
+func (q *Queries) GetObject(ctx context.Context, arg GetObjectParams) (GetObjectRow, error) {
 	row := q.db.QueryRowContext(ctx, getObject, arg.StoreID, arg.OcflID)
-	var i Object
+	var i GetObjectRow
 	err := row.Scan(
 		&i.ID,
 		&i.StoreID,
@@ -247,6 +651,33 @@ func (q *Queries) GetObject(ctx context.Context, arg GetObjectParams) (Object, e
 		&i.Path,
 		&i.Alg,
 		&i.Spec,
+		&i.InventoryDigest,
+		&i.Metadata,
+		&i.Head,
+	)
+	return i, err
+}
+
+const getObjectAudit = `-- name: GetObjectAudit :one
+SELECT store_id, path, ocfl_id, checked_at, valid, errors, warnings FROM object_audits WHERE store_id = ? AND path = ?
+`
+
+type GetObjectAuditParams struct {
+	StoreID string
+	Path    string
+}
+
+func (q *Queries) GetObjectAudit(ctx context.Context, arg GetObjectAuditParams) (ObjectAudit, error) {
+	row := q.db.QueryRowContext(ctx, getObjectAudit, arg.StoreID, arg.Path)
+	var i ObjectAudit
+	err := row.Scan(
+		&i.StoreID,
+		&i.Path,
+		&i.OcflID,
+		&i.CheckedAt,
+		&i.Valid,
+		&i.Errors,
+		&i.Warnings,
 	)
 	return i, err
 }
@@ -306,25 +737,74 @@ func (q *Queries) GetObjectContents(ctx context.Context, objectID int64) ([]Obje
 	return items, nil
 }
 
-const getUploader = `-- name: GetUploader :one
-SELECT id, user_id, algs, description, created_at FROM uploaders WHERE id = ? LIMIT 1
+const getObjectHead = `-- name: GetObjectHead :one
+SELECT id, path, head FROM objects WHERE store_id = ? AND ocfl_id = ?
 `
 
-func (q *Queries) GetUploader(ctx context.Context, id string) (Uploader, error) {
-	row := q.db.QueryRowContext(ctx, getUploader, id)
-	var i Uploader
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Algs,
-		&i.Description,
-		&i.CreatedAt,
-	)
-	return i, err
+type GetObjectHeadParams struct {
+	StoreID string
+	OcflID  string
 }
 
-const getUploaderIDs = `-- name: GetUploaderIDs :many
-SELECT id FROM uploaders ORDER BY created_at
+type GetObjectHeadRow struct {
+	ID   int64
+	Path string
+	Head int64
+}
+
+func (q *Queries) GetObjectHead(ctx context.Context, arg GetObjectHeadParams) (GetObjectHeadRow, error) {
+	row := q.db.QueryRowContext(ctx, getObjectHead, arg.StoreID, arg.OcflID)
+	var i GetObjectHeadRow
+	err := row.Scan(&i.ID, &i.Path, &i.Head)
+	return i, err
+}
+
+const getObjectIDs = `-- name: GetObjectIDs :many
+SELECT ocfl_id FROM objects WHERE store_id = ? ORDER BY ocfl_id
+`
+
+func (q *Queries) GetObjectIDs(ctx context.Context, storeID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getObjectIDs, storeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var ocfl_id string
+		if err := rows.Scan(&ocfl_id); err != nil {
+			return nil, err
+		}
+		items = append(items, ocfl_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUploader = `-- name: GetUploader :one
+SELECT id, user_id, algs, description, created_at FROM uploaders WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetUploader(ctx context.Context, id string) (Uploader, error) {
+	row := q.db.QueryRowContext(ctx, getUploader, id)
+	var i Uploader
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Algs,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUploaderIDs = `-- name: GetUploaderIDs :many
+SELECT id FROM uploaders ORDER BY created_at
 `
 
 func (q *Queries) GetUploaderIDs(ctx context.Context) ([]string, error) {
@@ -381,3 +861,640 @@ func (q *Queries) GetUploads(ctx context.Context, uploaderID string) ([]Upload,
 	}
 	return items, nil
 }
+
+const getUserUsage = `-- name: GetUserUsage :one
+SELECT COUNT(*) AS files, CAST(COALESCE(SUM(size), 0) AS INTEGER) AS bytes
+FROM (
+    SELECT uploads.size FROM uploads
+    JOIN uploaders ON uploads.uploader_id = uploaders.id
+    WHERE uploaders.user_id = ?1
+    UNION ALL
+    SELECT multipart_uploads.size FROM multipart_uploads
+    JOIN uploaders ON multipart_uploads.uploader_id = uploaders.id
+    WHERE uploaders.user_id = ?1
+) AS usage
+`
+
+type GetUserUsageRow struct {
+	Files int64
+	Bytes int64
+}
+
+func (q *Queries) GetUserUsage(ctx context.Context, userID string) (GetUserUsageRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserUsage, userID)
+	var i GetUserUsageRow
+	err := row.Scan(&i.Files, &i.Bytes)
+	return i, err
+}
+
+const getVersionTag = `-- name: GetVersionTag :one
+SELECT store_id, ocfl_id, name, version, created_at FROM version_tags WHERE store_id = ? AND ocfl_id = ? AND name = ?
+`
+
+type GetVersionTagParams struct {
+	StoreID string
+	OcflID  string
+	Name    string
+}
+
+func (q *Queries) GetVersionTag(ctx context.Context, arg GetVersionTagParams) (VersionTag, error) {
+	row := q.db.QueryRowContext(ctx, getVersionTag, arg.StoreID, arg.OcflID, arg.Name)
+	var i VersionTag
+	err := row.Scan(
+		&i.StoreID,
+		&i.OcflID,
+		&i.Name,
+		&i.Version,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const lastEventSeq = `-- name: LastEventSeq :one
+SELECT CAST(COALESCE(MAX(seq), 0) AS INTEGER) FROM events
+`
+
+func (q *Queries) LastEventSeq(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, lastEventSeq)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const listEndpointWebhookDeliveries = `-- name: ListEndpointWebhookDeliveries :many
+SELECT id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status, last_error, created_at, updated_at FROM webhook_deliveries WHERE endpoint_id = ?
+ORDER BY created_at DESC, id LIMIT ? OFFSET ?
+`
+
+type ListEndpointWebhookDeliveriesParams struct {
+	EndpointID string
+	Limit      int64
+	Offset     int64
+}
+
+func (q *Queries) ListEndpointWebhookDeliveries(ctx context.Context, arg ListEndpointWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listEndpointWebhookDeliveries, arg.EndpointID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.EndpointID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastStatus,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEvents = `-- name: ListEvents :many
+SELECT seq, id, type, store_id, ocfl_id, uploader_id, user_id, created_at FROM events WHERE seq > ? ORDER BY seq LIMIT ?
+`
+
+type ListEventsParams struct {
+	Seq   int64
+	Limit int64
+}
+
+func (q *Queries) ListEvents(ctx context.Context, arg ListEventsParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listEvents, arg.Seq, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.Seq,
+			&i.ID,
+			&i.Type,
+			&i.StoreID,
+			&i.OcflID,
+			&i.UploaderID,
+			&i.UserID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFailedObjectAudits = `-- name: ListFailedObjectAudits :many
+SELECT store_id, path, ocfl_id, checked_at, valid, errors, warnings FROM object_audits WHERE store_id = ? AND valid = FALSE
+ORDER BY checked_at DESC LIMIT ? OFFSET ?
+`
+
+type ListFailedObjectAuditsParams struct {
+	StoreID string
+	Limit   int64
+	Offset  int64
+}
+
+func (q *Queries) ListFailedObjectAudits(ctx context.Context, arg ListFailedObjectAuditsParams) ([]ObjectAudit, error) {
+	rows, err := q.db.QueryContext(ctx, listFailedObjectAudits, arg.StoreID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ObjectAudit
+	for rows.Next() {
+		var i ObjectAudit
+		if err := rows.Scan(
+			&i.StoreID,
+			&i.Path,
+			&i.OcflID,
+			&i.CheckedAt,
+			&i.Valid,
+			&i.Errors,
+			&i.Warnings,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listObjectAudits = `-- name: ListObjectAudits :many
+SELECT store_id, path, ocfl_id, checked_at, valid, errors, warnings FROM object_audits WHERE store_id = ?
+ORDER BY checked_at DESC LIMIT ? OFFSET ?
+`
+
+type ListObjectAuditsParams struct {
+	StoreID string
+	Limit   int64
+	Offset  int64
+}
+
+func (q *Queries) ListObjectAudits(ctx context.Context, arg ListObjectAuditsParams) ([]ObjectAudit, error) {
+	rows, err := q.db.QueryContext(ctx, listObjectAudits, arg.StoreID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ObjectAudit
+	for rows.Next() {
+		var i ObjectAudit
+		if err := rows.Scan(
+			&i.StoreID,
+			&i.Path,
+			&i.OcflID,
+			&i.CheckedAt,
+			&i.Valid,
+			&i.Errors,
+			&i.Warnings,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listObjectContents = `-- name: ListObjectContents :many
+SELECT object_id, digest, paths, fixity, size FROM object_contents
+WHERE object_id = ?1 AND digest > ?2
+AND (?3 = '' OR digest IN (
+    SELECT version_paths.digest FROM version_paths
+    WHERE version_paths.object_id = ?1
+    AND substr(version_paths.path, 1, length(?3)) = ?3
+))
+ORDER BY digest LIMIT ?4
+`
+
+type ListObjectContentsParams struct {
+	ObjectID int64
+	Digest   string
+	Prefix   string
+	Limit    int64
+}
+
+func (q *Queries) ListObjectContents(ctx context.Context, arg ListObjectContentsParams) ([]ObjectContent, error) {
+	rows, err := q.db.QueryContext(ctx, listObjectContents,
+		arg.ObjectID,
+		arg.Digest,
+		arg.Prefix,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ObjectContent
+	for rows.Next() {
+		var i ObjectContent
+		if err := rows.Scan(
+			&i.ObjectID,
+			&i.Digest,
+			&i.Paths,
+			&i.Fixity,
+			&i.Size,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listObjects = `-- name: ListObjects :many
+SELECT store_id, ocfl_id, path, metadata FROM objects WHERE store_id = ?
+ORDER BY ocfl_id LIMIT ? OFFSET ?
+`
+
+type ListObjectsParams struct {
+	StoreID string
+	Limit   int64
+	Offset  int64
+}
+
+type ListObjectsRow struct {
+	StoreID  string
+	OcflID   string
+	Path     string
+	Metadata string
+}
+
+func (q *Queries) ListObjects(ctx context.Context, arg ListObjectsParams) ([]ListObjectsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listObjects, arg.StoreID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListObjectsRow
+	for rows.Next() {
+		var i ListObjectsRow
+		if err := rows.Scan(
+			&i.StoreID,
+			&i.OcflID,
+			&i.Path,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVersionPaths = `-- name: ListVersionPaths :many
+SELECT version_paths.path, version_paths.digest, object_contents.size
+FROM version_paths JOIN object_contents
+ON object_contents.object_id = version_paths.object_id
+AND object_contents.digest = version_paths.digest
+WHERE version_paths.object_id = ?1
+AND version_paths.num = ?2
+AND version_paths.path > ?3
+AND version_paths.path >= ?4
+AND substr(version_paths.path, 1, length(?4)) = ?4
+ORDER BY version_paths.path LIMIT ?5
+`
+
+type ListVersionPathsParams struct {
+	ObjectID int64
+	Num      int64
+	After    string
+	Prefix   string
+	Limit    int64
+}
+
+type ListVersionPathsRow struct {
+	Path   string
+	Digest string
+	Size   int64
+}
+
+func (q *Queries) ListVersionPaths(ctx context.Context, arg ListVersionPathsParams) ([]ListVersionPathsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listVersionPaths,
+		arg.ObjectID,
+		arg.Num,
+		arg.After,
+		arg.Prefix,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListVersionPathsRow
+	for rows.Next() {
+		var i ListVersionPathsRow
+		if err := rows.Scan(&i.Path, &i.Digest, &i.Size); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVersionTags = `-- name: ListVersionTags :many
+SELECT store_id, ocfl_id, name, version, created_at FROM version_tags WHERE store_id = ? AND ocfl_id = ? ORDER BY name
+`
+
+type ListVersionTagsParams struct {
+	StoreID string
+	OcflID  string
+}
+
+func (q *Queries) ListVersionTags(ctx context.Context, arg ListVersionTagsParams) ([]VersionTag, error) {
+	rows, err := q.db.QueryContext(ctx, listVersionTags, arg.StoreID, arg.OcflID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []VersionTag
+	for rows.Next() {
+		var i VersionTag
+		if err := rows.Scan(
+			&i.StoreID,
+			&i.OcflID,
+			&i.Name,
+			&i.Version,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status, last_error, created_at, updated_at FROM webhook_deliveries
+ORDER BY created_at DESC, id LIMIT ? OFFSET ?
+`
+
+type ListWebhookDeliveriesParams struct {
+	Limit  int64
+	Offset int64
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookDeliveries, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.EndpointID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastStatus,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchObjects = `-- name: SearchObjects :many
+SELECT store_id, ocfl_id, path, metadata FROM objects
+WHERE store_id = ?1
+AND (?2 = '' OR id IN (
+    SELECT rowid FROM object_search WHERE object_search MATCH ?2
+))
+AND (?3 = '' OR id IN (
+    SELECT object_id FROM object_contents WHERE digest = ?3
+))
+ORDER BY ocfl_id LIMIT ?4 OFFSET ?5
+`
+
+type SearchObjectsParams struct {
+	StoreID string
+	Query   string
+	Digest  string
+	Limit   int64
+	Offset  int64
+}
+
+type SearchObjectsRow struct {
+	StoreID  string
+	OcflID   string
+	Path     string
+	Metadata string
+}
+
+func (q *Queries) SearchObjects(ctx context.Context, arg SearchObjectsParams) ([]SearchObjectsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchObjects,
+		arg.StoreID,
+		arg.Query,
+		arg.Digest,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchObjectsRow
+	for rows.Next() {
+		var i SearchObjectsRow
+		if err := rows.Scan(
+			&i.StoreID,
+			&i.OcflID,
+			&i.Path,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setObjectAudit = `-- name: SetObjectAudit :exec
+INSERT INTO object_audits (
+    store_id,
+    path,
+    ocfl_id,
+    checked_at,
+    valid,
+    errors,
+    warnings
+) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+ON CONFLICT(store_id, path) DO UPDATE SET
+    ocfl_id=?3,
+    checked_at=?4,
+    valid=?5,
+    errors=?6,
+    warnings=?7
+`
+
+type SetObjectAuditParams struct {
+	StoreID   string
+	Path      string
+	OcflID    string
+	CheckedAt time.Time
+	Valid     bool
+	Errors    []byte
+	Warnings  []byte
+}
+
+func (q *Queries) SetObjectAudit(ctx context.Context, arg SetObjectAuditParams) error {
+	_, err := q.db.ExecContext(ctx, setObjectAudit,
+		arg.StoreID,
+		arg.Path,
+		arg.OcflID,
+		arg.CheckedAt,
+		arg.Valid,
+		arg.Errors,
+		arg.Warnings,
+	)
+	return err
+}
+
+const setVersionTag = `-- name: SetVersionTag :exec
+INSERT INTO version_tags (
+    store_id,
+    ocfl_id,
+    name,
+    version,
+    created_at
+) VALUES (?1, ?2, ?3, ?4, ?5)
+ON CONFLICT(store_id, ocfl_id, name) DO UPDATE SET
+    version=?4,
+    created_at=?5
+`
+
+type SetVersionTagParams struct {
+	StoreID   string
+	OcflID    string
+	Name      string
+	Version   int64
+	CreatedAt time.Time
+}
+
+func (q *Queries) SetVersionTag(ctx context.Context, arg SetVersionTagParams) error {
+	_, err := q.db.ExecContext(ctx, setVersionTag,
+		arg.StoreID,
+		arg.OcflID,
+		arg.Name,
+		arg.Version,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const updateWebhookDelivery = `-- name: UpdateWebhookDelivery :exec
+UPDATE webhook_deliveries SET
+    status = ?,
+    attempts = ?,
+    next_attempt_at = ?,
+    last_status = ?,
+    last_error = ?,
+    updated_at = ?
+WHERE id = ?
+`
+
+type UpdateWebhookDeliveryParams struct {
+	Status        string
+	Attempts      int64
+	NextAttemptAt time.Time
+	LastStatus    int64
+	LastError     string
+	UpdatedAt     time.Time
+	ID            string
+}
+
+func (q *Queries) UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, updateWebhookDelivery,
+		arg.Status,
+		arg.Attempts,
+		arg.NextAttemptAt,
+		arg.LastStatus,
+		arg.LastError,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	return err
+}
@@ -1,85 +1,45 @@
 package chapdb_test
 
 import (
-	"context"
 	"testing"
-	"time"
 
 	"github.com/carlmjohnson/be"
-	"github.com/srerickson/chaparral"
+	"github.com/srerickson/chaparral/server/audit"
 	"github.com/srerickson/chaparral/server/chapdb"
+	"github.com/srerickson/chaparral/server/chapdb/dbtest"
+	"github.com/srerickson/chaparral/server/eventlog"
+	"github.com/srerickson/chaparral/server/store"
 	"github.com/srerickson/chaparral/server/uploader"
-	"github.com/srerickson/ocfl-go"
+	"github.com/srerickson/chaparral/server/webhook"
 )
 
-func TestUploader(t *testing.T) {
-	ctx := context.Background()
-	db, err := chapdb.Open("sqlite3", ":memory:", true)
-	be.NilErr(t, err)
-	defer db.Close()
-	chapDB := (*chapdb.SQLiteDB)(db)
-
-	t.Run("round-trip", func(t *testing.T) {
-		// check that GetUploader returns what was given to CreateUploader
-		input := &uploader.PersistentUploader{
-			ID:        "uploader",
-			CreatedAt: time.Now().UTC(),
-			Config: uploader.Config{
-				UserID:      "user",
-				Algs:        []string{"sha512", "md5"},
-				Description: "description",
-			},
-			Uploads: []uploader.Upload{
-				{Name: "file", Size: 12, Digests: map[string]string{"a": "b"}},
-			},
-		}
-		err = chapDB.CreateUploader(ctx, input)
-		be.NilErr(t, err)
-		output, err := chapDB.GetUploader(ctx, "uploader")
-		be.NilErr(t, err)
-		be.DeepEqual(t, input, output)
-
-		ids, err := chapDB.GetUploaderIDs(ctx)
-		be.NilErr(t, err)
-		be.DeepEqual(t, []string{"uploader"}, ids)
-
-		be.NilErr(t, chapDB.DeleteUploader(ctx, "uploader"))
-		counter, err := chapDB.CountUploaders(ctx)
+func TestSQLite(t *testing.T) {
+	testConformance(t, func(t *testing.T) chapdb.DB {
+		db, err := chapdb.OpenDSN(":memory:", true)
 		be.NilErr(t, err)
-		be.Equal(t, 0, counter)
+		t.Cleanup(func() { db.Close() })
+		return db
 	})
 }
 
-func TestObject(t *testing.T) {
-	ctx := context.Background()
-	db, err := chapdb.Open("sqlite3", ":memory:", true)
-	be.NilErr(t, err)
-	defer db.Close()
-	chapDB := (*chapdb.SQLiteDB)(db)
-	in := &chaparral.ObjectManifest{
-		ObjectRef: chaparral.ObjectRef{
-			StorageRootID: "store-id",
-			ID:            "object-id",
-		},
-		DigestAlgorithm: "sha512",
-		Spec:            "1.0",
-		Path:            "a/place",
-		Manifest: chaparral.Manifest{
-			"abc1": chaparral.FileInfo{
-				Paths: []string{"a", "b", "c"},
-				Size:  13,
-			},
-			"abc2": chaparral.FileInfo{
-				Paths:  []string{"dir/a"},
-				Size:   1,
-				Fixity: ocfl.DigestSet{"md5": "abc123"},
-			},
-		},
-	}
-	be.NilErr(t, chapDB.SetObjectManifest(ctx, in))
-	in.Spec = "1.1"
-	be.NilErr(t, chapDB.SetObjectManifest(ctx, in))
-	out, err := chapDB.GetObjectManifest(ctx, in.StorageRootID, in.ID)
-	be.NilErr(t, err)
-	be.DeepEqual(t, in, out)
+// testConformance runs the dbtest suites for a chapdb.DB implementation.
+func testConformance(t *testing.T, newDB func(*testing.T) chapdb.DB) {
+	t.Run("persistence", func(t *testing.T) {
+		dbtest.TestPersistence(t, func(t *testing.T) uploader.Persistence { return newDB(t) })
+	})
+	t.Run("object cache", func(t *testing.T) {
+		dbtest.TestObjectCache(t, func(t *testing.T) store.ObjectCache { return newDB(t) })
+	})
+	t.Run("tags", func(t *testing.T) {
+		dbtest.TestTagPersistence(t, func(t *testing.T) store.TagPersistence { return newDB(t) })
+	})
+	t.Run("audit", func(t *testing.T) {
+		dbtest.TestAuditPersistence(t, func(t *testing.T) audit.Persistence { return newDB(t) })
+	})
+	t.Run("webhooks", func(t *testing.T) {
+		dbtest.TestWebhookPersistence(t, func(t *testing.T) webhook.Persistence { return newDB(t) })
+	})
+	t.Run("events", func(t *testing.T) {
+		dbtest.TestEventPersistence(t, func(t *testing.T) eventlog.Persistence { return newDB(t) })
+	})
 }
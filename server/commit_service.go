@@ -5,14 +5,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/bufbuild/connect-go"
 	chap "github.com/srerickson/chaparral"
 	chaparralv1 "github.com/srerickson/chaparral/gen/chaparral/v1"
 	"github.com/srerickson/chaparral/gen/chaparral/v1/chaparralv1connect"
+	"github.com/srerickson/chaparral/server/eventlog"
 	"github.com/srerickson/chaparral/server/internal/lock"
+	"github.com/srerickson/chaparral/server/store"
 	"github.com/srerickson/chaparral/server/uploader"
 	"github.com/srerickson/ocfl-go"
 	"github.com/srerickson/ocfl-go/ocflv1"
@@ -20,6 +26,11 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	defaultPartURLExpiry = time.Hour
+	maxPartURLExpiry     = 7 * 24 * time.Hour
+)
+
 var (
 	ErrDigestAlgorithm = errors.New("invalid digest algorithm")
 )
@@ -68,6 +79,10 @@ func (s *CommitService) Commit(ctx context.Context, req *connect.Request[chaparr
 		err := fmt.Errorf("digest algorithm must be %s or %s", ocfl.SHA512, ocfl.SHA256)
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
+	if !store.Limits().AllowsAlg(commitAlg) {
+		err := fmt.Errorf("storage root %q doesn't allow commits using %s", req.Msg.StorageRootId, commitAlg)
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 	if req.Msg.State == nil {
 		err := errors.New("missing required 'state' value")
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
@@ -93,6 +108,8 @@ func (s *CommitService) Commit(ctx context.Context, req *connect.Request[chaparr
 		State:           state,
 		DigestAlgorithm: commitAlg,
 	}
+	// sizes of staged content, saved in the storage root's cache
+	sizes := map[string]int64{}
 	for _, item := range req.Msg.ContentSources {
 		switch src := item.Item.(type) {
 		case *chaparralv1.CommitRequest_ContentSourceItem_Uploader:
@@ -131,6 +148,11 @@ func (s *CommitService) Commit(ctx context.Context, req *connect.Request[chaparr
 				err := fmt.Errorf("error staging uploader %q", uploaderID)
 				return nil, connect.NewWireError(connect.CodeInvalidArgument, err)
 			}
+			for _, upload := range upper.Uploads() {
+				if digest := upload.Digests[commitAlg]; digest != "" {
+					sizes[digest] = upload.Size
+				}
+			}
 		case *chaparralv1.CommitRequest_ContentSourceItem_Object:
 			// commit content from another object
 			logger.Debug("commit from existing object state",
@@ -164,9 +186,30 @@ func (s *CommitService) Commit(ctx context.Context, req *connect.Request[chaparr
 				err := fmt.Errorf("error staging source object %q", src.Object.ObjectId)
 				return nil, connect.NewWireError(connect.CodeInvalidArgument, err)
 			}
+			for digest, info := range srcObj.Manifest {
+				if info.Size >= 0 {
+					sizes[digest] = info.Size
+				}
+			}
 		}
 
 	}
+	if req.Msg.Metadata != nil {
+		// save metadata as a file in the new version state
+		metaBytes, err := json.Marshal(MetadataFromProto(req.Msg.Metadata))
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		metaStage, err := ocfl.StageBytes(map[string][]byte{chap.MetadataPath: metaBytes}, commitAlg)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		if err := stage.Overlay(metaStage); err != nil {
+			err = fmt.Errorf("adding metadata to object state: %w", err)
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		sizes[metaStage.State.GetDigest(chap.MetadataPath)] = int64(len(metaBytes))
+	}
 
 	commitOpts := []ocflv1.CommitOption{
 		ocflv1.WithMessage(req.Msg.Message),
@@ -177,13 +220,27 @@ func (s *CommitService) Commit(ctx context.Context, req *connect.Request[chaparr
 		commitOpts = append(commitOpts, ocflv1.WithHEAD(int(req.Msg.Version)))
 	}
 	logger.Debug("finalizing commit")
-	if err := store.Commit(commitCtx, req.Msg.ObjectId, stage, commitOpts...); err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	if err := store.Commit(commitCtx, req.Msg.ObjectId, stage, sizes, commitOpts...); err != nil {
+		return nil, commitError(err)
 	}
+	s.notify(ctx, eventlog.Event{
+		Type:          eventlog.ObjectCommitted,
+		StorageRootID: req.Msg.StorageRootId,
+		ObjectID:      req.Msg.ObjectId,
+	})
 	resp := &chaparralv1.CommitResponse{}
 	return connect.NewResponse(resp), nil
 }
 
+// commitError returns a connect error for errors from StorageRoot.Commit.
+func commitError(err error) *connect.Error {
+	switch {
+	case errors.Is(err, store.ErrLimitExceeded), errors.Is(err, lock.ErrCapacity):
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	}
+	return connect.NewError(connect.CodeInvalidArgument, err)
+}
+
 // DeleteObject permanently deletes an existing OCFL object.
 func (s *CommitService) DeleteObject(ctx context.Context, req *connect.Request[chaparralv1.DeleteObjectRequest]) (*connect.Response[chaparralv1.DeleteObjectResponse], error) {
 	store, err := s.storageRoot(req.Msg.StorageRootId)
@@ -202,10 +259,186 @@ func (s *CommitService) DeleteObject(ctx context.Context, req *connect.Request[c
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	s.notify(ctx, eventlog.Event{
+		Type:          eventlog.ObjectDeleted,
+		StorageRootID: req.Msg.StorageRootId,
+		ObjectID:      req.Msg.ObjectId,
+	})
 	resp := &chaparralv1.DeleteObjectResponse{}
 	return connect.NewResponse(resp), nil
 }
 
+// CopyObject copies an existing object, with all its versions, to a different
+// storage root.
+func (s *CommitService) CopyObject(ctx context.Context, req *connect.Request[chaparralv1.CopyObjectRequest]) (*connect.Response[chaparralv1.CopyObjectResponse], error) {
+	logger := LoggerFromCtx(ctx).With(
+		chap.QueryStorageRoot, req.Msg.SrcStorageRootId,
+		chap.QueryObjectID, req.Msg.ObjectId,
+		"dst_storage_root", req.Msg.DstStorageRootId,
+	)
+	srcStore, dstStore, err := s.copyStorageRoots(req.Msg.SrcStorageRootId, req.Msg.DstStorageRootId)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.ObjectId == "" {
+		err := errors.New("missing required 'object_id' value")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	noCancel := context.WithoutCancel(ctx)
+	if err := dstStore.CopyObject(noCancel, srcStore, req.Msg.ObjectId); err != nil {
+		logger.Error("copying object: " + err.Error())
+		return nil, copyObjectError(err)
+	}
+	s.notify(ctx, eventlog.Event{
+		Type:          eventlog.ObjectCommitted,
+		StorageRootID: req.Msg.DstStorageRootId,
+		ObjectID:      req.Msg.ObjectId,
+	})
+	resp := &chaparralv1.CopyObjectResponse{}
+	return connect.NewResponse(resp), nil
+}
+
+// MoveObject moves an existing object, with all its versions, to a different
+// storage root. The source object is deleted after the copy is validated.
+func (s *CommitService) MoveObject(ctx context.Context, req *connect.Request[chaparralv1.MoveObjectRequest]) (*connect.Response[chaparralv1.MoveObjectResponse], error) {
+	logger := LoggerFromCtx(ctx).With(
+		chap.QueryStorageRoot, req.Msg.SrcStorageRootId,
+		chap.QueryObjectID, req.Msg.ObjectId,
+		"dst_storage_root", req.Msg.DstStorageRootId,
+	)
+	srcStore, dstStore, err := s.copyStorageRoots(req.Msg.SrcStorageRootId, req.Msg.DstStorageRootId)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.ObjectId == "" {
+		err := errors.New("missing required 'object_id' value")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	noCancel := context.WithoutCancel(ctx)
+	if err := dstStore.MoveObject(noCancel, srcStore, req.Msg.ObjectId); err != nil {
+		logger.Error("moving object: " + err.Error())
+		return nil, copyObjectError(err)
+	}
+	s.notify(ctx, eventlog.Event{
+		Type:          eventlog.ObjectCommitted,
+		StorageRootID: req.Msg.DstStorageRootId,
+		ObjectID:      req.Msg.ObjectId,
+	})
+	s.notify(ctx, eventlog.Event{
+		Type:          eventlog.ObjectDeleted,
+		StorageRootID: req.Msg.SrcStorageRootId,
+		ObjectID:      req.Msg.ObjectId,
+	})
+	resp := &chaparralv1.MoveObjectResponse{}
+	return connect.NewResponse(resp), nil
+}
+
+// copyStorageRoots returns the source and destination storage roots for
+// copy/move requests.
+func (s *CommitService) copyStorageRoots(srcID, dstID string) (*store.StorageRoot, *store.StorageRoot, error) {
+	if srcID == dstID {
+		err := errors.New("source and destination storage roots must be different")
+		return nil, nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	srcStore, err := s.storageRoot(srcID)
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	dstStore, err := s.storageRoot(dstID)
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	return srcStore, dstStore, nil
+}
+
+func copyObjectError(err error) *connect.Error {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, store.ErrObjectExists):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, lock.ErrCapacity), errors.Is(err, store.ErrLimitExceeded):
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	case errors.Is(err, store.ErrDisallowedDigest):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, lock.ErrWriteLock), errors.Is(err, lock.ErrReadLock):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+// TagVersion sets a named tag on an object version.
+func (s *CommitService) TagVersion(ctx context.Context, req *connect.Request[chaparralv1.TagVersionRequest]) (*connect.Response[chaparralv1.TagVersionResponse], error) {
+	logger := LoggerFromCtx(ctx).With(
+		chap.QueryStorageRoot, req.Msg.StorageRootId,
+		chap.QueryObjectID, req.Msg.ObjectId,
+		"tag", req.Msg.Name,
+	)
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if req.Msg.ObjectId == "" {
+		err := errors.New("missing required 'object_id' value")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if req.Msg.Version < 0 {
+		err := errors.New("version must not be negative")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	noCancel := context.WithoutCancel(ctx)
+	tag, err := store.TagVersion(noCancel, req.Msg.ObjectId, req.Msg.Name, int(req.Msg.Version))
+	if err != nil {
+		connErr := tagError(err)
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error("tagging version: " + err.Error())
+		}
+		return nil, connErr
+	}
+	resp := &chaparralv1.TagVersionResponse{Tag: (*VersionTag)(tag).AsProto()}
+	return connect.NewResponse(resp), nil
+}
+
+// DeleteTag removes a version tag from an object.
+func (s *CommitService) DeleteTag(ctx context.Context, req *connect.Request[chaparralv1.DeleteTagRequest]) (*connect.Response[chaparralv1.DeleteTagResponse], error) {
+	logger := LoggerFromCtx(ctx).With(
+		chap.QueryStorageRoot, req.Msg.StorageRootId,
+		chap.QueryObjectID, req.Msg.ObjectId,
+		"tag", req.Msg.Name,
+	)
+	store, err := s.storageRoot(req.Msg.StorageRootId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	noCancel := context.WithoutCancel(ctx)
+	if err := store.DeleteVersionTag(noCancel, req.Msg.ObjectId, req.Msg.Name); err != nil {
+		connErr := tagError(err)
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error("deleting version tag: " + err.Error())
+		}
+		return nil, connErr
+	}
+	resp := &chaparralv1.DeleteTagResponse{}
+	return connect.NewResponse(resp), nil
+}
+
+// tagError returns a connect error for errors from version tag methods.
+func tagError(err error) *connect.Error {
+	switch {
+	case errors.Is(err, store.ErrTagsDisabled):
+		return connect.NewError(connect.CodeUnimplemented, err)
+	case errors.Is(err, store.ErrInvalidTagName):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, fs.ErrNotExist):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, lock.ErrCapacity):
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	case errors.Is(err, lock.ErrWriteLock), errors.Is(err, lock.ErrReadLock):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
 func (s *CommitService) NewUploader(ctx context.Context, req *connect.Request[chaparralv1.NewUploaderRequest]) (*connect.Response[chaparralv1.NewUploaderResponse], error) {
 	logger := LoggerFromCtx(ctx)
 	user := AuthUserFromCtx(ctx)
@@ -246,6 +479,7 @@ func (s *CommitService) NewUploader(ctx context.Context, req *connect.Request[ch
 		}
 	}()
 	logger.Debug("new uploader", "uploader_id", id)
+	s.notify(ctx, eventlog.Event{Type: eventlog.UploaderCreated, UploaderID: id})
 	config := newUp.Config()
 	resp := &chaparralv1.NewUploaderResponse{
 		UploaderId:       id,
@@ -357,10 +591,369 @@ func (s *CommitService) DeleteUploader(ctx context.Context, req *connect.Request
 	if err := upper.Delete(noCancelCtx); err != nil {
 		return nil, connect.NewError(connect.CodeAborted, err)
 	}
+	s.notify(ctx, eventlog.Event{Type: eventlog.UploaderDeleted, UploaderID: req.Msg.UploaderId})
 	resp := &chaparralv1.DeleteUploaderResponse{}
 	return connect.NewResponse(resp), nil
 }
 
+// NewMultipartUpload starts a multipart upload to an uploader. Clients upload
+// parts directly to the storage backend using the presigned URLs in the
+// response.
+func (s *CommitService) NewMultipartUpload(ctx context.Context, req *connect.Request[chaparralv1.NewMultipartUploadRequest]) (*connect.Response[chaparralv1.NewMultipartUploadResponse], error) {
+	logger := LoggerFromCtx(ctx).With(chap.QueryUploaderID, req.Msg.UploaderId)
+	if s.uploadMgr == nil {
+		err := errors.New("the storage root does not allow uploading")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	expiresIn := time.Duration(req.Msg.ExpiresIn) * time.Second
+	switch {
+	case expiresIn == 0:
+		expiresIn = defaultPartURLExpiry
+	case expiresIn < 0 || expiresIn > maxPartURLExpiry:
+		err := fmt.Errorf("'expires_in' must be between 1 and %d seconds", int64(maxPartURLExpiry.Seconds()))
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	upper, err := s.uploadMgr.GetUploader(ctx, req.Msg.UploaderId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	defer func() {
+		if err := upper.Close(context.WithoutCancel(ctx)); err != nil {
+			logger.Error(err.Error())
+		}
+	}()
+	upload, err := upper.NewMultipartUpload(ctx, req.Msg.Size, int(req.Msg.PartCount), expiresIn)
+	if err != nil {
+		connErr := multipartError(err)
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error(err.Error())
+		}
+		return nil, connErr
+	}
+	resp := &chaparralv1.NewMultipartUploadResponse{
+		Name:     upload.Name,
+		UploadId: upload.UploadID,
+		PartUrls: upload.PartURLs,
+		Expires:  timestamppb.New(upload.Expires),
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// CompleteMultipartUpload assembles the parts of a multipart upload and adds
+// the file to the uploader.
+func (s *CommitService) CompleteMultipartUpload(ctx context.Context, req *connect.Request[chaparralv1.CompleteMultipartUploadRequest]) (*connect.Response[chaparralv1.CompleteMultipartUploadResponse], error) {
+	logger := LoggerFromCtx(ctx).With(chap.QueryUploaderID, req.Msg.UploaderId)
+	// don't cancel context if client disconnects.
+	noCancelCtx := context.WithoutCancel(ctx)
+	if s.uploadMgr == nil {
+		err := errors.New("the storage root does not allow uploading")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	upper, err := s.uploadMgr.GetUploader(ctx, req.Msg.UploaderId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	defer func() {
+		if err := upper.Close(noCancelCtx); err != nil {
+			logger.Error(err.Error())
+		}
+	}()
+	parts := make([]uploader.Part, len(req.Msg.Parts))
+	for i, p := range req.Msg.Parts {
+		parts[i] = uploader.Part{Number: p.Number, ETag: p.Etag}
+	}
+	upload, err := upper.CompleteMultipartUpload(noCancelCtx, req.Msg.Name, req.Msg.UploadId, parts, req.Msg.Digests)
+	if err != nil {
+		connErr := multipartError(err)
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error(err.Error())
+		}
+		return nil, connErr
+	}
+	resp := &chaparralv1.CompleteMultipartUploadResponse{
+		Digests: upload.Digests,
+		Size:    upload.Size,
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// AbortMultipartUpload cancels a multipart upload.
+func (s *CommitService) AbortMultipartUpload(ctx context.Context, req *connect.Request[chaparralv1.AbortMultipartUploadRequest]) (*connect.Response[chaparralv1.AbortMultipartUploadResponse], error) {
+	logger := LoggerFromCtx(ctx).With(chap.QueryUploaderID, req.Msg.UploaderId)
+	if s.uploadMgr == nil {
+		err := errors.New("the storage root does not allow uploading")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	upper, err := s.uploadMgr.GetUploader(ctx, req.Msg.UploaderId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	defer func() {
+		if err := upper.Close(context.WithoutCancel(ctx)); err != nil {
+			logger.Error(err.Error())
+		}
+	}()
+	if err := upper.AbortMultipartUpload(ctx, req.Msg.Name, req.Msg.UploadId); err != nil {
+		connErr := multipartError(err)
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error(err.Error())
+		}
+		return nil, connErr
+	}
+	return connect.NewResponse(&chaparralv1.AbortMultipartUploadResponse{}), nil
+}
+
+// HasContent reports which digests are already available in an uploader or
+// an object.
+func (s *CommitService) HasContent(ctx context.Context, req *connect.Request[chaparralv1.HasContentRequest]) (*connect.Response[chaparralv1.HasContentResponse], error) {
+	logger := LoggerFromCtx(ctx).With(
+		chap.QueryUploaderID, req.Msg.UploaderId,
+		chap.QueryStorageRoot, req.Msg.StorageRootId,
+		chap.QueryObjectID, req.Msg.ObjectId,
+	)
+	alg := req.Msg.DigestAlgorithm
+	if alg == "" {
+		err := errors.New("missing required 'digest_algorithm'")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if req.Msg.UploaderId == "" && req.Msg.ObjectId == "" {
+		err := errors.New("must provide 'uploader_id' or 'object_id'")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	found := map[string]bool{}
+	if req.Msg.UploaderId != "" {
+		if s.uploadMgr == nil {
+			err := errors.New("the storage root does not allow uploading")
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		upper, err := s.uploadMgr.GetUploader(ctx, req.Msg.UploaderId)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		defer func() {
+			if err := upper.Close(context.WithoutCancel(ctx)); err != nil {
+				logger.Error(err.Error())
+			}
+		}()
+		for _, digest := range req.Msg.Digests {
+			if upper.HasContent(alg, digest) {
+				found[digest] = true
+			}
+		}
+	}
+	if req.Msg.ObjectId != "" {
+		store, err := s.storageRoot(req.Msg.StorageRootId)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		obj, err := store.GetObjectManifest(ctx, req.Msg.ObjectId)
+		switch {
+		case err == nil:
+			defer obj.Close()
+			digests := manifestDigests(obj.ObjectManifest, alg)
+			for _, digest := range req.Msg.Digests {
+				if digests[strings.ToLower(digest)] {
+					found[digest] = true
+				}
+			}
+		case errors.Is(err, fs.ErrNotExist):
+			// the object doesn't exist yet
+		default:
+			logger.Error(err.Error())
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	resp := &chaparralv1.HasContentResponse{}
+	for _, digest := range req.Msg.Digests {
+		if found[digest] {
+			resp.Digests = append(resp.Digests, digest)
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// manifestDigests returns the set of lowercase digests for content in the
+// object's manifest, computed with alg. If the object uses a different digest
+// algorithm, digests are taken from the manifest's fixity.
+func manifestDigests(obj *chap.ObjectManifest, alg string) map[string]bool {
+	digests := make(map[string]bool, len(obj.Manifest))
+	for digest, info := range obj.Manifest {
+		if obj.DigestAlgorithm != alg {
+			digest = info.Fixity[alg]
+		}
+		if digest != "" {
+			digests[strings.ToLower(digest)] = true
+		}
+	}
+	return digests
+}
+
+// multipartError returns a connect error for errors from multipart upload
+// methods.
+func multipartError(err error) *connect.Error {
+	switch {
+	case errors.Is(err, uploader.ErrMultipartUnsupported):
+		return connect.NewError(connect.CodeUnimplemented, err)
+	case errors.Is(err, uploader.ErrPartCount),
+		errors.Is(err, uploader.ErrUploadName),
+		errors.Is(err, uploader.ErrDigestAlgorithm),
+		errors.Is(err, uploader.ErrDigestMismatch),
+		errors.Is(err, uploader.ErrUploadSize):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, uploader.ErrQuotaExceeded):
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+// GetUsage returns upload usage and quota for a user.
+func (s *CommitService) GetUsage(ctx context.Context, req *connect.Request[chaparralv1.GetUsageRequest]) (*connect.Response[chaparralv1.GetUsageResponse], error) {
+	logger := LoggerFromCtx(ctx)
+	if s.uploadMgr == nil {
+		err := errors.New("the storage root does not allow uploading")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	userID := req.Msg.UserId
+	if userID == "" {
+		userID = AuthUserFromCtx(ctx).ID
+	}
+	if userID == "" {
+		err := errors.New("missing required 'user_id' value")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	usage, err := s.uploadMgr.UserUsage(ctx, userID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	quota := s.uploadMgr.Quota(ctx, userID)
+	resp := &chaparralv1.GetUsageResponse{
+		UserId:           userID,
+		Bytes:            usage.Bytes,
+		Files:            int64(usage.Files),
+		MaxBytes:         quota.MaxBytes,
+		MaxFiles:         int64(quota.MaxFiles),
+		MaxUploaderBytes: quota.MaxUploaderBytes,
+		MaxUploaderFiles: int64(quota.MaxUploaderFiles),
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// Upload adds a file sent in chunks over a client stream to an uploader. It's
+// an alternative to HandleUpload for gRPC clients.
+func (s *CommitService) Upload(ctx context.Context, stream *connect.ClientStream[chaparralv1.UploadRequest]) (*connect.Response[chaparralv1.UploadResponse], error) {
+	if s.uploadMgr == nil {
+		err := errors.New("the storage root does not allow uploading")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if !stream.Receive() {
+		err := stream.Err()
+		if err == nil {
+			err = errors.New("missing upload request")
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	first := stream.Msg()
+	logger := LoggerFromCtx(ctx).With(chap.QueryUploaderID, first.UploaderId)
+	upper, err := s.uploadMgr.GetUploader(ctx, first.UploaderId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	defer func() {
+		if err := upper.Close(context.WithoutCancel(ctx)); err != nil {
+			logger.Error(err.Error())
+		}
+	}()
+	body := &uploadStreamReader{
+		stream:   stream,
+		config:   upper.Config(),
+		expected: ocfl.DigestSet{},
+	}
+	if err := body.add(first); err != nil {
+		return nil, uploadError(err)
+	}
+	upload, err := upper.WriteVerify(ctx, body, func() (ocfl.DigestSet, error) {
+		return body.expected, nil
+	})
+	if err != nil {
+		if body.err != nil {
+			// the reader's error may not be wrapped by the backend
+			err = body.err
+		}
+		connErr := uploadError(err)
+		if connErr.Code() == connect.CodeInternal {
+			logger.Error(err.Error())
+		}
+		return nil, connErr
+	}
+	resp := &chaparralv1.UploadResponse{
+		Digests: upload.Digests,
+		Size:    upload.Size,
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// uploadStreamReader reads the content of a file from an Upload request
+// stream. Expected digests in each message are added to expected. As with
+// digest fields in HandleUpload, digests for algorithms the uploader doesn't
+// use are ignored.
+type uploadStreamReader struct {
+	stream   *connect.ClientStream[chaparralv1.UploadRequest]
+	config   *uploader.Config
+	expected ocfl.DigestSet
+	buf      []byte
+	err      error
+}
+
+func (r *uploadStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if !r.stream.Receive() {
+			if err := r.stream.Err(); err != nil {
+				r.err = err
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		if err := r.add(r.stream.Msg()); err != nil {
+			r.err = err
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *uploadStreamReader) add(msg *chaparralv1.UploadRequest) error {
+	for alg, digest := range msg.Digests {
+		if !r.config.UsesAlg(alg) {
+			continue
+		}
+		if err := addExpectedDigest(r.expected, alg, digest); err != nil {
+			return err
+		}
+	}
+	r.buf = msg.Data
+	return nil
+}
+
+func uploadError(err error) *connect.Error {
+	var connErr *connect.Error
+	switch {
+	case errors.As(err, &connErr):
+		return connErr
+	case errors.Is(err, uploader.ErrDigestMismatch),
+		errors.Is(err, errConflictingDigests):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, uploader.ErrQuotaExceeded):
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
 // Handler for file uploads.
 func (s *CommitService) HandleUpload(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -405,9 +998,31 @@ func (s *CommitService) HandleUpload(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 	// TODO: only allow uploading to own uploaders
-	upload, err := upper.Write(ctx, r.Body)
+	expected, err := uploadQueryDigests(r.URL.Query(), upper.Config())
+	if err == nil {
+		err = addUploadFieldDigests(expected, r.Header, upper.Config())
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		errMsg = err.Error()
+		return
+	}
+	upload, err := upper.WriteVerify(ctx, r.Body, func() (ocfl.DigestSet, error) {
+		// digest fields may also be sent as trailers
+		return expected, addUploadFieldDigests(expected, r.Trailer, upper.Config())
+	})
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, uploader.ErrDigestMismatch),
+			errors.Is(err, uploader.ErrDigestAlgorithm),
+			errors.Is(err, chap.ErrDigestField),
+			errors.Is(err, errConflictingDigests):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, uploader.ErrQuotaExceeded):
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
 		errMsg = err.Error()
 		return
 	}
@@ -415,10 +1030,104 @@ func (s *CommitService) HandleUpload(w http.ResponseWriter, r *http.Request) {
 	result.Size = upload.Size
 }
 
+var errConflictingDigests = errors.New("conflicting expected digests")
+
+// uploadQueryDigests returns expected digests for an upload from the query's
+// digest parameters, which have the form "alg:digest". The algorithms must be
+// used by the uploader.
+func uploadQueryDigests(query url.Values, config *uploader.Config) (ocfl.DigestSet, error) {
+	expected := ocfl.DigestSet{}
+	for _, val := range query[chap.QueryDigest] {
+		alg, digest, found := strings.Cut(val, ":")
+		if !found || digest == "" {
+			return nil, fmt.Errorf("invalid %q parameter: %q", chap.QueryDigest, val)
+		}
+		if !config.UsesAlg(alg) {
+			return nil, fmt.Errorf("%w: %q", uploader.ErrDigestAlgorithm, alg)
+		}
+		if err := addExpectedDigest(expected, alg, digest); err != nil {
+			return nil, err
+		}
+	}
+	return expected, nil
+}
+
+// addUploadFieldDigests adds expected digests from the Repr-Digest and Digest
+// fields in header to expected. As with RFC 9530, digests for algorithms the
+// uploader doesn't use are ignored.
+func addUploadFieldDigests(expected ocfl.DigestSet, header http.Header, config *uploader.Config) error {
+	fields := []struct {
+		name  string
+		parse func(string) (ocfl.DigestSet, error)
+	}{
+		{chap.HeaderReprDigest, chap.ParseReprDigest},
+		{chap.HeaderDigest, chap.ParseDigest},
+	}
+	for _, field := range fields {
+		vals := header.Values(field.name)
+		if len(vals) == 0 {
+			continue
+		}
+		digests, err := field.parse(strings.Join(vals, ","))
+		if err != nil {
+			return err
+		}
+		for alg, digest := range digests {
+			if !config.UsesAlg(alg) {
+				continue
+			}
+			if err := addExpectedDigest(expected, alg, digest); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func addExpectedDigest(expected ocfl.DigestSet, alg string, digest string) error {
+	if existing, ok := expected[alg]; ok && !strings.EqualFold(existing, digest) {
+		return fmt.Errorf("%w: %s", errConflictingDigests, alg)
+	}
+	expected[alg] = digest
+	return nil
+}
+
 // AuthIntercept is middleware that does authorization for all grpc/connect-go
-// requests to the commit service. Note that auth for the upload handler is done
-// in handler itself.
-func (s *CommitService) AuthorizeInterceptor() connect.UnaryInterceptorFunc {
+// requests to the commit service, including streaming requests. Note that auth
+// for the upload handler is done in handler itself.
+func (s *CommitService) AuthorizeInterceptor() connect.Interceptor {
+	return commitAuthInterceptor{
+		UnaryInterceptorFunc: s.authorizeUnary(),
+		service:              s,
+	}
+}
+
+// commitAuthInterceptor does authorization for streaming requests. Unary
+// requests are handled by the embedded UnaryInterceptorFunc.
+type commitAuthInterceptor struct {
+	connect.UnaryInterceptorFunc
+	service *CommitService
+}
+
+func (i commitAuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	auth := i.service.auth
+	if auth == nil {
+		return next
+	}
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		var ok bool
+		switch conn.Spec().Procedure {
+		case chaparralv1connect.CommitServiceUploadProcedure:
+			ok = auth.Allowed(ctx, ActionCommitObject, "*::*")
+		}
+		if !ok {
+			return connect.NewError(connect.CodePermissionDenied, errors.New("API key insufficient permission"))
+		}
+		return next(ctx, conn)
+	}
+}
+
+func (s *CommitService) authorizeUnary() connect.UnaryInterceptorFunc {
 	return func(next connect.UnaryFunc) connect.UnaryFunc {
 		if s.auth == nil {
 			return next
@@ -446,6 +1155,23 @@ func (s *CommitService) AuthorizeInterceptor() connect.UnaryInterceptorFunc {
 			case *chaparralv1.DeleteObjectRequest:
 				resource := AuthResource(msg.StorageRootId, msg.ObjectId)
 				ok = s.auth.Allowed(ctx, ActionDeleteObject, resource)
+			case *chaparralv1.CopyObjectRequest:
+				src := AuthResource(msg.SrcStorageRootId, msg.ObjectId)
+				dst := AuthResource(msg.DstStorageRootId, msg.ObjectId)
+				ok = s.auth.Allowed(ctx, ActionReadObject, src) &&
+					s.auth.Allowed(ctx, ActionCommitObject, dst)
+			case *chaparralv1.MoveObjectRequest:
+				src := AuthResource(msg.SrcStorageRootId, msg.ObjectId)
+				dst := AuthResource(msg.DstStorageRootId, msg.ObjectId)
+				ok = s.auth.Allowed(ctx, ActionReadObject, src) &&
+					s.auth.Allowed(ctx, ActionDeleteObject, src) &&
+					s.auth.Allowed(ctx, ActionCommitObject, dst)
+			case *chaparralv1.TagVersionRequest:
+				resource := AuthResource(msg.StorageRootId, msg.ObjectId)
+				ok = s.auth.Allowed(ctx, ActionCommitObject, resource)
+			case *chaparralv1.DeleteTagRequest:
+				resource := AuthResource(msg.StorageRootId, msg.ObjectId)
+				ok = s.auth.Allowed(ctx, ActionCommitObject, resource)
 			case *chaparralv1.NewUploaderRequest:
 				ok = s.auth.Allowed(ctx, ActionCommitObject, "*::*")
 			case *chaparralv1.DeleteUploaderRequest:
@@ -454,6 +1180,28 @@ func (s *CommitService) AuthorizeInterceptor() connect.UnaryInterceptorFunc {
 				ok = s.auth.Allowed(ctx, ActionCommitObject, "*::*")
 			case *chaparralv1.ListUploadersRequest:
 				ok = s.auth.Allowed(ctx, ActionCommitObject, "*::*")
+			case *chaparralv1.NewMultipartUploadRequest:
+				ok = s.auth.Allowed(ctx, ActionCommitObject, "*::*")
+			case *chaparralv1.CompleteMultipartUploadRequest:
+				ok = s.auth.Allowed(ctx, ActionCommitObject, "*::*")
+			case *chaparralv1.AbortMultipartUploadRequest:
+				ok = s.auth.Allowed(ctx, ActionCommitObject, "*::*")
+			case *chaparralv1.HasContentRequest:
+				ok = true
+				if msg.UploaderId != "" {
+					ok = s.auth.Allowed(ctx, ActionCommitObject, "*::*")
+				}
+				if ok && msg.ObjectId != "" {
+					resource := AuthResource(msg.StorageRootId, msg.ObjectId)
+					ok = s.auth.Allowed(ctx, ActionReadObject, resource)
+				}
+			case *chaparralv1.GetUsageRequest:
+				user := AuthUserFromCtx(ctx)
+				if msg.UserId == "" || msg.UserId == user.ID {
+					ok = s.auth.Allowed(ctx, ActionCommitObject, "*::*")
+					break
+				}
+				ok = s.auth.Allowed(ctx, ActionAdmin, AuthResource("*", "*"))
 			}
 			if !ok {
 				return nil, connect.NewError(connect.CodePermissionDenied, errors.New("API key insufficient permission"))
@@ -1,6 +1,7 @@
 package server_test
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/json"
@@ -8,7 +9,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 
@@ -19,6 +23,8 @@ import (
 	chapv1connect "github.com/srerickson/chaparral/gen/chaparral/v1/chaparralv1connect"
 	"github.com/srerickson/chaparral/internal/testutil"
 	"github.com/srerickson/chaparral/server"
+	"github.com/srerickson/chaparral/server/store"
+	"github.com/srerickson/chaparral/server/uploader"
 	"github.com/srerickson/ocfl-go"
 	"golang.org/x/exp/slices"
 )
@@ -114,6 +120,79 @@ func TestCommitServiceCommit(t *testing.T) {
 	testutil.RunServiceTest(t, test, testUnauthorized)
 }
 
+func TestCommitServiceCopyObject(t *testing.T) {
+	ctx := context.Background()
+	objID := "ark:123/abc"
+	otherID := "other"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	srcRoot := testutil.NewStoreTempDir(t)
+	dstRoot := testutil.NewStoreTempDirID(t, otherID)
+	be.NilErr(t, srcRoot.CopyObject(ctx, fixture, objID))
+	mux := server.New(
+		server.WithStorageRoots(srcRoot, dstRoot),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	htc := srv.Client()
+	commitCli := chapv1connect.NewCommitServiceClient(htc, srv.URL)
+	accessCli := chapv1connect.NewAccessServiceClient(htc, srv.URL)
+	copyReq := &chapv1.CopyObjectRequest{
+		SrcStorageRootId: srcRoot.ID(),
+		ObjectId:         objID,
+		DstStorageRootId: dstRoot.ID(),
+	}
+	moveReq := &chapv1.MoveObjectRequest{
+		SrcStorageRootId: srcRoot.ID(),
+		ObjectId:         objID,
+		DstStorageRootId: dstRoot.ID(),
+	}
+	getDst := &chapv1.GetObjectVersionRequest{StorageRootId: dstRoot.ID(), ObjectId: objID}
+	getSrc := &chapv1.GetObjectVersionRequest{StorageRootId: srcRoot.ID(), ObjectId: objID}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		// managers can't commit to the destination storage root
+		testutil.SetUserToken(htc, testutil.ManagerUser)
+		_, err := commitCli.CopyObject(ctx, connect.NewRequest(copyReq))
+		isConnectErrCode(t, err, connect.CodePermissionDenied)
+		_, err = commitCli.MoveObject(ctx, connect.NewRequest(moveReq))
+		isConnectErrCode(t, err, connect.CodePermissionDenied)
+	})
+	t.Run("copy", func(t *testing.T) {
+		testutil.SetUserToken(htc, testutil.AdminUser)
+		_, err := commitCli.CopyObject(ctx, connect.NewRequest(copyReq))
+		be.NilErr(t, err)
+		_, err = accessCli.GetObjectVersion(ctx, connect.NewRequest(getDst))
+		be.NilErr(t, err)
+		// the object already exists in the destination
+		_, err = commitCli.CopyObject(ctx, connect.NewRequest(copyReq))
+		isConnectErrCode(t, err, connect.CodeAlreadyExists)
+		_, err = commitCli.DeleteObject(ctx, connect.NewRequest(&chapv1.DeleteObjectRequest{
+			StorageRootId: dstRoot.ID(),
+			ObjectId:      objID,
+		}))
+		be.NilErr(t, err)
+	})
+	t.Run("move", func(t *testing.T) {
+		testutil.SetUserToken(htc, testutil.AdminUser)
+		_, err := commitCli.MoveObject(ctx, connect.NewRequest(moveReq))
+		be.NilErr(t, err)
+		_, err = accessCli.GetObjectVersion(ctx, connect.NewRequest(getDst))
+		be.NilErr(t, err)
+		_, err = accessCli.GetObjectVersion(ctx, connect.NewRequest(getSrc))
+		isConnectErrCode(t, err, connect.CodeNotFound)
+	})
+	t.Run("same storage root", func(t *testing.T) {
+		testutil.SetUserToken(htc, testutil.AdminUser)
+		_, err := commitCli.CopyObject(ctx, connect.NewRequest(&chapv1.CopyObjectRequest{
+			SrcStorageRootId: dstRoot.ID(),
+			ObjectId:         objID,
+			DstStorageRootId: dstRoot.ID(),
+		}))
+		isConnectErrCode(t, err, connect.CodeInvalidArgument)
+	})
+}
+
 func TestCommitServiceUploader(t *testing.T) {
 	testutil.RunServiceTest(t, func(t *testing.T, htc *http.Client, url string) {
 		times := 4 // concurrent uploaders
@@ -293,3 +372,502 @@ func goGroupErrors(times int, run func() error) []error {
 	wg.Wait()
 	return errs
 }
+
+func TestCommitServiceHasContent(t *testing.T) {
+	ctx := context.Background()
+	objectID := "ark:123/abc"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	store := testutil.NewStoreTempDir(t)
+	be.NilErr(t, store.CopyObject(ctx, fixture, objectID))
+	mgr := uploader.NewManager(store.FS(), "uploads", nil)
+	mux := server.New(server.WithStorageRoots(store),
+		server.WithUploaderManager(mgr),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	htc := srv.Client()
+	cli := chaparral.NewClient(htc, srv.URL)
+	testutil.SetUserToken(htc, testutil.ManagerUser)
+	up, err := cli.NewUploader(ctx, []string{ocfl.SHA512, ocfl.MD5}, "has content test")
+	be.NilErr(t, err)
+
+	// uploading the same content twice only stores it once
+	first, err := cli.Upload(ctx, up.UploadPath, strings.NewReader("new content"))
+	be.NilErr(t, err)
+	second, err := cli.Upload(ctx, up.UploadPath, strings.NewReader("new content"))
+	be.NilErr(t, err)
+	be.DeepEqual(t, first, second)
+	up, err = cli.GetUploader(ctx, up.ID)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(up.Uploads))
+
+	newDigest := first.Digests[ocfl.SHA512]
+	obj := chaparral.ObjectRef{StorageRootID: store.ID(), ID: objectID}
+	found, err := cli.HasContent(ctx, up.ID, obj, ocfl.SHA512, "missing", testDigest, newDigest)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []string{testDigest, newDigest}, found)
+	found, err = cli.HasContent(ctx, up.ID, chaparral.ObjectRef{}, ocfl.SHA512, testDigest, newDigest)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []string{newDigest}, found)
+	found, err = cli.HasContent(ctx, "", obj, ocfl.SHA512, testDigest, newDigest)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []string{testDigest}, found)
+	found, err = cli.HasContent(ctx, up.ID, obj, ocfl.MD5, first.Digests[ocfl.MD5])
+	be.NilErr(t, err)
+	be.DeepEqual(t, []string{first.Digests[ocfl.MD5]}, found)
+	// new objects don't have content
+	found, err = cli.HasContent(ctx, "", chaparral.ObjectRef{StorageRootID: store.ID(), ID: "new"}, ocfl.SHA512, testDigest)
+	be.NilErr(t, err)
+	be.Equal(t, 0, len(found))
+
+	_, err = cli.HasContent(ctx, up.ID, obj, "", testDigest)
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+	_, err = cli.HasContent(ctx, "", chaparral.ObjectRef{}, ocfl.SHA512, testDigest)
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+	_, err = cli.HasContent(ctx, "missing", chaparral.ObjectRef{}, ocfl.SHA512, testDigest)
+	isConnectErrCode(t, err, connect.CodeNotFound)
+
+	// members can check objects but not uploaders
+	testutil.SetUserToken(htc, testutil.MemberUser)
+	found, err = cli.HasContent(ctx, "", obj, ocfl.SHA512, testDigest)
+	be.NilErr(t, err)
+	be.DeepEqual(t, []string{testDigest}, found)
+	_, err = cli.HasContent(ctx, up.ID, obj, ocfl.SHA512, testDigest)
+	isConnectErrCode(t, err, connect.CodePermissionDenied)
+	testutil.SetUserToken(htc, testutil.AnonUser)
+	_, err = cli.HasContent(ctx, "", obj, ocfl.SHA512, testDigest)
+	isConnectErrCode(t, err, connect.CodePermissionDenied)
+}
+
+func TestCommitServiceUploadDigests(t *testing.T) {
+	testutil.RunServiceTest(t, func(t *testing.T, htc *http.Client, baseURL string) {
+		ctx := context.Background()
+		cli := chaparral.NewClient(htc, baseURL)
+		up, err := cli.NewUploader(ctx, []string{ocfl.SHA256}, "digest test")
+		be.NilErr(t, err)
+		defer func() {
+			be.NilErr(t, cli.DeleteUploader(ctx, up.ID))
+		}()
+		content := "test content"
+		digester := ocfl.NewDigester(ocfl.SHA256)
+		digester.Write([]byte(content))
+		sha256 := digester.String()
+		goodField, err := chaparral.FormatReprDigest(ocfl.DigestSet{ocfl.SHA256: sha256})
+		be.NilErr(t, err)
+		badField, err := chaparral.FormatReprDigest(ocfl.DigestSet{ocfl.SHA256: strings.Repeat("0", 64)})
+		be.NilErr(t, err)
+		upload := func(t *testing.T, query string, header http.Header, trailer http.Header) int {
+			t.Helper()
+			body := io.Reader(strings.NewReader(content))
+			if trailer != nil {
+				// hide the reader's type so the request is chunked
+				body = io.MultiReader(body)
+			}
+			req, err := http.NewRequest(http.MethodPost, baseURL+up.UploadPath+query, body)
+			be.NilErr(t, err)
+			for k, v := range header {
+				req.Header[k] = v
+			}
+			req.Trailer = trailer
+			resp, err := htc.Do(req)
+			be.NilErr(t, err)
+			defer resp.Body.Close()
+			return resp.StatusCode
+		}
+		tests := []struct {
+			desc    string
+			query   string
+			header  http.Header
+			trailer http.Header
+			status  int
+		}{
+			{desc: "query", query: "&digest=sha256:" + sha256, status: http.StatusOK},
+			{desc: "bad query", query: "&digest=sha256:abc", status: http.StatusBadRequest},
+			{desc: "unused query alg", query: "&digest=md5:abc", status: http.StatusBadRequest},
+			{desc: "repr-digest", header: http.Header{chaparral.HeaderReprDigest: {goodField}}, status: http.StatusOK},
+			{desc: "bad repr-digest", header: http.Header{chaparral.HeaderReprDigest: {badField}}, status: http.StatusBadRequest},
+			{desc: "invalid repr-digest", header: http.Header{chaparral.HeaderReprDigest: {"sha-256=abc"}}, status: http.StatusBadRequest},
+			{desc: "unused repr-digest alg", header: http.Header{chaparral.HeaderReprDigest: {"md5=:AAAA:"}}, status: http.StatusOK},
+			{desc: "digest", header: http.Header{chaparral.HeaderDigest: {strings.ReplaceAll(goodField, ":", "")}}, status: http.StatusOK},
+			{desc: "conflicting", query: "&digest=sha256:" + sha256, header: http.Header{chaparral.HeaderReprDigest: {badField}}, status: http.StatusBadRequest},
+			{desc: "trailer", trailer: http.Header{chaparral.HeaderReprDigest: {goodField}}, status: http.StatusOK},
+			{desc: "bad trailer", trailer: http.Header{chaparral.HeaderReprDigest: {badField}}, status: http.StatusBadRequest},
+		}
+		for _, tcase := range tests {
+			t.Run(tcase.desc, func(t *testing.T) {
+				be.Equal(t, tcase.status, upload(t, tcase.query, tcase.header, tcase.trailer))
+			})
+		}
+		// accepted uploads have the same content, so there is only one.
+		up, err = cli.GetUploader(ctx, up.ID)
+		be.NilErr(t, err)
+		be.Equal(t, 1, len(up.Uploads))
+	})
+}
+
+func TestCommitServiceUploadStream(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStoreTempDir(t)
+	mgr := uploader.NewManager(store.FS(), "uploads", nil)
+	mux := server.New(server.WithStorageRoots(store),
+		server.WithUploaderManager(mgr),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	htc := srv.Client()
+	cli := chaparral.NewClient(htc, srv.URL)
+	commitCli := chapv1connect.NewCommitServiceClient(htc, srv.URL)
+	testutil.SetUserToken(htc, testutil.ManagerUser)
+	up, err := cli.NewUploader(ctx, []string{ocfl.SHA512, ocfl.MD5}, "upload stream test")
+	be.NilErr(t, err)
+
+	// content is sent in multiple chunks
+	content := make([]byte, 600_000)
+	_, err = rand.Read(content)
+	be.NilErr(t, err)
+	result, err := cli.UploadStream(ctx, up.ID, bytes.NewReader(content))
+	be.NilErr(t, err)
+	be.Equal(t, int64(len(content)), result.Size)
+	be.Equal(t, 2, len(result.Digests))
+	// same result as the upload path
+	httpResult, err := cli.Upload(ctx, up.UploadPath, bytes.NewReader(content))
+	be.NilErr(t, err)
+	be.DeepEqual(t, httpResult, result)
+	empty, err := cli.UploadStream(ctx, up.ID, strings.NewReader(""))
+	be.NilErr(t, err)
+	be.Equal(t, int64(0), empty.Size)
+	up, err = cli.GetUploader(ctx, up.ID)
+	be.NilErr(t, err)
+	be.Equal(t, 2, len(up.Uploads))
+
+	// upload with expected digests
+	upload := func(msgs ...*chapv1.UploadRequest) error {
+		stream := commitCli.Upload(ctx)
+		for _, msg := range msgs {
+			if err := stream.Send(msg); err != nil {
+				break
+			}
+		}
+		_, err := stream.CloseAndReceive()
+		return err
+	}
+	digester := ocfl.NewDigester(ocfl.MD5)
+	digester.Write([]byte("content"))
+	md5 := digester.String()
+	be.NilErr(t, upload(
+		&chapv1.UploadRequest{UploaderId: up.ID, Data: []byte("con")},
+		&chapv1.UploadRequest{Data: []byte("tent"), Digests: map[string]string{ocfl.MD5: md5}},
+	))
+	err = upload(&chapv1.UploadRequest{UploaderId: up.ID, Data: []byte("content"), Digests: map[string]string{ocfl.MD5: "abc"}})
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+	err = upload(
+		&chapv1.UploadRequest{UploaderId: up.ID, Data: []byte("content"), Digests: map[string]string{ocfl.MD5: md5}},
+		&chapv1.UploadRequest{Digests: map[string]string{ocfl.MD5: "abc"}},
+	)
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+	up, err = cli.GetUploader(ctx, up.ID)
+	be.NilErr(t, err)
+	be.Equal(t, 3, len(up.Uploads))
+
+	_, err = cli.UploadStream(ctx, "missing", strings.NewReader("content"))
+	isConnectErrCode(t, err, connect.CodeNotFound)
+	err = upload()
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+	testutil.SetUserToken(htc, testutil.MemberUser)
+	_, err = cli.UploadStream(ctx, up.ID, strings.NewReader("content"))
+	isConnectErrCode(t, err, connect.CodePermissionDenied)
+}
+
+func TestCommitServiceMultipartUpload(t *testing.T) {
+	testutil.RunServiceTest(t, func(t *testing.T, htc *http.Client, url string) {
+		ctx := context.Background()
+		cli := chaparral.NewClient(htc, url)
+		up, err := cli.NewUploader(ctx, []string{ocfl.SHA256}, "multipart test")
+		be.NilErr(t, err)
+		defer func() {
+			be.NilErr(t, cli.DeleteUploader(ctx, up.ID))
+		}()
+		// S3 parts, except the last, must be at least 5 MiB.
+		partSize := int64(5 * 1024 * 1024)
+		content := make([]byte, partSize+size)
+		_, err = rand.Read(content)
+		be.NilErr(t, err)
+		digester := ocfl.NewDigester(ocfl.SHA256)
+		digester.Write(content)
+		digests := ocfl.DigestSet{ocfl.SHA256: digester.String()}
+		result, err := cli.UploadMultipart(ctx, up.ID, bytes.NewReader(content), int64(len(content)), partSize, digests)
+		be.NilErr(t, err)
+		be.Equal(t, int64(len(content)), result.Size)
+		be.DeepEqual(t, digests, result.Digests)
+
+		// commit the upload
+		objectID := "multipart-object"
+		be.NilErr(t, cli.Commit(ctx, &chaparral.Commit{
+			To:             chaparral.ObjectRef{StorageRootID: testutil.TestStoreID, ID: objectID},
+			Message:        "multipart upload",
+			User:           ocfl.User{Name: "Test"},
+			State:          map[string]string{"file.dat": digests[ocfl.SHA256]},
+			Alg:            ocfl.SHA256,
+			ContentSources: []any{up.UploaderRef},
+		}))
+		cont, err := cli.GetContent(ctx, testutil.TestStoreID, objectID, digests[ocfl.SHA256])
+		be.NilErr(t, err)
+		defer cont.Close()
+		got, err := io.ReadAll(cont)
+		be.NilErr(t, err)
+		be.True(t, bytes.Equal(content, got))
+
+		// digest mismatch
+		_, err = cli.UploadMultipart(ctx, up.ID, bytes.NewReader(content[:size]), size, partSize, ocfl.DigestSet{
+			ocfl.SHA256: "bad",
+		})
+		isConnectErrCode(t, err, connect.CodeInvalidArgument)
+
+		// abort
+		mp, err := cli.NewMultipartUpload(ctx, up.ID, size, 1, 0)
+		be.NilErr(t, err)
+		be.NilErr(t, cli.AbortMultipartUpload(ctx, mp))
+		_, err = cli.NewMultipartUpload(ctx, up.ID, size, 0, 0)
+		isConnectErrCode(t, err, connect.CodeInvalidArgument)
+		_, err = cli.NewMultipartUpload(ctx, up.ID, -1, 1, 0)
+		isConnectErrCode(t, err, connect.CodeInvalidArgument)
+
+		// pending uploads are aborted when the uploader is deleted
+		up2, err := cli.NewUploader(ctx, []string{ocfl.SHA256}, "multipart test")
+		be.NilErr(t, err)
+		mp, err = cli.NewMultipartUpload(ctx, up2.ID, size, 1, 0)
+		be.NilErr(t, err)
+		be.NilErr(t, cli.DeleteUploader(ctx, up2.ID))
+		err = cli.AbortMultipartUpload(ctx, mp)
+		isConnectErrCode(t, err, connect.CodeNotFound)
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		ctx := context.Background()
+		store := testutil.NewStoreTempDir(t)
+		mgr := uploader.NewManager(store.FS(), "uploads", nil)
+		mux := server.New(server.WithStorageRoots(store), server.WithUploaderManager(mgr))
+		srv := httptest.NewTLSServer(mux)
+		defer srv.Close()
+		cli := chaparral.NewClient(srv.Client(), srv.URL)
+		up, err := cli.NewUploader(ctx, []string{ocfl.SHA256}, "")
+		be.NilErr(t, err)
+		_, err = cli.NewMultipartUpload(ctx, up.ID, 1, 1, 0)
+		isConnectErrCode(t, err, connect.CodeUnimplemented)
+	})
+}
+
+func TestCommitServiceVersionTags(t *testing.T) {
+	ctx := context.Background()
+	fixtureID := "ark:123/abc"
+	objID := "tagged-object"
+	otherID := "other"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	root := testutil.NewStoreTempDir(t)
+	otherRoot := testutil.NewStoreTempDirID(t, otherID)
+	be.NilErr(t, root.CopyObject(ctx, fixture, fixtureID))
+	mux := server.New(
+		server.WithStorageRoots(root, otherRoot),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	htc := srv.Client()
+	cli := chaparral.NewClient(htc, srv.URL)
+	testutil.SetUserToken(htc, testutil.ManagerUser)
+
+	// new object with two versions
+	fixtureVer, err := cli.GetObjectVersion(ctx, root.ID(), fixtureID, 0)
+	be.NilErr(t, err)
+	commit := &chaparral.Commit{
+		To:             chaparral.ObjectRef{StorageRootID: root.ID(), ID: objID},
+		Message:        "first version",
+		User:           ocfl.User{Name: "Test"},
+		State:          fixtureVer.State.PathMap(),
+		Alg:            fixtureVer.DigestAlgorithm,
+		ContentSources: []any{chaparral.ObjectRef{StorageRootID: root.ID(), ID: fixtureID}},
+	}
+	be.NilErr(t, cli.Commit(ctx, commit))
+	commit.Message = "second version"
+	commit.Metadata = &chaparral.ObjectMetadata{Title: "second version"}
+	be.NilErr(t, cli.Commit(ctx, commit))
+	first, err := cli.GetObjectVersion(ctx, root.ID(), objID, 1)
+	be.NilErr(t, err)
+
+	tag, err := cli.TagVersion(ctx, root.ID(), objID, "submitted", first.Version)
+	be.NilErr(t, err)
+	be.Equal(t, first.Version, tag.Version)
+	be.Equal(t, "submitted", tag.Name)
+	be.False(t, tag.Created.IsZero())
+	tag, err = cli.TagVersion(ctx, root.ID(), objID, "published", 0)
+	be.NilErr(t, err)
+	be.Equal(t, first.Version+1, tag.Version)
+	tags, err := cli.ListTags(ctx, root.ID(), objID)
+	be.NilErr(t, err)
+	be.Equal(t, 2, len(tags))
+	be.Equal(t, "published", tags[0].Name)
+	be.Equal(t, "submitted", tags[1].Name)
+
+	// get versions by tag
+	ver, err := cli.GetTaggedVersion(ctx, root.ID(), objID, "submitted")
+	be.NilErr(t, err)
+	be.Equal(t, first.Version, ver.Version)
+	ver, err = cli.GetTaggedVersion(ctx, root.ID(), objID, "published")
+	be.NilErr(t, err)
+	be.Equal(t, first.Version+1, ver.Version)
+	_, err = cli.GetTaggedVersion(ctx, root.ID(), objID, "missing")
+	isConnectErrCode(t, err, connect.CodeNotFound)
+	accessCli := chapv1connect.NewAccessServiceClient(htc, srv.URL)
+	_, err = accessCli.GetObjectVersion(ctx, connect.NewRequest(&chapv1.GetObjectVersionRequest{
+		StorageRootId: root.ID(),
+		ObjectId:      objID,
+		Version:       1,
+		Tag:           "published",
+	}))
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+
+	// invalid tags
+	_, err = cli.TagVersion(ctx, root.ID(), objID, "not valid", 0)
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+	_, err = cli.TagVersion(ctx, root.ID(), objID, "future", first.Version+2)
+	isConnectErrCode(t, err, connect.CodeNotFound)
+	_, err = cli.TagVersion(ctx, root.ID(), "missing", "published", 0)
+	isConnectErrCode(t, err, connect.CodeNotFound)
+
+	// delete
+	be.NilErr(t, cli.DeleteTag(ctx, root.ID(), objID, "submitted"))
+	err = cli.DeleteTag(ctx, root.ID(), objID, "submitted")
+	isConnectErrCode(t, err, connect.CodeNotFound)
+
+	// tags are moved with the object
+	testutil.SetUserToken(htc, testutil.AdminUser)
+	be.NilErr(t, cli.MoveObject(ctx, root.ID(), objID, otherRoot.ID()))
+	tags, err = cli.ListTags(ctx, otherRoot.ID(), objID)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(tags))
+	be.Equal(t, "published", tags[0].Name)
+	tags, err = cli.ListTags(ctx, root.ID(), objID)
+	be.NilErr(t, err)
+	be.Equal(t, 0, len(tags))
+
+	// users without commit permission can't tag versions
+	testutil.SetUserToken(htc, testutil.AnonUser)
+	_, err = cli.TagVersion(ctx, otherRoot.ID(), objID, "mine", 0)
+	isConnectErrCode(t, err, connect.CodePermissionDenied)
+	err = cli.DeleteTag(ctx, otherRoot.ID(), objID, "published")
+	isConnectErrCode(t, err, connect.CodePermissionDenied)
+}
+
+func TestCommitServiceQuotas(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStoreTempDir(t)
+	quotas := server.Quotas{
+		Default: uploader.Quota{MaxBytes: 10, MaxFiles: 5},
+		Roles: map[string]uploader.Quota{
+			testutil.AdminUser.Roles[0]: {}, // unlimited
+		},
+	}
+	mgr := uploader.NewManager(store.FS(), "uploads", testutil.TestDB(t), uploader.WithQuotas(quotas.QuotaFunc()))
+	mux := server.New(
+		server.WithStorageRoots(store),
+		server.WithUploaderManager(mgr),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	htc := srv.Client()
+	cli := chaparral.NewClient(htc, srv.URL)
+	testutil.SetUserToken(htc, testutil.ManagerUser)
+	up, err := cli.NewUploader(ctx, []string{ocfl.SHA256}, "quota test")
+	be.NilErr(t, err)
+	_, err = cli.Upload(ctx, up.UploadPath, strings.NewReader("12345678"))
+	be.NilErr(t, err)
+	_, err = cli.Upload(ctx, up.UploadPath, strings.NewReader("abcdef"))
+	be.Nonzero(t, err)
+	usage, err := cli.GetUsage(ctx, "")
+	be.NilErr(t, err)
+	be.Equal(t, chaparral.UploadUsage{
+		UserID:   testutil.ManagerUser.ID,
+		Bytes:    8,
+		Files:    1,
+		MaxBytes: 10,
+		MaxFiles: 5,
+	}, *usage)
+	// usage for other users requires admin permission
+	_, err = cli.GetUsage(ctx, testutil.AdminUser.ID)
+	isConnectErrCode(t, err, connect.CodePermissionDenied)
+	testutil.SetUserToken(htc, testutil.MemberUser)
+	_, err = cli.GetUsage(ctx, "")
+	isConnectErrCode(t, err, connect.CodePermissionDenied)
+	testutil.SetUserToken(htc, testutil.AdminUser)
+	usage, err = cli.GetUsage(ctx, testutil.ManagerUser.ID)
+	be.NilErr(t, err)
+	be.Equal(t, 8, usage.Bytes)
+	// admins don't have quotas
+	adminUp, err := cli.NewUploader(ctx, []string{ocfl.SHA256}, "admin uploader")
+	be.NilErr(t, err)
+	_, err = cli.Upload(ctx, adminUp.UploadPath, strings.NewReader("0123456789abcdef"))
+	be.NilErr(t, err)
+	usage, err = cli.GetUsage(ctx, "")
+	be.NilErr(t, err)
+	be.Equal(t, chaparral.UploadUsage{UserID: testutil.AdminUser.ID, Bytes: 16, Files: 1}, *usage)
+}
+
+func TestQuotasUserQuota(t *testing.T) {
+	quotas := server.Quotas{
+		Default: uploader.Quota{MaxBytes: 10},
+		Roles: map[string]uploader.Quota{
+			"a": {MaxBytes: 100, MaxFiles: 5},
+			"b": {MaxBytes: 50, MaxUploaderFiles: 2},
+		},
+		Users: map[string]uploader.Quota{
+			"special": {MaxFiles: 1},
+		},
+	}
+	be.Equal(t, quotas.Default, quotas.UserQuota(server.AuthUser{ID: "user"}))
+	be.Equal(t, quotas.Default, quotas.UserQuota(server.AuthUser{ID: "user", Roles: []string{"c"}}))
+	be.Equal(t, quotas.Roles["a"], quotas.UserQuota(server.AuthUser{ID: "user", Roles: []string{"a", "c"}}))
+	// the most permissive values for the user's roles
+	be.Equal(t, uploader.Quota{MaxBytes: 100}, quotas.UserQuota(server.AuthUser{ID: "user", Roles: []string{"a", "b"}}))
+	be.Equal(t, quotas.Users["special"], quotas.UserQuota(server.AuthUser{ID: "special", Roles: []string{"a"}}))
+}
+
+func TestCommitServiceLimits(t *testing.T) {
+	ctx := context.Background()
+	fixtureID := "ark:123/abc"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "testdata"))
+	tmp := testutil.NewStoreTempDir(t)
+	root := store.NewStorageRoot(tmp.ID(), tmp.FS(), tmp.Path(), nil, testutil.TestDB(t),
+		store.WithLimits(store.Limits{
+			MaxCommitBytes:   10,
+			DigestAlgorithms: []string{ocfl.SHA512},
+		}))
+	be.NilErr(t, root.CopyObject(ctx, fixture, fixtureID))
+	mux := server.New(
+		server.WithStorageRoots(root),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	htc := srv.Client()
+	cli := chaparral.NewClient(htc, srv.URL)
+	testutil.SetUserToken(htc, testutil.ManagerUser)
+	fixtureVer, err := cli.GetObjectVersion(ctx, root.ID(), fixtureID, 0)
+	be.NilErr(t, err)
+	commit := &chaparral.Commit{
+		To:             chaparral.ObjectRef{StorageRootID: root.ID(), ID: "new-object"},
+		Message:        "new object",
+		User:           ocfl.User{Name: "Test"},
+		State:          fixtureVer.State.PathMap(),
+		Alg:            fixtureVer.DigestAlgorithm,
+		ContentSources: []any{chaparral.ObjectRef{StorageRootID: root.ID(), ID: fixtureID}},
+	}
+	// the fixture's content is larger than 10 bytes
+	err = cli.Commit(ctx, commit)
+	isConnectErrCode(t, err, connect.CodeResourceExhausted)
+	// sha256 isn't allowed
+	commit.Alg = ocfl.SHA256
+	err = cli.Commit(ctx, commit)
+	isConnectErrCode(t, err, connect.CodeInvalidArgument)
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	chap "github.com/srerickson/chaparral"
+	"github.com/srerickson/chaparral/server/store"
+)
+
+const (
+	defaultDownloadURLExpiry = time.Hour
+	maxDownloadURLExpiry     = 7 * 24 * time.Hour
+)
+
+var (
+	ErrDownloadURLExpired   = errors.New("download URL has expired")
+	ErrDownloadURLSignature = errors.New("download URL signature is invalid")
+	ErrDownloadURLDisabled  = errors.New("signed download URLs are not enabled")
+	errInvalidContentPath   = errors.New("invalid content path")
+)
+
+// signedDownloadURL returns a download URL, relative to the server's base
+// URL, signed with the server's download key.
+func (c *chaparral) signedDownloadURL(storeID, objectID, digest, contentPath string, expires time.Time) (string, error) {
+	if len(c.downloadKey) == 0 {
+		return "", ErrDownloadURLDisabled
+	}
+	exp := expires.Unix()
+	vals := url.Values{
+		chap.QueryStorageRoot: {storeID},
+		chap.QueryObjectID:    {objectID},
+		chap.QueryExpires:     {strconv.FormatInt(exp, 10)},
+		chap.QuerySignature:   {downloadSignature(c.downloadKey, storeID, objectID, digest, contentPath, exp)},
+	}
+	if digest != "" {
+		vals.Set(chap.QueryDigest, digest)
+	}
+	if contentPath != "" {
+		vals.Set(chap.QueryContentPath, contentPath)
+	}
+	return chap.RouteDownload + "?" + vals.Encode(), nil
+}
+
+// verifyDownloadURL checks the signature and expiration in the query values
+// of a signed download URL.
+func (c *chaparral) verifyDownloadURL(vals url.Values) error {
+	if len(c.downloadKey) == 0 {
+		return ErrDownloadURLDisabled
+	}
+	exp, err := strconv.ParseInt(vals.Get(chap.QueryExpires), 10, 64)
+	if err != nil {
+		return ErrDownloadURLSignature
+	}
+	expect := downloadSignature(c.downloadKey,
+		vals.Get(chap.QueryStorageRoot),
+		vals.Get(chap.QueryObjectID),
+		vals.Get(chap.QueryDigest),
+		vals.Get(chap.QueryContentPath),
+		exp)
+	if !hmac.Equal([]byte(expect), []byte(vals.Get(chap.QuerySignature))) {
+		return ErrDownloadURLSignature
+	}
+	if time.Now().Unix() > exp {
+		return ErrDownloadURLExpired
+	}
+	return nil
+}
+
+// downloadSignature returns the HMAC-SHA256 signature for the download values,
+// encoded as unpadded base64url.
+func downloadSignature(key []byte, storeID, objectID, digest, contentPath string, expires int64) string {
+	msg := url.Values{
+		chap.QueryStorageRoot: {storeID},
+		chap.QueryObjectID:    {objectID},
+		chap.QueryDigest:      {digest},
+		chap.QueryContentPath: {contentPath},
+		chap.QueryExpires:     {strconv.FormatInt(expires, 10)},
+	}.Encode()
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(msg))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// contentFullPath returns the path in the storage root's FS for the object
+// content with the content path or, if the content path is empty, the digest.
+// Content paths are not checked for existence.
+func contentFullPath(ctx context.Context, root *store.StorageRoot, objectID, digest, contentPath string) (string, error) {
+	if contentPath == "" {
+		obj, err := root.GetObjectManifest(ctx, objectID)
+		if err != nil {
+			return "", err
+		}
+		defer obj.Close()
+		paths := obj.Manifest[digest].Paths
+		if len(paths) == 0 {
+			return "", fmt.Errorf("object %q has no content with digest %q: %w", objectID, digest, fs.ErrNotExist)
+		}
+		return path.Join(obj.Path, paths[0]), nil
+	}
+	if !fs.ValidPath(contentPath) || contentPath == "." {
+		return "", fmt.Errorf("%w: %s", errInvalidContentPath, contentPath)
+	}
+	objPath, err := root.ResolveID(objectID)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", errInvalidContentPath, err)
+	}
+	return path.Join(root.Path(), objPath, contentPath), nil
+}
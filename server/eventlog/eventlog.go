@@ -0,0 +1,207 @@
+package eventlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types
+const (
+	ObjectCommitted = "object.committed"
+	ObjectDeleted   = "object.deleted"
+	UploaderCreated = "uploader.created"
+	UploaderDeleted = "uploader.deleted"
+)
+
+// ValidType returns true if t is a known event type.
+func ValidType(t string) bool {
+	switch t {
+	case ObjectCommitted, ObjectDeleted, UploaderCreated, UploaderDeleted:
+		return true
+	}
+	return false
+}
+
+// Event is a change to an object or uploader.
+type Event struct {
+	// Seq is the event's position in the log. It is set when the event is
+	// appended; later events have larger values.
+	Seq           int64     `json:"seq,omitempty"`
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	Created       time.Time `json:"created"`
+	StorageRootID string    `json:"storage_root_id,omitempty"`
+	ObjectID      string    `json:"object_id,omitempty"`
+	UploaderID    string    `json:"uploader_id,omitempty"`
+	// UserID is the id of the user whose request caused the event.
+	UserID string `json:"user_id,omitempty"`
+}
+
+// IsObjectEvent returns true if the event is a change to an object.
+func (e Event) IsObjectEvent() bool {
+	return e.Type == ObjectCommitted || e.Type == ObjectDeleted
+}
+
+// Persistence is used to store the event log.
+type Persistence interface {
+	// AppendEvent saves the event and sets its Seq. Events must become
+	// visible to ListEvents in Seq order: an event can't be listed before
+	// an event with a smaller Seq.
+	AppendEvent(ctx context.Context, event *Event) error
+
+	// ListEvents returns events with Seq greater than after, in order.
+	ListEvents(ctx context.Context, after int64, limit int) ([]Event, error)
+
+	// LastEventSeq returns the largest Seq in the log, or zero if the log is
+	// empty.
+	LastEventSeq(ctx context.Context) (int64, error)
+
+	// DeleteEvents removes events created before the time.
+	DeleteEvents(ctx context.Context, before time.Time) error
+}
+
+// Config is used to configure a Log
+type Config struct {
+	// Retention is how long events are kept. If zero, events are never
+	// removed.
+	Retention time.Duration
+	// PollInterval is how often Watch checks for events appended by other
+	// processes that share the log's persistence. The default is 5 seconds.
+	PollInterval time.Duration
+	// Logger is used to log errors removing old events.
+	Logger *slog.Logger
+}
+
+const (
+	defaultPollInterval = 5 * time.Second
+	pruneInterval       = time.Hour
+	watchBatch          = 100
+)
+
+// Log is a persistent, ordered log of events. Watchers are woken as soon as
+// events are appended.
+type Log struct {
+	persist Persistence
+	conf    Config
+	mx      sync.Mutex
+	waiters map[chan struct{}]struct{}
+}
+
+func NewLog(persist Persistence, conf Config) *Log {
+	if conf.PollInterval <= 0 {
+		conf.PollInterval = defaultPollInterval
+	}
+	if conf.Logger == nil {
+		conf.Logger = slog.Default()
+	}
+	return &Log{
+		persist: persist,
+		conf:    conf,
+		waiters: map[chan struct{}]struct{}{},
+	}
+}
+
+// Append saves the event to the log, setting its Seq. If the event's ID or
+// Created time aren't set, they are generated.
+func (l *Log) Append(ctx context.Context, event *Event) error {
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.Created.IsZero() {
+		event.Created = time.Now()
+	}
+	event.Created = event.Created.UTC()
+	if err := l.persist.AppendEvent(ctx, event); err != nil {
+		return fmt.Errorf("saving %s event: %w", event.Type, err)
+	}
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	for ch := range l.waiters {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Events returns up to limit events with Seq greater than after, in order.
+func (l *Log) Events(ctx context.Context, after int64, limit int) ([]Event, error) {
+	return l.persist.ListEvents(ctx, after, limit)
+}
+
+// Last returns the Seq of the most recent event, or zero if the log is empty.
+func (l *Log) Last(ctx context.Context) (int64, error) {
+	return l.persist.LastEventSeq(ctx)
+}
+
+// Watch calls fn with each event with Seq greater than after, in order,
+// including events appended while watching. It returns when ctx is canceled
+// or fn returns an error.
+func (l *Log) Watch(ctx context.Context, after int64, fn func(Event) error) error {
+	// register before reading so appends aren't missed
+	wake := make(chan struct{}, 1)
+	l.mx.Lock()
+	l.waiters[wake] = struct{}{}
+	l.mx.Unlock()
+	defer func() {
+		l.mx.Lock()
+		delete(l.waiters, wake)
+		l.mx.Unlock()
+	}()
+	ticker := time.NewTicker(l.conf.PollInterval)
+	defer ticker.Stop()
+	for {
+		events, err := l.persist.ListEvents(ctx, after, watchBatch)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("reading events: %w", err)
+		}
+		for _, event := range events {
+			if err := fn(event); err != nil {
+				return err
+			}
+			after = event.Seq
+		}
+		if len(events) == watchBatch {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run removes events older than the retention period until ctx is canceled.
+func (l *Log) Run(ctx context.Context) error {
+	if l.conf.Retention <= 0 {
+		return errors.New("event retention must be greater than zero")
+	}
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		before := time.Now().UTC().Add(-l.conf.Retention)
+		if err := l.persist.DeleteEvents(ctx, before); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			l.conf.Logger.Error("removing old events", "err", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package eventlog_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/carlmjohnson/be"
+	"github.com/srerickson/chaparral/internal/testutil"
+	"github.com/srerickson/chaparral/server/eventlog"
+)
+
+func TestLog(t *testing.T) {
+	ctx := context.Background()
+	log := eventlog.NewLog(testutil.TestDB(t), eventlog.Config{})
+	last, err := log.Last(ctx)
+	be.NilErr(t, err)
+	be.Equal(t, int64(0), last)
+	first := &eventlog.Event{Type: eventlog.ObjectCommitted, StorageRootID: "test", ObjectID: "object-1"}
+	be.NilErr(t, log.Append(ctx, first))
+	be.Nonzero(t, first.ID)
+	be.Nonzero(t, first.Seq)
+	be.False(t, first.Created.IsZero())
+	last, err = log.Last(ctx)
+	be.NilErr(t, err)
+	be.Equal(t, first.Seq, last)
+
+	// watch from the start of the log: the existing event is received
+	// followed by events appended while watching.
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	received := make(chan eventlog.Event)
+	done := make(chan error, 1)
+	go func() {
+		done <- log.Watch(watchCtx, 0, func(e eventlog.Event) error {
+			received <- e
+			return nil
+		})
+	}()
+	next := func() eventlog.Event {
+		t.Helper()
+		select {
+		case e := <-received:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+		return eventlog.Event{}
+	}
+	be.Equal(t, first.ID, next().ID)
+	second := &eventlog.Event{Type: eventlog.ObjectDeleted, StorageRootID: "test", ObjectID: "object-1"}
+	be.NilErr(t, log.Append(ctx, second))
+	got := next()
+	be.Equal(t, second.ID, got.ID)
+	be.Equal(t, second.Seq, got.Seq)
+	be.True(t, got.Seq > first.Seq)
+	be.Equal(t, eventlog.ObjectDeleted, got.Type)
+	cancel()
+	be.True(t, errors.Is(<-done, context.Canceled))
+
+	// watching after the last event
+	errStop := errors.New("stop")
+	third := &eventlog.Event{Type: eventlog.UploaderCreated, UploaderID: "uploader-1"}
+	be.NilErr(t, log.Append(ctx, third))
+	err = log.Watch(ctx, second.Seq, func(e eventlog.Event) error {
+		be.Equal(t, third.ID, e.ID)
+		return errStop
+	})
+	be.True(t, errors.Is(err, errStop))
+}
+
+func TestLogRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db := testutil.TestDB(t)
+	// no retention
+	be.True(t, eventlog.NewLog(db, eventlog.Config{}).Run(ctx) != nil)
+	log := eventlog.NewLog(db, eventlog.Config{Retention: time.Hour})
+	be.NilErr(t, log.Append(ctx, &eventlog.Event{
+		Type:    eventlog.ObjectCommitted,
+		Created: time.Now().Add(-2 * time.Hour),
+	}))
+	recent := &eventlog.Event{Type: eventlog.ObjectDeleted}
+	be.NilErr(t, log.Append(ctx, recent))
+	done := make(chan error, 1)
+	go func() { done <- log.Run(ctx) }()
+	var events []eventlog.Event
+	for i := 0; i < 100; i++ {
+		var err error
+		events, err = log.Events(ctx, 0, 10)
+		be.NilErr(t, err)
+		if len(events) == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	be.True(t, errors.Is(<-done, context.Canceled))
+	be.Equal(t, 1, len(events))
+	be.Equal(t, recent.ID, events[0].ID)
+}
@@ -1,8 +1,10 @@
 package server
 
 import (
+	chap "github.com/srerickson/chaparral"
 	chaprv1 "github.com/srerickson/chaparral/gen/chaparral/v1"
 	"github.com/srerickson/ocfl-go"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // User is used to convert to/from a protobuf User
@@ -28,3 +30,45 @@ func UserFromProto(proto *chaprv1.User) *ocfl.User {
 	user.FromProto(proto)
 	return (*ocfl.User)(&user)
 }
+
+// Metadata is used to convert to/from a protobuf ObjectMetadata
+type Metadata chap.ObjectMetadata
+
+// AsProto returns meta as a protobuf ObjectMetadata. It returns nil if meta
+// is nil.
+func (meta *Metadata) AsProto() *chaprv1.ObjectMetadata {
+	if meta == nil {
+		return nil
+	}
+	return &chaprv1.ObjectMetadata{
+		Title:       meta.Title,
+		Description: meta.Description,
+		Creators:    meta.Creators,
+		License:     meta.License,
+		Keywords:    meta.Keywords,
+	}
+}
+
+func MetadataFromProto(proto *chaprv1.ObjectMetadata) *chap.ObjectMetadata {
+	if proto == nil {
+		return nil
+	}
+	return &chap.ObjectMetadata{
+		Title:       proto.Title,
+		Description: proto.Description,
+		Creators:    proto.Creators,
+		License:     proto.License,
+		Keywords:    proto.Keywords,
+	}
+}
+
+// VersionTag is used to convert to a protobuf VersionTag
+type VersionTag chap.VersionTag
+
+func (tag VersionTag) AsProto() *chaprv1.VersionTag {
+	return &chaprv1.VersionTag{
+		Name:    tag.Name,
+		Version: int32(tag.Version),
+		Created: timestamppb.New(tag.Created),
+	}
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+
+	"github.com/srerickson/chaparral/server/uploader"
+)
+
+// Quotas configures upload quotas for users. The quota for a user is the
+// entry in Users for the user's id, if there is one. Otherwise, it is the
+// most permissive quota for the user's roles in Roles or, if none of the
+// user's roles have quotas, the Default quota.
+type Quotas struct {
+	Default uploader.Quota            `json:"default"`
+	Roles   map[string]uploader.Quota `json:"roles"`
+	Users   map[string]uploader.Quota `json:"users"`
+}
+
+func (q Quotas) Empty() bool {
+	return q.Default.Unlimited() && len(q.Roles) < 1 && len(q.Users) < 1
+}
+
+// UserQuota returns the quota for the user.
+func (q Quotas) UserQuota(user AuthUser) uploader.Quota {
+	if quota, ok := q.Users[user.ID]; ok {
+		return quota
+	}
+	var (
+		quota uploader.Quota
+		found bool
+	)
+	for _, role := range user.Roles {
+		roleQuota, ok := q.Roles[role]
+		if !ok {
+			continue
+		}
+		if !found {
+			quota, found = roleQuota, true
+			continue
+		}
+		quota = uploader.Quota{
+			MaxBytes:         maxLimit(quota.MaxBytes, roleQuota.MaxBytes),
+			MaxFiles:         maxLimit(quota.MaxFiles, roleQuota.MaxFiles),
+			MaxUploaderBytes: maxLimit(quota.MaxUploaderBytes, roleQuota.MaxUploaderBytes),
+			MaxUploaderFiles: maxLimit(quota.MaxUploaderFiles, roleQuota.MaxUploaderFiles),
+		}
+	}
+	if !found {
+		return q.Default
+	}
+	return quota
+}
+
+// QuotaFunc returns an uploader.QuotaFunc for use with uploader.WithQuotas.
+// Roles are only known for the user making the request: if the request is
+// from a different user, the quota is determined by Users and Default.
+func (q Quotas) QuotaFunc() uploader.QuotaFunc {
+	return func(ctx context.Context, userID string) uploader.Quota {
+		user := AuthUserFromCtx(ctx)
+		if user.ID != userID {
+			user = AuthUser{ID: userID}
+		}
+		return q.UserQuota(user)
+	}
+}
+
+// maxLimit returns the more permissive of two limits, where 0 is unlimited.
+func maxLimit[T int | int64](a, b T) T {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return max(a, b)
+}
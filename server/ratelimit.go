@@ -0,0 +1,230 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	chap "github.com/srerickson/chaparral"
+	"github.com/srerickson/chaparral/gen/chaparral/v1/chaparralv1connect"
+)
+
+// rateLimitIdle is how long per-user limiter state is kept after the user's
+// last request.
+const rateLimitIdle = 10 * time.Minute
+
+// RateLimit limits the rate and concurrency of a user's requests. Zero values
+// are unlimited.
+type RateLimit struct {
+	// Rate is the sustained number of requests allowed per second.
+	Rate float64 `json:"rate"`
+	// Burst is the number of requests allowed at once before Rate applies. If
+	// it is less than 1, the burst is Rate rounded up.
+	Burst int `json:"burst"`
+	// Concurrent is the maximum number of requests that may be in progress at
+	// the same time.
+	Concurrent int `json:"concurrent"`
+}
+
+func (l RateLimit) unlimited() bool {
+	return l.Rate <= 0 && l.Concurrent <= 0
+}
+
+func (l RateLimit) burst() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return max(math.Ceil(l.Rate), 1)
+}
+
+// RateLimits configures per-user rate limits, with separate budgets for
+// downloads (including the GetContent RPC), uploads (including the Upload and
+// multipart upload RPCs), commits (including object copies and moves), and all
+// other requests.
+type RateLimits struct {
+	Requests  RateLimit `json:"requests"`
+	Downloads RateLimit `json:"downloads"`
+	Uploads   RateLimit `json:"uploads"`
+	Commits   RateLimit `json:"commits"`
+}
+
+// rate limit budgets
+const (
+	budgetRequests = iota
+	budgetDownloads
+	budgetUploads
+	budgetCommits
+	numBudgets
+)
+
+func (l RateLimits) budget(b int) RateLimit {
+	switch b {
+	case budgetDownloads:
+		return l.Downloads
+	case budgetUploads:
+		return l.Uploads
+	case budgetCommits:
+		return l.Commits
+	default:
+		return l.Requests
+	}
+}
+
+// requestBudget returns the budget used for the request.
+func requestBudget(r *http.Request) int {
+	switch r.URL.Path {
+	case chap.RouteDownload,
+		chaparralv1connect.AccessServiceGetContentProcedure:
+		return budgetDownloads
+	case chap.RouteUpload,
+		chaparralv1connect.CommitServiceUploadProcedure,
+		chaparralv1connect.CommitServiceNewMultipartUploadProcedure,
+		chaparralv1connect.CommitServiceCompleteMultipartUploadProcedure:
+		return budgetUploads
+	case chaparralv1connect.CommitServiceCommitProcedure,
+		chaparralv1connect.CommitServiceCopyObjectProcedure,
+		chaparralv1connect.CommitServiceMoveObjectProcedure:
+		return budgetCommits
+	default:
+		return budgetRequests
+	}
+}
+
+// RateLimitMiddleware returns middleware that enforces limits for each user,
+// identified by AuthUserFromCtx. Requests without an authenticated user are
+// limited by remote IP address. Requests that exceed a limit get a
+// ResourceExhausted error (HTTP 429) with a Retry-After header. The middleware
+// must be installed after the middleware that sets the user, as it is with
+// WithMiddleware.
+func RateLimitMiddleware(limits RateLimits) func(http.Handler) http.Handler {
+	limiter := &rateLimiter{
+		limits: limits,
+		users:  map[string]*userLimiter{},
+	}
+	errWriter := connect.NewErrorWriter()
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			budget := requestBudget(r)
+			if limits.budget(budget).unlimited() {
+				next.ServeHTTP(w, r)
+				return
+			}
+			key := rateLimitKey(r)
+			retry, ok := limiter.acquire(key, budget, time.Now())
+			if !ok {
+				LoggerFromCtx(r.Context()).Debug("rate limit exceeded", "rate_limit_key", key)
+				writeRateLimited(w, r, errWriter, retry)
+				return
+			}
+			defer limiter.release(key, budget)
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+func rateLimitKey(r *http.Request) string {
+	if user := AuthUserFromCtx(r.Context()); !user.Empty() {
+		return "user:" + user.ID
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "addr:" + host
+}
+
+func writeRateLimited(w http.ResponseWriter, r *http.Request, errWriter *connect.ErrorWriter, retry time.Duration) {
+	secs := int(math.Ceil(retry.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(max(secs, 1)))
+	err := fmt.Errorf("rate limit exceeded: retry in %d seconds", max(secs, 1))
+	if errWriter.IsSupported(r) {
+		errWriter.Write(w, r, connect.NewError(connect.CodeResourceExhausted, err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(struct {
+		Err string `json:"error"`
+	}{Err: err.Error()})
+}
+
+type rateLimiter struct {
+	limits    RateLimits
+	mx        sync.Mutex
+	users     map[string]*userLimiter
+	lastSweep time.Time
+}
+
+type userLimiter struct {
+	tokens   [numBudgets]float64
+	updated  [numBudgets]time.Time
+	active   [numBudgets]int
+	lastSeen time.Time
+}
+
+// acquire returns true if the user can make a request using the budget. If it
+// returns false, it also returns how long the user should wait before trying
+// again. If it returns true, release must be called when the request is
+// complete.
+func (l *rateLimiter) acquire(key string, budget int, now time.Time) (time.Duration, bool) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	l.sweep(now)
+	limit := l.limits.budget(budget)
+	user := l.users[key]
+	if user == nil {
+		user = &userLimiter{}
+		for b := range user.tokens {
+			user.tokens[b] = l.limits.budget(b).burst()
+			user.updated[b] = now
+		}
+		l.users[key] = user
+	}
+	user.lastSeen = now
+	if limit.Concurrent > 0 && user.active[budget] >= limit.Concurrent {
+		return time.Second, false
+	}
+	if limit.Rate > 0 {
+		elapsed := now.Sub(user.updated[budget]).Seconds()
+		user.tokens[budget] = min(user.tokens[budget]+elapsed*limit.Rate, limit.burst())
+		user.updated[budget] = now
+		if user.tokens[budget] < 1 {
+			wait := (1 - user.tokens[budget]) / limit.Rate
+			return time.Duration(wait * float64(time.Second)), false
+		}
+		user.tokens[budget]--
+	}
+	user.active[budget]++
+	return 0, true
+}
+
+func (l *rateLimiter) release(key string, budget int) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if user := l.users[key]; user != nil && user.active[budget] > 0 {
+		user.active[budget]--
+	}
+}
+
+// sweep removes state for idle users. The caller must hold the lock.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitIdle {
+		return
+	}
+	l.lastSweep = now
+	for key, user := range l.users {
+		if now.Sub(user.lastSeen) < rateLimitIdle {
+			continue
+		}
+		if user.active == [numBudgets]int{} {
+			delete(l.users, key)
+		}
+	}
+}
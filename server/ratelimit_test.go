@@ -0,0 +1,67 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/carlmjohnson/be"
+	"github.com/srerickson/chaparral"
+	"github.com/srerickson/chaparral/internal/testutil"
+	"github.com/srerickson/chaparral/server"
+	"github.com/srerickson/chaparral/server/uploader"
+	"github.com/srerickson/ocfl-go"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStoreTempDir(t)
+	mgr := uploader.NewManager(store.FS(), "uploads", testutil.TestDB(t))
+	limits := server.RateLimits{
+		Requests: server.RateLimit{Rate: 0.001, Burst: 3},
+		Uploads:  server.RateLimit{Rate: 0.001, Burst: 1},
+	}
+	mux := server.New(
+		server.WithStorageRoots(store),
+		server.WithUploaderManager(mgr),
+		server.WithAuthorizer(testutil.AuthorizeDefaults),
+		server.WithAuthUserFunc(testutil.AuthUserFunc()),
+		server.WithMiddleware(server.RateLimitMiddleware(limits)))
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	htc := srv.Client()
+	cli := chaparral.NewClient(htc, srv.URL)
+	testutil.SetUserToken(htc, testutil.ManagerUser)
+
+	up, err := cli.NewUploader(ctx, []string{ocfl.SHA256}, "rate limit test")
+	be.NilErr(t, err)
+	upload := func() *http.Response {
+		req, err := http.NewRequest(http.MethodPost, srv.URL+up.UploadPath, strings.NewReader("content"))
+		be.NilErr(t, err)
+		resp, err := htc.Do(req)
+		be.NilErr(t, err)
+		resp.Body.Close()
+		return resp
+	}
+	// uploads have a separate budget
+	be.Equal(t, http.StatusOK, upload().StatusCode)
+	resp := upload()
+	be.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	be.Nonzero(t, resp.Header.Get("Retry-After"))
+
+	// NewUploader used one of the three requests
+	_, err = cli.GetUploader(ctx, up.ID)
+	be.NilErr(t, err)
+	_, err = cli.GetUploader(ctx, up.ID)
+	be.NilErr(t, err)
+	_, err = cli.GetUploader(ctx, up.ID)
+	isConnectErrCode(t, err, connect.CodeResourceExhausted)
+
+	// other users have their own budgets
+	testutil.SetUserToken(htc, testutil.AdminUser)
+	_, err = cli.GetUploader(ctx, up.ID)
+	be.NilErr(t, err)
+}
@@ -1,13 +1,17 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/srerickson/chaparral/server/audit"
+	"github.com/srerickson/chaparral/server/eventlog"
 	"github.com/srerickson/chaparral/server/store"
 	"github.com/srerickson/chaparral/server/uploader"
+	"github.com/srerickson/chaparral/server/webhook"
 )
 
 // chaparral represents complete chaparral server state.
@@ -15,6 +19,12 @@ type chaparral struct {
 	roots     map[string]*store.StorageRoot
 	auth      Authorizer
 	uploadMgr *uploader.Manager
+	auditor   *audit.Auditor
+	notifier  *webhook.Notifier
+	events    *eventlog.Log
+
+	// key for signing download URLs
+	downloadKey []byte
 }
 
 type config struct {
@@ -43,6 +53,7 @@ func New(opts ...Option) *chi.Mux {
 	}
 	mux.Mount(cfg.chaparral.CommitServiceHandler())
 	mux.Mount(cfg.chaparral.AccessServiceHandler())
+	mux.Mount(cfg.chaparral.AdminServiceHandler())
 	return mux
 }
 
@@ -64,6 +75,38 @@ func WithUploaderManager(mgr *uploader.Manager) Option {
 	}
 }
 
+// WithAuditor sets the Auditor used to access fixity audit results.
+func WithAuditor(auditor *audit.Auditor) Option {
+	return func(c *config) {
+		c.chaparral.auditor = auditor
+	}
+}
+
+// WithNotifier sets the Notifier used to send webhook events for changes to
+// objects and uploaders, and to access the webhook delivery log.
+func WithNotifier(notifier *webhook.Notifier) Option {
+	return func(c *config) {
+		c.chaparral.notifier = notifier
+	}
+}
+
+// WithEventLog sets the Log used to record changes to objects and uploaders.
+// The log is required for the WatchEvents RPC.
+func WithEventLog(log *eventlog.Log) Option {
+	return func(c *config) {
+		c.chaparral.events = log
+	}
+}
+
+// WithDownloadKey sets the secret key used to sign and verify download URLs
+// created with the CreateDownloadURL RPC. If the key isn't set, signed
+// download URLs are not available.
+func WithDownloadKey(key []byte) Option {
+	return func(c *config) {
+		c.chaparral.downloadKey = key
+	}
+}
+
 // WithAuthorizer sets the Authorizer used to determine if user are authorize
 // user actions on resources.
 func WithAuthorizer(auth Authorizer) Option {
@@ -101,6 +144,10 @@ func (c *chaparral) CommitServiceHandler() (string, http.Handler) {
 	return (&CommitService{chaparral: c}).Handler()
 }
 
+func (c *chaparral) AdminServiceHandler() (string, http.Handler) {
+	return (&AdminService{chaparral: c}).Handler()
+}
+
 // close any resource created with New().
 func (c *chaparral) Close() error {
 	return nil
@@ -112,3 +159,22 @@ func (c *chaparral) storageRoot(id string) (*store.StorageRoot, error) {
 	}
 	return nil, fmt.Errorf("unknown storage root: %q", id)
 }
+
+// notify records an event for a completed change in the event log and sends
+// it to webhook endpoints, if the server has either. The event's user is the
+// user making the request. Errors are logged: they don't affect the change.
+func (c *chaparral) notify(ctx context.Context, event eventlog.Event) {
+	logger := LoggerFromCtx(ctx)
+	ctx = context.WithoutCancel(ctx)
+	event.UserID = AuthUserFromCtx(ctx).ID
+	if c.events != nil {
+		if err := c.events.Append(ctx, &event); err != nil {
+			logger.Error("recording event", "event_type", event.Type, "err", err.Error())
+		}
+	}
+	if c.notifier != nil {
+		if err := c.notifier.Notify(ctx, event); err != nil {
+			logger.Error("sending webhook event", "event_type", event.Type, "err", err.Error())
+		}
+	}
+}
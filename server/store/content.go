@@ -0,0 +1,208 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+
+	"github.com/srerickson/chaparral"
+	"github.com/srerickson/chaparral/internal/pipeline"
+	ocfl "github.com/srerickson/ocfl-go"
+)
+
+const (
+	// findContentPageSize is the number of cached objects read at a time by
+	// FindContent.
+	findContentPageSize = 1000
+
+	// unknownSize is the size of cached content that hasn't been read.
+	unknownSize = -1
+)
+
+// FindContent returns the objects, versions, and logical paths in the storage
+// root that include content with the digest, sorted by object id. Candidate
+// objects are found using the storage root's cache; each object's inventory is
+// read to find logical paths. Objects that no longer exist in storage are
+// skipped.
+func (store *StorageRoot) FindContent(ctx context.Context, digest string) ([]chaparral.ContentLocation, error) {
+	if err := store.Ready(ctx); err != nil {
+		return nil, err
+	}
+	var locs []chaparral.ContentLocation
+	for offset := 0; ; offset += findContentPageSize {
+		items, err := store.cache.SearchObjects(ctx, store.id, "", digest, findContentPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			loc, err := store.findObjectContent(ctx, item.ID, digest)
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return nil, fmt.Errorf("reading object %q: %w", item.ID, err)
+			}
+			if loc != nil {
+				locs = append(locs, *loc)
+			}
+		}
+		if len(items) < findContentPageSize {
+			break
+		}
+	}
+	return locs, nil
+}
+
+// findObjectContent returns the location of content with the digest in the
+// object. It returns nil if the object's manifest doesn't include the digest.
+func (store *StorageRoot) findObjectContent(ctx context.Context, objectID string, digest string) (*chaparral.ContentLocation, error) {
+	unlock, err := store.locker.ReadLock(objectID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	obj, err := store.base.GetObject(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	contentPaths := obj.Inventory.Manifest[digest]
+	if len(contentPaths) == 0 {
+		return nil, nil
+	}
+	contentPaths = slices.Clone(contentPaths)
+	sort.Strings(contentPaths)
+	loc := &chaparral.ContentLocation{
+		ObjectRef: chaparral.ObjectRef{
+			StorageRootID: store.id,
+			ID:            obj.Inventory.ID,
+		},
+		ContentPaths: contentPaths,
+	}
+	for num := 1; num <= obj.Inventory.Head.Num(); num++ {
+		version := obj.Inventory.Version(num)
+		if version == nil {
+			continue
+		}
+		paths := version.State[digest]
+		if len(paths) == 0 {
+			continue
+		}
+		paths = slices.Clone(paths)
+		sort.Strings(paths)
+		loc.Versions = append(loc.Versions, chaparral.VersionPaths{
+			Version: num,
+			Paths:   paths,
+		})
+	}
+	return loc, nil
+}
+
+// DedupReport returns a report of content stored in more than one object in
+// the storage root. Duplicates are found using the storage root's cache. The
+// size of each duplicate is read from storage. The report's items are sorted
+// by duplicate bytes (largest first) and include at most limit entries;
+// totals include all duplicates.
+func (store *StorageRoot) DedupReport(ctx context.Context, limit int) (*chaparral.DedupReport, error) {
+	if err := store.Ready(ctx); err != nil {
+		return nil, err
+	}
+	dups, err := store.cache.GetDuplicateContents(ctx, store.id)
+	if err != nil {
+		return nil, err
+	}
+	report := &chaparral.DedupReport{DuplicateDigests: len(dups)}
+	for i := range dups {
+		size, err := store.contentSize(ctx, dups[i].ObjectIDs[0], dups[i].Digest)
+		if err != nil {
+			return nil, fmt.Errorf("getting size of %q: %w", dups[i].Digest, err)
+		}
+		dups[i].Size = size
+		report.DuplicateBytes += dups[i].DuplicateBytes()
+	}
+	sort.SliceStable(dups, func(i, j int) bool {
+		return dups[i].DuplicateBytes() > dups[j].DuplicateBytes()
+	})
+	if limit < len(dups) {
+		dups = dups[:limit]
+	}
+	report.Items = dups
+	return report, nil
+}
+
+// contentSize returns the size of the content with the digest in the cached
+// object.
+func (store *StorageRoot) contentSize(ctx context.Context, objectID string, digest string) (int64, error) {
+	man, err := store.cache.GetObjectManifest(ctx, store.id, objectID)
+	if err != nil {
+		return 0, err
+	}
+	return store.manifestContentSize(ctx, man, digest)
+}
+
+// manifestContentSize returns the size of the content with the digest in the
+// object manifest. If the size isn't known, it is read from storage.
+func (store *StorageRoot) manifestContentSize(ctx context.Context, man *chaparral.ObjectManifest, digest string) (int64, error) {
+	info, exists := man.Manifest[digest]
+	if exists && info.Size != unknownSize {
+		return info.Size, nil
+	}
+	if len(info.Paths) == 0 {
+		return 0, fmt.Errorf("object %q: content not found: %w", man.ID, fs.ErrNotExist)
+	}
+	return store.statContent(ctx, man.Path, info.Paths[0])
+}
+
+// manifestSizes returns the sizes of all content in the object manifest,
+// keyed by digest. Sizes that aren't known are read from storage; content
+// files are statted concurrently.
+func (store *StorageRoot) manifestSizes(ctx context.Context, man *chaparral.ObjectManifest) (map[string]int64, error) {
+	sizes := make(map[string]int64, len(man.Manifest))
+	var unknown []string
+	for digest, info := range man.Manifest {
+		if info.Size == unknownSize {
+			unknown = append(unknown, digest)
+			continue
+		}
+		sizes[digest] = info.Size
+	}
+	setup := func(add func(string) bool) error {
+		for _, digest := range unknown {
+			if !add(digest) {
+				break
+			}
+		}
+		return nil
+	}
+	work := func(digest string) (int64, error) {
+		return store.manifestContentSize(ctx, man, digest)
+	}
+	result := func(digest string, size int64, err error) error {
+		if err != nil {
+			return err
+		}
+		sizes[digest] = size
+		return nil
+	}
+	if err := pipeline.Run(setup, work, result, ocfl.XferConcurrency()); err != nil {
+		return nil, err
+	}
+	return sizes, nil
+}
+
+// statContent returns the size of the content file in the object.
+func (store *StorageRoot) statContent(ctx context.Context, objPath string, contentPath string) (int64, error) {
+	f, err := store.fs.OpenFile(ctx, path.Join(objPath, contentPath))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return stat.Size(), nil
+}
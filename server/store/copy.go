@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/srerickson/chaparral/internal/pipeline"
+	ocfl "github.com/srerickson/ocfl-go"
+	"github.com/srerickson/ocfl-go/ocflv1"
+)
+
+var (
+	ErrObjectExists = errors.New("object already exists in the storage root")
+	ErrSameRoot     = errors.New("source and destination storage roots are the same")
+)
+
+// CopyObject copies the object with the given id from src to the storage root.
+// All of the object's versions are copied. The copy is fully validated before
+// it is added to the storage root's cache. If validation fails, the copy is
+// removed. An error is returned if the object already exists in the storage
+// root or if the object exceeds the storage root's limits (MaxCommitBytes
+// doesn't apply to copies). The object's version tags are copied if both
+// storage roots have version tags enabled.
+func (store *StorageRoot) CopyObject(ctx context.Context, src *StorageRoot, objectID string) error {
+	if err := src.Ready(ctx); err != nil {
+		return err
+	}
+	unlock, err := src.locker.ReadLock(objectID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return store.copyObject(ctx, src, objectID)
+}
+
+// MoveObject is like CopyObject except the object is deleted from src after
+// it is successfully copied.
+func (store *StorageRoot) MoveObject(ctx context.Context, src *StorageRoot, objectID string) error {
+	if err := src.Ready(ctx); err != nil {
+		return err
+	}
+	// exclusive lock on the source prevents commits while the
+	// object is being copied.
+	unlock, err := src.locker.WriteLock(objectID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := store.copyObject(ctx, src, objectID); err != nil {
+		return err
+	}
+	if err := src.deleteObject(ctx, objectID); err != nil {
+		return fmt.Errorf("deleting source object after copy: %w", err)
+	}
+	return nil
+}
+
+// copyObject does the work for CopyObject and MoveObject. The caller must hold
+// a lock on the source object.
+func (store *StorageRoot) copyObject(ctx context.Context, src *StorageRoot, objectID string) error {
+	if store == src {
+		return ErrSameRoot
+	}
+	if err := store.Ready(ctx); err != nil {
+		return err
+	}
+	unlock, err := store.locker.WriteLock(objectID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	srcObj, err := src.base.GetObject(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	if objSpec := srcObj.Inventory.Type.Spec; objSpec.Cmp(store.Spec()) > 0 {
+		return fmt.Errorf("object's OCFL version (%s) is higher than the storage root's (%s)", objSpec, store.Spec())
+	}
+	exists, err := store.base.ObjectExists(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrObjectExists
+	}
+	sizes, err := store.checkCopyLimits(ctx, src, srcObj)
+	if err != nil {
+		return err
+	}
+	dstPath, err := store.base.ResolveID(objectID)
+	if err != nil {
+		return err
+	}
+	dstPath = path.Join(store.path, dstPath)
+	if err := copyDir(ctx, store.fs, dstPath, src.fs, srcObj.Path); err != nil {
+		err = fmt.Errorf("copying object files: %w", err)
+		return errors.Join(err, store.fs.RemoveAll(ctx, dstPath))
+	}
+	if _, result := ocflv1.ValidateObject(ctx, store.fs, dstPath); result.Err() != nil {
+		err = fmt.Errorf("copied object is invalid: %w", result.Err())
+		return errors.Join(err, store.fs.RemoveAll(ctx, dstPath))
+	}
+	if err := store.syncObject(ctx, objectID, sizes); err != nil {
+		return fmt.Errorf("while syncing object, post-copy: %w", err)
+	}
+	if err := store.copyTags(ctx, src, objectID, dstPath); err != nil {
+		return fmt.Errorf("copying version tags: %w", err)
+	}
+	return nil
+}
+
+// copyTags copies the object's version tags from src to the storage root. It
+// does nothing if either storage root doesn't have version tags enabled.
+func (store *StorageRoot) copyTags(ctx context.Context, src *StorageRoot, objectID string, objPath string) error {
+	if store.tags == nil || src.tags == nil {
+		return nil
+	}
+	tags, err := src.tags.ListVersionTags(ctx, src.id, objectID)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		tag.StorageRootID = store.id
+		if err := store.tags.SetVersionTag(ctx, &tag); err != nil {
+			return err
+		}
+	}
+	return store.mirrorObjectTags(ctx, objectID, objPath)
+}
+
+// copyDir copies all files in srcDir to dstDir
+func copyDir(ctx context.Context, dstFS ocfl.WriteFS, dstDir string, srcFS ocfl.FS, srcDir string) error {
+	setup := func(add func(string) bool) error {
+		return ocfl.Files(ctx, srcFS, ocfl.Dir(srcDir), func(name string) error {
+			if !add(name) {
+				return errors.New("copy interrupted")
+			}
+			return nil
+		})
+	}
+	work := func(name string) (struct{}, error) {
+		dst := path.Join(dstDir, strings.TrimPrefix(name, srcDir+"/"))
+		return struct{}{}, ocfl.Copy(ctx, dstFS, dst, srcFS, name)
+	}
+	result := func(name string, _ struct{}, err error) error {
+		if err != nil {
+			return fmt.Errorf("copying %q: %w", name, err)
+		}
+		return nil
+	}
+	return pipeline.Run(setup, work, result, ocfl.XferConcurrency())
+}
@@ -0,0 +1,188 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	"github.com/srerickson/chaparral"
+	ocfl "github.com/srerickson/ocfl-go"
+	"github.com/srerickson/ocfl-go/ocflv1"
+)
+
+var (
+	ErrLimitExceeded      = errors.New("commit exceeds the storage root's limits")
+	ErrDisallowedDigest   = errors.New("digest algorithm is not allowed in the storage root")
+	errUnknownContentSize = errors.New("can't determine the size of new content")
+)
+
+// Limits are policies for commits to a storage root. Zero values are
+// unlimited.
+type Limits struct {
+	// maximum size of new content added to an object in a commit
+	MaxCommitBytes int64
+	// maximum number of files in an object version
+	MaxVersionFiles int
+	// maximum size of all content in an object
+	MaxObjectBytes int64
+	// digest algorithms allowed for commits. If empty, any algorithm is
+	// allowed.
+	DigestAlgorithms []string
+}
+
+// WithLimits sets limits that are enforced for commits to the storage root.
+func WithLimits(limits Limits) Option {
+	return func(store *StorageRoot) {
+		store.limits = limits
+		store.limits.DigestAlgorithms = slices.Clone(limits.DigestAlgorithms)
+	}
+}
+
+// Limits returns the storage root's commit limits.
+func (store *StorageRoot) Limits() Limits {
+	limits := store.limits
+	limits.DigestAlgorithms = slices.Clone(limits.DigestAlgorithms)
+	return limits
+}
+
+// AllowsAlg returns true if commits to the storage root may use the digest
+// algorithm.
+func (l Limits) AllowsAlg(alg string) bool {
+	return len(l.DigestAlgorithms) == 0 || slices.Contains(l.DigestAlgorithms, alg)
+}
+
+// checkLimits returns an error if committing the stage to the object would
+// exceed the storage root's limits. Sizes of new content are taken from sizes
+// or, if they aren't there, read from the stage's content source. If
+// MaxObjectBytes is set, sizes of existing content that aren't cached are read
+// from storage. Sizes that are read are added to sizes. The caller should hold
+// the object's write lock.
+func (store *StorageRoot) checkLimits(ctx context.Context, objectID string, stage *ocfl.Stage, sizes map[string]int64) error {
+	limits := store.limits
+	if !limits.AllowsAlg(stage.DigestAlgorithm) {
+		return fmt.Errorf("%w: %q", ErrDisallowedDigest, stage.DigestAlgorithm)
+	}
+	if limits.MaxVersionFiles > 0 && stage.State.NumPaths() > limits.MaxVersionFiles {
+		return fmt.Errorf("%w: the new version has %d files; the maximum is %d",
+			ErrLimitExceeded, stage.State.NumPaths(), limits.MaxVersionFiles)
+	}
+	if limits.MaxCommitBytes < 1 && limits.MaxObjectBytes < 1 {
+		return nil
+	}
+	man, err := store.getObjectManifest(ctx, objectID)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		// new object
+		man = &chaparral.ObjectManifest{}
+	}
+	var newBytes int64
+	for _, digest := range stage.State.Digests() {
+		if _, exists := man.Manifest[digest]; exists {
+			continue
+		}
+		size, known := sizes[digest]
+		if !known {
+			size, err = stageContentSize(ctx, stage, digest)
+			if err != nil {
+				return err
+			}
+			sizes[digest] = size
+		}
+		newBytes += size
+	}
+	if limits.MaxCommitBytes > 0 && newBytes > limits.MaxCommitBytes {
+		return fmt.Errorf("%w: the commit adds %d bytes of new content; the maximum is %d",
+			ErrLimitExceeded, newBytes, limits.MaxCommitBytes)
+	}
+	if limits.MaxObjectBytes > 0 {
+		objSizes, err := store.manifestSizes(ctx, man)
+		if err != nil {
+			return err
+		}
+		objBytes := newBytes
+		for digest, size := range objSizes {
+			objBytes += size
+			sizes[digest] = size
+		}
+		if objBytes > limits.MaxObjectBytes {
+			return fmt.Errorf("%w: the object would have %d bytes of content; the maximum is %d",
+				ErrLimitExceeded, objBytes, limits.MaxObjectBytes)
+		}
+	}
+	return nil
+}
+
+// checkCopyLimits returns an error if copying the object from src to the
+// storage root would exceed the storage root's limits: the object must use an
+// allowed digest algorithm, each of its versions must be within
+// MaxVersionFiles, and all of its content must be within MaxObjectBytes. It
+// returns the sizes of the object's content from src's cache, for the copy's
+// cache entry; if MaxObjectBytes is set, sizes that aren't cached are read from
+// storage. The caller should hold a lock on the source object.
+func (store *StorageRoot) checkCopyLimits(ctx context.Context, src *StorageRoot, obj *ocflv1.Object) (map[string]int64, error) {
+	limits := store.limits
+	inv := obj.Inventory
+	if !limits.AllowsAlg(inv.DigestAlgorithm) {
+		return nil, fmt.Errorf("%w: %q", ErrDisallowedDigest, inv.DigestAlgorithm)
+	}
+	if limits.MaxVersionFiles > 0 {
+		for num, ver := range inv.Versions {
+			if files := ver.State.NumPaths(); files > limits.MaxVersionFiles {
+				return nil, fmt.Errorf("%w: version %s has %d files; the maximum is %d",
+					ErrLimitExceeded, num, files, limits.MaxVersionFiles)
+			}
+		}
+	}
+	man, err := src.getObjectManifest(ctx, inv.ID)
+	if err != nil {
+		return nil, err
+	}
+	if limits.MaxObjectBytes < 1 {
+		sizes := map[string]int64{}
+		for digest, info := range man.Manifest {
+			if info.Size != unknownSize {
+				sizes[digest] = info.Size
+			}
+		}
+		return sizes, nil
+	}
+	sizes, err := src.manifestSizes(ctx, man)
+	if err != nil {
+		return nil, err
+	}
+	var objBytes int64
+	for _, size := range sizes {
+		objBytes += size
+	}
+	if objBytes > limits.MaxObjectBytes {
+		return nil, fmt.Errorf("%w: the object has %d bytes of content; the maximum is %d",
+			ErrLimitExceeded, objBytes, limits.MaxObjectBytes)
+	}
+	return sizes, nil
+}
+
+// stageContentSize returns the size of the content with the digest from the
+// stage's content source.
+func stageContentSize(ctx context.Context, stage *ocfl.Stage, digest string) (int64, error) {
+	if stage.ContentSource == nil {
+		return 0, fmt.Errorf("%w: no content source for %q", errUnknownContentSize, digest)
+	}
+	fsys, name := stage.GetContent(digest)
+	if fsys == nil {
+		return 0, fmt.Errorf("%w: no content for %q", errUnknownContentSize, digest)
+	}
+	f, err := fsys.OpenFile(ctx, name)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", errUnknownContentSize, err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", errUnknownContentSize, err)
+	}
+	return info.Size(), nil
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"path"
+
+	"github.com/srerickson/chaparral"
+	"github.com/srerickson/ocfl-go/ocflv1"
+)
+
+// metadataMax is the maximum size of an object metadata file. Larger files
+// are ignored.
+const metadataMax = 1024 * 1024
+
+// readMetadata returns the metadata from the object's head version. It
+// returns nil if the version doesn't include a metadata file or if the file
+// isn't valid.
+func (store *StorageRoot) readMetadata(ctx context.Context, obj *ocflv1.Object) (*chaparral.ObjectMetadata, error) {
+	head := obj.Inventory.Version(0)
+	if head == nil {
+		return nil, nil
+	}
+	digest := head.State.GetDigest(chaparral.MetadataPath)
+	if digest == "" {
+		return nil, nil
+	}
+	contentPaths := obj.Inventory.Manifest[digest]
+	if len(contentPaths) < 1 {
+		return nil, nil
+	}
+	f, err := store.fs.OpenFile(ctx, path.Join(obj.Path, contentPaths[0]))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	cont, err := io.ReadAll(io.LimitReader(f, metadataMax+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(cont) > metadataMax {
+		return nil, nil
+	}
+	meta := &chaparral.ObjectMetadata{}
+	if err := json.Unmarshal(cont, meta); err != nil {
+		return nil, nil
+	}
+	return meta, nil
+}
+
+// ListObjects returns objects in the storage root's cache, sorted by id.
+// Objects created outside the server are only included after the storage
+// root is reindexed.
+func (store *StorageRoot) ListObjects(ctx context.Context, limit int, offset int) ([]chaparral.ObjectListItem, error) {
+	return store.cache.ListObjects(ctx, store.id, limit, offset)
+}
+
+// SearchObjects returns objects in the storage root's cache that match query
+// and digest, sorted by id. The query is matched against terms in the
+// objects' metadata and logical paths. If digest is not empty, only objects
+// with the content digest are included.
+func (store *StorageRoot) SearchObjects(ctx context.Context, query string, digest string, limit int, offset int) ([]chaparral.ObjectListItem, error) {
+	return store.cache.SearchObjects(ctx, store.id, query, digest, limit, offset)
+}
@@ -0,0 +1,152 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/srerickson/chaparral/internal/pipeline"
+	ocfl "github.com/srerickson/ocfl-go"
+	"github.com/srerickson/ocfl-go/ocflv1"
+)
+
+// ReindexStats are running totals for a reindex
+type ReindexStats struct {
+	Indexed int // objects saved to the cache
+	Failed  int // objects that couldn't be read
+	Skipped int // objects that weren't updated because they were in use
+	Removed int // cache entries removed for objects no longer in storage
+}
+
+// reindexResult is the result of reindexing a single object.
+type reindexResult struct {
+	id      string // the object's id, if its inventory could be read
+	skipped bool   // the object was in use and wasn't updated
+}
+
+// ReindexProgressFunc is called during a reindex after each object is
+// processed, with the object's path, any error that occurred reading it, and
+// the running totals.
+type ReindexProgressFunc func(objPath string, err error, stats ReindexStats)
+
+// Reindex rebuilds the storage root's object cache from the objects in
+// storage. Object roots are read concurrently and each object's manifest is
+// saved to the cache. Objects that can't be read are counted as failed but
+// don't stop the reindex. Once all objects have been read, cache entries for
+// objects that are no longer in storage are removed. If progress is not nil,
+// it is called after each object.
+func (store *StorageRoot) Reindex(ctx context.Context, progress ReindexProgressFunc) (ReindexStats, error) {
+	var stats ReindexStats
+	if err := store.Ready(ctx); err != nil {
+		return stats, err
+	}
+	// cached ids from before the reindex: objects created during the
+	// reindex shouldn't be treated as stale.
+	cachedIDs, err := store.cache.GetObjectIDs(ctx, store.id)
+	if err != nil {
+		return stats, fmt.Errorf("listing cached objects: %w", err)
+	}
+	foundIDs := map[string]struct{}{}
+	setup := func(add func(*ocfl.ObjectRoot) bool) error {
+		sel := ocfl.PathSelector{
+			Dir: store.path,
+			SkipDirFn: func(name string) bool {
+				return name == path.Join(store.path, "extensions")
+			},
+		}
+		return ocfl.ObjectRoots(ctx, store.fs, sel, func(objRoot *ocfl.ObjectRoot) error {
+			if !add(objRoot) {
+				return errors.New("reindex interrupted")
+			}
+			return nil
+		})
+	}
+	work := func(objRoot *ocfl.ObjectRoot) (reindexResult, error) {
+		return store.reindexObject(ctx, objRoot)
+	}
+	result := func(objRoot *ocfl.ObjectRoot, res reindexResult, err error) error {
+		if res.id != "" {
+			foundIDs[res.id] = struct{}{}
+		}
+		switch {
+		case err != nil:
+			stats.Failed++
+		case res.skipped:
+			stats.Skipped++
+		case res.id != "":
+			stats.Indexed++
+		}
+		if progress != nil {
+			progress(objRoot.Path, err, stats)
+		}
+		return ctx.Err()
+	}
+	if err := pipeline.Run(setup, work, result, 0); err != nil {
+		return stats, err
+	}
+	for _, id := range cachedIDs {
+		if _, found := foundIDs[id]; found {
+			continue
+		}
+		removed, err := store.removeStale(ctx, id)
+		if err != nil {
+			return stats, fmt.Errorf("removing stale cache entry for %q: %w", id, err)
+		}
+		if removed {
+			stats.Removed++
+		}
+	}
+	return stats, nil
+}
+
+// reindexObject reads the inventory for the object at objRoot and saves
+// the object's manifest to the cache. If the object is locked for a commit or
+// delete, it isn't updated (the commit will update the cache) and the result
+// is marked as skipped.
+func (store *StorageRoot) reindexObject(ctx context.Context, objRoot *ocfl.ObjectRoot) (reindexResult, error) {
+	obj := &ocflv1.Object{ObjectRoot: *objRoot}
+	if err := obj.SyncInventory(ctx); err != nil {
+		return reindexResult{}, err
+	}
+	res := reindexResult{id: obj.Inventory.ID}
+	unlock, err := store.locker.ReadLock(res.id)
+	if err != nil {
+		res.skipped = true
+		return res, nil
+	}
+	defer unlock()
+	// re-read the inventory in case of a commit before the lock was acquired.
+	if err := obj.SyncInventory(ctx); err != nil {
+		return res, err
+	}
+	man, err := store.objectManifest(ctx, obj, nil)
+	if err != nil {
+		return res, err
+	}
+	if err := store.cache.SetObjectManifest(ctx, man); err != nil {
+		return res, fmt.Errorf("saving to storage root cache: %w", err)
+	}
+	store.setChecked(res.id)
+	return res, nil
+}
+
+// removeStale removes the cache entry for id if the object doesn't exist. The
+// entry is kept if the object is in use or if its existence can't be
+// confirmed.
+func (store *StorageRoot) removeStale(ctx context.Context, id string) (bool, error) {
+	unlock, err := store.locker.WriteLock(id)
+	if err != nil {
+		// the object is in use
+		return false, nil
+	}
+	defer unlock()
+	exists, err := store.base.ObjectExists(ctx, id)
+	if err != nil || exists {
+		return false, nil
+	}
+	if err := store.cache.DeleteObject(ctx, store.id, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
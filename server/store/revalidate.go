@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/srerickson/chaparral"
+	ocfl "github.com/srerickson/ocfl-go"
+)
+
+// inventorySidecarMax is the maximum number of bytes read from an inventory
+// sidecar file.
+const inventorySidecarMax = 1024
+
+// Option is used to configure a StorageRoot
+type Option func(*StorageRoot)
+
+// WithRevalidation enables revalidation of cached object manifests. If an
+// object's cached manifest hasn't been checked within maxAge, the object's
+// inventory sidecar is read from storage and compared to the inventory digest
+// in the cache. If the digests differ, the object is synced from storage. If
+// maxAge is zero, the sidecar is read every time the object is accessed.
+// Revalidation is necessary if objects in the storage root may be modified
+// outside of the StorageRoot (e.g., by another server instance).
+func WithRevalidation(maxAge time.Duration) Option {
+	return func(store *StorageRoot) {
+		store.revalidate = true
+		store.maxAge = maxAge
+	}
+}
+
+// isCurrent returns true if the cached manifest man can be used without
+// resyncing the object.
+func (store *StorageRoot) isCurrent(ctx context.Context, man *chaparral.ObjectManifest) bool {
+	if !store.revalidate {
+		return true
+	}
+	store.checkedMx.Lock()
+	checked, ok := store.checked[man.ID]
+	store.checkedMx.Unlock()
+	if ok && store.maxAge > 0 && time.Since(checked) < store.maxAge {
+		return true
+	}
+	digest, err := readInventorySidecar(ctx, store.fs, man.Path, man.DigestAlgorithm)
+	if err != nil || !strings.EqualFold(digest, man.InventoryDigest) {
+		return false
+	}
+	store.setChecked(man.ID)
+	return true
+}
+
+func (store *StorageRoot) setChecked(objectID string) {
+	if !store.revalidate {
+		return
+	}
+	store.checkedMx.Lock()
+	defer store.checkedMx.Unlock()
+	store.checked[objectID] = time.Now()
+}
+
+func (store *StorageRoot) clearChecked(objectID string) {
+	store.checkedMx.Lock()
+	defer store.checkedMx.Unlock()
+	delete(store.checked, objectID)
+}
+
+// readInventorySidecar returns the digest value from the root inventory
+// sidecar for the object at objPath
+func readInventorySidecar(ctx context.Context, fsys ocfl.FS, objPath string, alg string) (string, error) {
+	f, err := fsys.OpenFile(ctx, path.Join(objPath, "inventory.json."+alg))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	cont, err := io.ReadAll(io.LimitReader(f, inventorySidecarMax))
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(cont))
+	if len(fields) != 2 || fields[1] != "inventory.json" {
+		return "", errors.New("invalid inventory sidecar contents")
+	}
+	return fields[0], nil
+}
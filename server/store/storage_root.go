@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
 	"path"
 	"slices"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/srerickson/chaparral"
 	"github.com/srerickson/chaparral/server/internal/lock"
@@ -35,13 +38,32 @@ type StorageRoot struct {
 
 	cache ObjectCache
 
+	// commit policies
+	limits Limits
+
+	// version tags
+	tags       TagPersistence
+	mirrorTags bool
+
 	syncing   map[string]chan struct{}
 	syncingMx sync.Mutex
+
+	// cache revalidation
+	revalidate bool
+	maxAge     time.Duration
+	checked    map[string]time.Time
+	checkedMx  sync.Mutex
 }
 
 type ObjectCache interface {
 	SetObjectManifest(ctx context.Context, m *chaparral.ObjectManifest) error
 	GetObjectManifest(ctx context.Context, storeID string, objID string) (*chaparral.ObjectManifest, error)
+	GetObjectManifestPage(ctx context.Context, storeID string, objID string, prefix string, after string, limit int) (*chaparral.ObjectManifest, error)
+	ListVersionPaths(ctx context.Context, storeID string, objID string, version int, prefix string, after string, limit int) ([]chaparral.StateItem, error)
+	GetObjectIDs(ctx context.Context, storeID string) ([]string, error)
+	ListObjects(ctx context.Context, storeID string, limit int, offset int) ([]chaparral.ObjectListItem, error)
+	SearchObjects(ctx context.Context, storeID string, query string, digest string, limit int, offset int) ([]chaparral.ObjectListItem, error)
+	GetDuplicateContents(ctx context.Context, storeID string) ([]chaparral.DuplicateContent, error)
 	DeleteObject(ctx context.Context, storeID string, objID string) error
 }
 
@@ -52,16 +74,21 @@ type StorageRootInitializer struct {
 	//LayoutConfig map[string]any `json:"layout_config,omitempty"`
 }
 
-func NewStorageRoot(id string, fsys ocfl.WriteFS, path string, init *StorageRootInitializer, cache ObjectCache) *StorageRoot {
-	return &StorageRoot{
+func NewStorageRoot(id string, fsys ocfl.WriteFS, path string, init *StorageRootInitializer, cache ObjectCache, opts ...Option) *StorageRoot {
+	store := &StorageRoot{
 		id:      id,
 		fs:      fsys,
 		path:    path,
 		init:    init,
 		locker:  lock.NewLocker(),
 		syncing: map[string]chan struct{}{},
+		checked: map[string]time.Time{},
 		cache:   cache,
 	}
+	for _, opt := range opts {
+		opt(store)
+	}
+	return store
 }
 
 // FS returns the ocfl.WriteFS where the storage root is saved
@@ -90,6 +117,15 @@ func (store *StorageRoot) Spec() ocfl.Spec {
 	return store.base.Spec()
 }
 
+// LayoutName returns the name of the storage root's layout extension. It may
+// be empty.
+func (store *StorageRoot) LayoutName() string {
+	if store.base == nil {
+		return ""
+	}
+	return store.base.LayoutName()
+}
+
 func (store *StorageRoot) ResolveID(id string) (string, error) {
 	if store.base == nil {
 		return "", errors.New("storage root not initialized")
@@ -163,7 +199,7 @@ func (store *StorageRoot) GetObjectVersion(ctx context.Context, objectID string,
 	version := obj.Inventory.Version(verIndex)
 	if version == nil {
 		unlock()
-		return nil, fmt.Errorf("version index %d not found", verIndex)
+		return nil, fmt.Errorf("version index %d not found: %w", verIndex, fs.ErrNotExist)
 	}
 	objVersion := ObjectVersion{
 		ObjectVersion: chaparral.ObjectVersion{
@@ -240,18 +276,117 @@ func (store *StorageRoot) GetObjectManifest(ctx context.Context, objectID string
 	}, nil
 }
 
+// GetObjectManifestPage returns the object's manifest with up to limit entries
+// for digests that sort after the given digest. If prefix isn't empty, only
+// digests with a logical path that has the prefix, in any version, are
+// included. It is used to list the contents of large objects without reading
+// the full manifest.
+func (store *StorageRoot) GetObjectManifestPage(ctx context.Context, objectID string, prefix string, after string, limit int) (*chaparral.ObjectManifest, error) {
+	if err := store.Ready(ctx); err != nil {
+		return nil, err
+	}
+	unlock, err := store.locker.ReadLock(objectID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return store.getCachedObject(ctx, objectID, func() (*chaparral.ObjectManifest, error) {
+		man, err := store.cache.GetObjectManifestPage(ctx, store.id, objectID, prefix, after, limit)
+		if err != nil {
+			return nil, err
+		}
+		if prefix != "" && man.Head < 1 {
+			// logical paths are read from cached version states
+			return nil, fmt.Errorf("version states for %q aren't cached: %w", objectID, fs.ErrNotExist)
+		}
+		return man, nil
+	})
+}
+
+// VersionStatePage is a page of logical paths from an object version's state.
+type VersionStatePage struct {
+	Version         int
+	DigestAlgorithm string
+	// Items are sorted by path. Sizes are from the cached manifest.
+	Items []chaparral.StateItem
+}
+
+// ListVersionState returns up to limit logical paths from the state of the
+// object version that have the prefix and sort after the given path. If
+// version is 0, the most recent version is used. Paths are read from the
+// cache, so large version states can be listed without reading the full state.
+func (store *StorageRoot) ListVersionState(ctx context.Context, objectID string, version int, prefix string, after string, limit int) (*VersionStatePage, error) {
+	if err := store.Ready(ctx); err != nil {
+		return nil, err
+	}
+	unlock, err := store.locker.ReadLock(objectID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	var page *VersionStatePage
+	_, err = store.getCachedObject(ctx, objectID, func() (*chaparral.ObjectManifest, error) {
+		// the manifest is only used for the head and digest algorithm
+		man, err := store.cache.GetObjectManifestPage(ctx, store.id, objectID, "", "", 0)
+		if err != nil {
+			return nil, err
+		}
+		if man.Head < 1 {
+			// cached before version states were
+			return nil, fmt.Errorf("version states for %q aren't cached: %w", objectID, fs.ErrNotExist)
+		}
+		page = &VersionStatePage{
+			Version:         version,
+			DigestAlgorithm: man.DigestAlgorithm,
+		}
+		if page.Version == 0 {
+			page.Version = man.Head
+		}
+		if page.Version > man.Head {
+			return nil, fmt.Errorf("version index %d not found: %w", version, fs.ErrNotExist)
+		}
+		page.Items, err = store.cache.ListVersionPaths(ctx, store.id, objectID, page.Version, prefix, after, limit)
+		if err != nil {
+			return nil, err
+		}
+		return man, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
 func (store *StorageRoot) getObjectManifest(ctx context.Context, objectID string) (*chaparral.ObjectManifest, error) {
-	man, err := store.cache.GetObjectManifest(ctx, store.id, objectID)
+	return store.getCachedObject(ctx, objectID, func() (*chaparral.ObjectManifest, error) {
+		return store.cache.GetObjectManifest(ctx, store.id, objectID)
+	})
+}
+
+// getCachedObject calls get to read the object from the cache, syncing the
+// cache with storage first if the cached object is missing or out of date.
+func (store *StorageRoot) getCachedObject(ctx context.Context, objectID string, get func() (*chaparral.ObjectManifest, error)) (*chaparral.ObjectManifest, error) {
+	man, err := get()
 	if err == nil {
-		return man, nil
+		if store.isCurrent(ctx, man) {
+			return man, nil
+		}
 	}
-	if err := store.syncObject(ctx, objectID); err != nil {
+	if err := store.syncObject(ctx, objectID, nil); err != nil {
+		if man != nil && errors.Is(err, fs.ErrNotExist) {
+			// object was removed from storage
+			if err := store.cache.DeleteObject(ctx, store.id, objectID); err != nil {
+				return nil, fmt.Errorf("clearing cache: %w", err)
+			}
+		}
 		return nil, err
 	}
-	return store.cache.GetObjectManifest(ctx, store.id, objectID)
+	return get()
 }
 
-func (store *StorageRoot) syncObject(ctx context.Context, objectID string) error {
+// syncObject saves the object's manifest to the cache. Content sizes are taken
+// from sizes, if they are there, or from the existing cache entry.
+func (store *StorageRoot) syncObject(ctx context.Context, objectID string, sizes map[string]int64) error {
 	done, syncing := store.getObjectSync(objectID)
 	if syncing {
 		<-done // wait for result from a current request
@@ -269,6 +404,26 @@ func (store *StorageRoot) syncObject(ctx context.Context, objectID string) error
 	if err != nil {
 		return err
 	}
+	man, err := store.objectManifest(ctx, obj, sizes)
+	if err != nil {
+		return err
+	}
+	if err := store.cache.SetObjectManifest(ctx, man); err != nil {
+		return fmt.Errorf("saving to storage root cache: %w", err)
+	}
+	store.setChecked(objectID)
+	return nil
+}
+
+// objectManifest returns the cache entry for obj. Content sizes are taken from
+// sizes or copied from the existing cache entry, if there is one. Content files
+// aren't read: sizes that aren't known are set to unknownSize.
+func (store *StorageRoot) objectManifest(ctx context.Context, obj *ocflv1.Object, sizes map[string]int64) (*chaparral.ObjectManifest, error) {
+	// content with the same digest and path doesn't change size
+	prev, err := store.cache.GetObjectManifest(ctx, store.id, obj.Inventory.ID)
+	if err != nil || prev.Path != obj.Path {
+		prev = &chaparral.ObjectManifest{}
+	}
 	man := &chaparral.ObjectManifest{
 		ObjectRef: chaparral.ObjectRef{
 			StorageRootID: store.id,
@@ -278,19 +433,40 @@ func (store *StorageRoot) syncObject(ctx context.Context, objectID string) error
 		DigestAlgorithm: obj.Inventory.DigestAlgorithm,
 		Manifest:        chaparral.Manifest{},
 		Spec:            string(obj.Inventory.Type.Spec),
+		InventoryDigest: obj.Inventory.Digest(),
 	}
 	for d, paths := range obj.Inventory.Manifest {
 		paths = slices.Clone(paths)
 		sort.Strings(paths)
-		man.Manifest[d] = chaparral.FileInfo{
+		info := chaparral.FileInfo{
 			Paths:  paths,
 			Fixity: obj.Inventory.GetFixity(d),
 		}
+		if size, known := sizes[d]; known {
+			info.Size = size
+		} else if prevInfo, cached := prev.Manifest[d]; cached && slices.Equal(prevInfo.Paths, paths) {
+			info.Size = prevInfo.Size
+		} else {
+			info.Size = unknownSize
+		}
+		man.Manifest[d] = info
 	}
-	if err := store.cache.SetObjectManifest(ctx, man); err != nil {
-		return fmt.Errorf("saving to storage root cache: %w", err)
+	if head := obj.Inventory.Version(0); head != nil {
+		man.HeadPaths = head.State.Paths()
 	}
-	return nil
+	man.Head = obj.Inventory.Head.Num()
+	man.VersionStates = make([]ocfl.DigestMap, man.Head)
+	for i := range man.VersionStates {
+		if v := obj.Inventory.Version(i + 1); v != nil {
+			man.VersionStates[i] = v.State
+		}
+	}
+	meta, err := store.readMetadata(ctx, obj)
+	if err != nil {
+		return nil, fmt.Errorf("reading object metadata: %w", err)
+	}
+	man.Metadata = meta
+	return man, nil
 }
 
 func (store *StorageRoot) getObjectSync(objectID string) (chan struct{}, bool) {
@@ -304,7 +480,10 @@ func (store *StorageRoot) getObjectSync(objectID string) (chan struct{}, bool) {
 	return ch, false
 }
 
-func (store *StorageRoot) Commit(ctx context.Context, objectID string, stage *ocfl.Stage, opts ...ocflv1.CommitOption) error {
+// Commit creates a new version of the object with the stage. If sizes isn't
+// nil, it has the sizes of the stage's content, keyed by digest; the sizes are
+// saved in the storage root's cache.
+func (store *StorageRoot) Commit(ctx context.Context, objectID string, stage *ocfl.Stage, sizes map[string]int64, opts ...ocflv1.CommitOption) error {
 	if err := store.Ready(ctx); err != nil {
 		return err
 	}
@@ -313,6 +492,13 @@ func (store *StorageRoot) Commit(ctx context.Context, objectID string, stage *oc
 		return err
 	}
 	defer unlock()
+	sizes = maps.Clone(sizes)
+	if sizes == nil {
+		sizes = map[string]int64{}
+	}
+	if err := store.checkLimits(ctx, objectID, stage, sizes); err != nil {
+		return err
+	}
 	if err := store.base.Commit(ctx, objectID, stage, opts...); err != nil {
 		var commitErr *ocflv1.CommitError
 		if errors.As(err, &commitErr) && commitErr.Dirty {
@@ -320,7 +506,7 @@ func (store *StorageRoot) Commit(ctx context.Context, objectID string, stage *oc
 		}
 		return err
 	}
-	if err := store.syncObject(ctx, objectID); err != nil {
+	if err := store.syncObject(ctx, objectID, sizes); err != nil {
 		return fmt.Errorf("while syncing object, post-commit: %w", err)
 	}
 	return nil
@@ -335,6 +521,12 @@ func (store *StorageRoot) DeleteObject(ctx context.Context, objectID string) err
 		return err
 	}
 	defer unlock()
+	return store.deleteObject(ctx, objectID)
+}
+
+// deleteObject removes the object and clears it from the cache. The caller
+// must hold the object's write lock.
+func (store *StorageRoot) deleteObject(ctx context.Context, objectID string) error {
 	obj, err := store.base.GetObject(ctx, objectID)
 	if err != nil {
 		return err
@@ -345,6 +537,12 @@ func (store *StorageRoot) DeleteObject(ctx context.Context, objectID string) err
 	if err := store.cache.DeleteObject(ctx, store.id, objectID); err != nil {
 		return fmt.Errorf("clearing cache: %w", err)
 	}
+	if store.tags != nil {
+		if err := store.tags.DeleteVersionTags(ctx, store.id, objectID); err != nil {
+			return fmt.Errorf("deleting version tags: %w", err)
+		}
+	}
+	store.clearChecked(objectID)
 	return nil
 }
 
@@ -355,6 +553,72 @@ func (store *StorageRoot) Validate(ctx context.Context, opts ...ocflv1.Validatio
 	return store.base.Validate(ctx, opts...), nil
 }
 
+// Stats is a summary of a storage root's contents.
+type Stats struct {
+	ObjectCount int64 // number of objects
+	TotalBytes  int64 // size of all files in the storage root
+}
+
+// Stats walks the storage root, counting objects and file sizes.
+func (store *StorageRoot) Stats(ctx context.Context) (*Stats, error) {
+	if err := store.Ready(ctx); err != nil {
+		return nil, err
+	}
+	stats := &Stats{}
+	if err := store.walkStats(ctx, store.path, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (store *StorageRoot) walkStats(ctx context.Context, dir string, stats *Stats) error {
+	entries, err := store.fs.ReadDir(ctx, dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		name := path.Join(dir, e.Name())
+		switch {
+		case e.IsDir():
+			if err := store.walkStats(ctx, name, stats); err != nil {
+				return err
+			}
+		case e.Type().IsRegular():
+			if dec, err := ocfl.ParseNamaste(e.Name()); err == nil && dec.Type == ocfl.NamasteTypeObject {
+				stats.ObjectCount++
+			}
+			info, err := e.Info()
+			if err != nil {
+				return err
+			}
+			stats.TotalBytes += info.Size()
+		}
+	}
+	return nil
+}
+
+// Objects calls fn for each object in the storage root. If an error occurs
+// while reading an object's inventory, the error is passed to fn. If fn
+// returns an error, iteration stops.
+func (store *StorageRoot) Objects(ctx context.Context, fn func(*ocflv1.Object, error) error) error {
+	if err := store.Ready(ctx); err != nil {
+		return err
+	}
+	return store.base.Objects(ctx, fn)
+}
+
+// ValidateObject fully validates obj, including its content digests. The
+// object is read-locked during validation, so commits to it will fail until
+// validation is complete.
+func (store *StorageRoot) ValidateObject(ctx context.Context, obj *ocflv1.Object, opts ...ocflv1.ValidationOption) (*validation.Result, error) {
+	unlock, err := store.locker.ReadLock(obj.Inventory.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return obj.Validate(ctx, opts...), nil
+}
+
 //	func (store *StorageRoot) ListObjects(ctx context.Context, objFn func(*ocflv1.Object, error) bool, concurrency int) error {
 //		if err := store.Init(ctx); err != nil {
 //			return err
@@ -2,13 +2,18 @@ package store_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io/fs"
+	"path"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/carlmjohnson/be"
+	"github.com/srerickson/chaparral"
 	"github.com/srerickson/chaparral/internal/testutil"
 	"github.com/srerickson/chaparral/server/internal/lock"
 	"github.com/srerickson/chaparral/server/store"
@@ -71,6 +76,53 @@ func TestGetObjectManifest(t *testing.T) {
 	})
 }
 
+func TestListVersionState(t *testing.T) {
+	ctx := context.Background()
+	objID := "ark:123/abc"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "..", "testdata"))
+	db := testutil.TestDB(t)
+	root := store.NewStorageRoot(fixture.ID(), fixture.FS(), fixture.Path(), nil, db)
+	ver, err := root.GetObjectVersion(ctx, objID, 0)
+	be.NilErr(t, err)
+	ver.Close()
+	man, err := root.GetObjectManifest(ctx, objID)
+	be.NilErr(t, err)
+	man.Close()
+	checkState := func(t *testing.T) {
+		t.Helper()
+		page, err := root.ListVersionState(ctx, objID, 0, "", "", 1000)
+		be.NilErr(t, err)
+		be.Equal(t, ver.Version, page.Version)
+		be.Equal(t, ver.DigestAlgorithm, page.DigestAlgorithm)
+		paths := ver.State.PathMap()
+		be.Equal(t, len(paths), len(page.Items))
+		for _, item := range page.Items {
+			be.Equal(t, paths[item.Path], item.Digest)
+			be.Equal(t, man.Manifest[item.Digest].Size, item.Size)
+		}
+		be.True(t, slices.IsSortedFunc(page.Items, func(a, b chaparral.StateItem) int {
+			return strings.Compare(a.Path, b.Path)
+		}))
+	}
+	checkState(t)
+	// paging
+	page, err := root.ListVersionState(ctx, objID, ver.Version, "", "", 1)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(page.Items))
+	next, err := root.ListVersionState(ctx, objID, ver.Version, "", page.Items[0].Path, 1000)
+	be.NilErr(t, err)
+	be.Equal(t, len(ver.State.PathMap())-1, len(next.Items))
+	// missing version
+	_, err = root.ListVersionState(ctx, objID, ver.Version+1, "", "", 1000)
+	be.True(t, errors.Is(err, fs.ErrNotExist))
+	// objects cached without version states are synced
+	cached, err := db.GetObjectManifest(ctx, root.ID(), objID)
+	be.NilErr(t, err)
+	cached.Head = 0
+	be.NilErr(t, db.SetObjectManifest(ctx, cached))
+	checkState(t)
+}
+
 func testObjectLifecycle(t *testing.T, root *store.StorageRoot) {
 	ctx := context.Background()
 
@@ -108,7 +160,7 @@ func testObjectLifecycle(t *testing.T, root *store.StorageRoot) {
 
 	// test concurrent go-routines
 	errs := goGroupErrors(2, func() error {
-		return root.Commit(ctx, srcID, stage,
+		return root.Commit(ctx, srcID, stage, nil,
 			ocflv1.WithCreated(srcVersion.Created),
 			ocflv1.WithMessage(srcVersion.Message),
 			ocflv1.WithUser(*srcVersion.User),
@@ -159,3 +211,350 @@ func goGroupErrors(times int, run func() error) []error {
 	wg.Wait()
 	return errs
 }
+
+func TestCopyObject(t *testing.T) {
+	ctx := context.Background()
+	srcID := "ark:123/abc"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "..", "testdata"))
+	rootA := testutil.NewStoreTempDir(t)
+	rootB := testutil.NewStoreTempDir(t)
+
+	be.NilErr(t, rootA.CopyObject(ctx, fixture, srcID))
+	err := rootA.CopyObject(ctx, fixture, srcID)
+	be.True(t, errors.Is(err, store.ErrObjectExists))
+	err = rootA.CopyObject(ctx, rootA, srcID)
+	be.True(t, errors.Is(err, store.ErrSameRoot))
+
+	// copy has the same versions as the source
+	fixtureVer, err := fixture.GetObjectVersion(ctx, srcID, 0)
+	be.NilErr(t, err)
+	defer fixtureVer.Close()
+	copyVer, err := rootA.GetObjectVersion(ctx, srcID, 0)
+	be.NilErr(t, err)
+	be.Equal(t, fixtureVer.Head, copyVer.Head)
+	be.DeepEqual(t, fixtureVer.State, copyVer.State)
+	be.Equal(t, fixtureVer.Created, copyVer.Created)
+
+	// move fails while the object is open
+	err = rootB.MoveObject(ctx, rootA, srcID)
+	be.True(t, errors.Is(err, lock.ErrWriteLock))
+	copyVer.Close()
+
+	be.NilErr(t, rootB.MoveObject(ctx, rootA, srcID))
+	_, err = rootA.GetObjectManifest(ctx, srcID)
+	be.True(t, errors.Is(err, fs.ErrNotExist))
+	moved, err := rootB.GetObjectManifest(ctx, srcID)
+	be.NilErr(t, err)
+	defer moved.Close()
+	be.Equal(t, rootB.ID(), moved.StorageRootID)
+	result, err := rootB.Validate(ctx)
+	be.NilErr(t, err)
+	be.NilErr(t, result.Err())
+}
+
+func TestStatsReindex(t *testing.T) {
+	ctx := context.Background()
+	srcID := "ark:123/abc"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "..", "testdata"))
+	root := testutil.NewStoreTempDir(t)
+	stats, err := root.Stats(ctx)
+	be.NilErr(t, err)
+	be.Equal(t, 0, stats.ObjectCount)
+	be.NilErr(t, root.CopyObject(ctx, fixture, srcID))
+	stats, err = root.Stats(ctx)
+	be.NilErr(t, err)
+	be.Equal(t, 1, stats.ObjectCount)
+	be.True(t, stats.TotalBytes > 0)
+
+	// a new cache for the same storage root is populated by reindex
+	db := testutil.TestDB(t)
+	sameRoot := store.NewStorageRoot(root.ID(), root.FS(), root.Path(), nil, db)
+	var progressCalls int
+	reindexStats, err := sameRoot.Reindex(ctx, func(_ string, err error, _ store.ReindexStats) {
+		be.NilErr(t, err)
+		progressCalls++
+	})
+	be.NilErr(t, err)
+	be.Equal(t, store.ReindexStats{Indexed: 1}, reindexStats)
+	be.Equal(t, 1, progressCalls)
+	ids, err := db.GetObjectIDs(ctx, root.ID())
+	be.NilErr(t, err)
+	be.DeepEqual(t, []string{srcID}, ids)
+
+	// stale entries are removed for objects deleted outside the storage root.
+	obj, err := sameRoot.GetObjectManifest(ctx, srcID)
+	be.NilErr(t, err)
+	obj.Close()
+	be.NilErr(t, root.FS().RemoveAll(ctx, obj.Path))
+	reindexStats, err = sameRoot.Reindex(ctx, nil)
+	be.NilErr(t, err)
+	be.Equal(t, store.ReindexStats{Removed: 1}, reindexStats)
+	ids, err = db.GetObjectIDs(ctx, root.ID())
+	be.NilErr(t, err)
+	be.Equal(t, 0, len(ids))
+}
+
+func TestRevalidation(t *testing.T) {
+	ctx := context.Background()
+	srcID := "ark:123/abc"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "..", "testdata"))
+	writer := testutil.NewStoreTempDir(t)
+	be.NilErr(t, writer.CopyObject(ctx, fixture, srcID))
+	// other instances using the same storage root with separate caches
+	revalidating := store.NewStorageRoot(writer.ID(), writer.FS(), writer.Path(), nil,
+		testutil.TestDB(t), store.WithRevalidation(0))
+	trusting := store.NewStorageRoot(writer.ID(), writer.FS(), writer.Path(), nil,
+		testutil.TestDB(t))
+	getDigest := func(root *store.StorageRoot) (string, error) {
+		man, err := root.GetObjectManifest(ctx, srcID)
+		if err != nil {
+			return "", err
+		}
+		defer man.Close()
+		return man.InventoryDigest, nil
+	}
+	firstDigest, err := getDigest(writer)
+	be.NilErr(t, err)
+	be.Nonzero(t, firstDigest)
+	be.Equal(t, firstDigest, testutil.Must(getDigest(revalidating)))
+	be.Equal(t, firstDigest, testutil.Must(getDigest(trusting)))
+
+	// new version
+	ver, err := writer.GetObjectVersion(ctx, srcID, 0)
+	be.NilErr(t, err)
+	stage := &ocfl.Stage{
+		DigestAlgorithm: ver.DigestAlgorithm,
+		State:           ver.State.DigestMap(),
+	}
+	ver.Close()
+	be.NilErr(t, writer.Commit(ctx, srcID, stage, nil, ocflv1.WithMessage("v2"), ocflv1.WithAllowUnchanged()))
+	secondDigest, err := getDigest(writer)
+	be.NilErr(t, err)
+	be.True(t, firstDigest != secondDigest)
+	be.Equal(t, secondDigest, testutil.Must(getDigest(revalidating)))
+	be.Equal(t, firstDigest, testutil.Must(getDigest(trusting)))
+
+	// deleted object
+	be.NilErr(t, writer.DeleteObject(ctx, srcID))
+	_, err = getDigest(revalidating)
+	be.True(t, errors.Is(err, fs.ErrNotExist))
+	_, err = getDigest(trusting)
+	be.NilErr(t, err)
+}
+
+func TestReindexRevalidation(t *testing.T) {
+	ctx := context.Background()
+	srcID := "ark:123/abc"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "..", "testdata"))
+	writer := testutil.NewStoreTempDir(t)
+	be.NilErr(t, writer.CopyObject(ctx, fixture, srcID))
+	root := store.NewStorageRoot(writer.ID(), writer.FS(), writer.Path(), nil,
+		testutil.TestDB(t), store.WithRevalidation(time.Hour))
+	_, err := root.Reindex(ctx, nil)
+	be.NilErr(t, err)
+	man, err := writer.GetObjectManifest(ctx, srcID)
+	be.NilErr(t, err)
+	firstDigest := man.InventoryDigest
+	man.Close()
+
+	// reindexed objects count as checked, so the new version isn't seen
+	// until maxAge has passed.
+	ver, err := writer.GetObjectVersion(ctx, srcID, 0)
+	be.NilErr(t, err)
+	stage := &ocfl.Stage{
+		DigestAlgorithm: ver.DigestAlgorithm,
+		State:           ver.State.DigestMap(),
+	}
+	ver.Close()
+	be.NilErr(t, writer.Commit(ctx, srcID, stage, nil, ocflv1.WithMessage("v2"), ocflv1.WithAllowUnchanged()))
+	man, err = root.GetObjectManifest(ctx, srcID)
+	be.NilErr(t, err)
+	be.Equal(t, firstDigest, man.InventoryDigest)
+	man.Close()
+}
+
+func TestVersionTags(t *testing.T) {
+	ctx := context.Background()
+	srcID := "tagged-object"
+	tmp := testutil.NewStoreTempDir(t)
+	db := testutil.TestDB(t)
+	root := store.NewStorageRoot(tmp.ID(), tmp.FS(), tmp.Path(), nil, db,
+		store.WithVersionTags(db), store.WithTagMirroring())
+	for _, cont := range []string{"version 1", "version 2"} {
+		stage, err := ocfl.StageBytes(map[string][]byte{"file.txt": []byte(cont)}, ocfl.SHA256)
+		be.NilErr(t, err)
+		be.NilErr(t, root.Commit(ctx, srcID, stage, nil, ocflv1.WithMessage(cont)))
+	}
+
+	// tag the head and a previous version
+	tag, err := root.TagVersion(ctx, srcID, "published", 0)
+	be.NilErr(t, err)
+	be.Equal(t, 2, tag.Version)
+	_, err = root.TagVersion(ctx, srcID, "first-release", 1)
+	be.NilErr(t, err)
+	_, err = root.TagVersion(ctx, srcID, "bad tag", 1)
+	be.True(t, errors.Is(err, store.ErrInvalidTagName))
+	_, err = root.TagVersion(ctx, srcID, "missing", 3)
+	be.True(t, errors.Is(err, fs.ErrNotExist))
+	tags, err := root.ListVersionTags(ctx, srcID)
+	be.NilErr(t, err)
+	be.Equal(t, 2, len(tags))
+	be.Equal(t, "first-release", tags[0].Name)
+	be.Equal(t, "published", tags[1].Name)
+
+	// tags are mirrored in the object's extensions directory
+	man, err := root.GetObjectManifest(ctx, srcID)
+	be.NilErr(t, err)
+	objPath := man.Path
+	man.Close()
+	tagsFile := path.Join(objPath, "extensions", store.TagExtension, "tags.json")
+	readMirror := func() (map[string]int, error) {
+		f, err := root.FS().OpenFile(ctx, tagsFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		mirror := map[string]int{}
+		return mirror, json.NewDecoder(f).Decode(&mirror)
+	}
+	mirror, err := readMirror()
+	be.NilErr(t, err)
+	be.DeepEqual(t, map[string]int{"published": 2, "first-release": 1}, mirror)
+	_, result := ocflv1.ValidateObject(ctx, root.FS(), objPath)
+	be.NilErr(t, result.Err())
+
+	// deleting tags
+	be.NilErr(t, root.DeleteVersionTag(ctx, srcID, "published"))
+	err = root.DeleteVersionTag(ctx, srcID, "published")
+	be.True(t, errors.Is(err, fs.ErrNotExist))
+	mirror, err = readMirror()
+	be.NilErr(t, err)
+	be.DeepEqual(t, map[string]int{"first-release": 1}, mirror)
+	be.NilErr(t, root.DeleteVersionTag(ctx, srcID, "first-release"))
+	_, err = readMirror()
+	be.True(t, errors.Is(err, fs.ErrNotExist))
+
+	// tags are removed with the object
+	_, err = root.TagVersion(ctx, srcID, "published", 0)
+	be.NilErr(t, err)
+	be.NilErr(t, root.DeleteObject(ctx, srcID))
+	tags, err = db.ListVersionTags(ctx, root.ID(), srcID)
+	be.NilErr(t, err)
+	be.Equal(t, 0, len(tags))
+
+	// tags aren't available without TagPersistence
+	noTags := store.NewStorageRoot(tmp.ID(), tmp.FS(), tmp.Path(), nil, db)
+	_, err = noTags.TagVersion(ctx, srcID, "published", 0)
+	be.True(t, errors.Is(err, store.ErrTagsDisabled))
+}
+
+func TestLimits(t *testing.T) {
+	ctx := context.Background()
+	objID := "limited-object"
+	tmp := testutil.NewStoreTempDir(t)
+	root := store.NewStorageRoot(tmp.ID(), tmp.FS(), tmp.Path(), nil, testutil.TestDB(t),
+		store.WithLimits(store.Limits{
+			MaxCommitBytes:   10,
+			MaxVersionFiles:  2,
+			MaxObjectBytes:   15,
+			DigestAlgorithms: []string{ocfl.SHA256},
+		}))
+	commit := func(alg string, files map[string]string) error {
+		byts := map[string][]byte{}
+		for name, cont := range files {
+			byts[name] = []byte(cont)
+		}
+		stage, err := ocfl.StageBytes(byts, alg)
+		be.NilErr(t, err)
+		return root.Commit(ctx, objID, stage, nil, ocflv1.WithMessage("test"))
+	}
+	err := commit(ocfl.SHA512, map[string]string{"a.txt": "a"})
+	be.True(t, errors.Is(err, store.ErrDisallowedDigest))
+	err = commit(ocfl.SHA256, map[string]string{"a.txt": "a", "b.txt": "b", "c.txt": "c"})
+	be.True(t, errors.Is(err, store.ErrLimitExceeded))
+	err = commit(ocfl.SHA256, map[string]string{"a.txt": "more than 10 bytes"})
+	be.True(t, errors.Is(err, store.ErrLimitExceeded))
+	be.NilErr(t, commit(ocfl.SHA256, map[string]string{"a.txt": "0123456789"}))
+	// existing content isn't new
+	be.NilErr(t, commit(ocfl.SHA256, map[string]string{"a.txt": "0123456789", "b.txt": "0123456789"}))
+	// object would exceed 15 bytes
+	err = commit(ocfl.SHA256, map[string]string{"a.txt": "0123456789", "b.txt": "abcdef"})
+	be.True(t, errors.Is(err, store.ErrLimitExceeded))
+	be.NilErr(t, commit(ocfl.SHA256, map[string]string{"a.txt": "0123456789", "b.txt": "abcde"}))
+	// content sizes are cached
+	man, err := root.GetObjectManifest(ctx, objID)
+	be.NilErr(t, err)
+	var sizes []int64
+	for _, info := range man.Manifest {
+		sizes = append(sizes, info.Size)
+	}
+	man.Close()
+	slices.Sort(sizes)
+	be.DeepEqual(t, []int64{5, 10}, sizes)
+
+	// copies are checked against the destination's limits
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "..", "testdata"))
+	err = root.CopyObject(ctx, fixture, "ark:123/abc")
+	be.True(t, errors.Is(err, store.ErrDisallowedDigest))
+	for _, limits := range []store.Limits{
+		{MaxObjectBytes: 14},
+		{MaxVersionFiles: 1},
+	} {
+		tmp := testutil.NewStoreTempDirID(t, "dst")
+		dst := store.NewStorageRoot(tmp.ID(), tmp.FS(), tmp.Path(), nil, testutil.TestDB(t), store.WithLimits(limits))
+		err := dst.CopyObject(ctx, root, objID)
+		be.True(t, errors.Is(err, store.ErrLimitExceeded))
+		err = dst.MoveObject(ctx, root, objID)
+		be.True(t, errors.Is(err, store.ErrLimitExceeded))
+		// the source object isn't moved
+		obj, err := root.GetObjectVersion(ctx, objID, 0)
+		be.NilErr(t, err)
+		obj.Close()
+	}
+	tmp = testutil.NewStoreTempDirID(t, "dst")
+	dst := store.NewStorageRoot(tmp.ID(), tmp.FS(), tmp.Path(), nil, testutil.TestDB(t),
+		store.WithLimits(store.Limits{MaxObjectBytes: 15, MaxVersionFiles: 2}))
+	be.NilErr(t, dst.CopyObject(ctx, root, objID))
+}
+
+func TestContentSizes(t *testing.T) {
+	ctx := context.Background()
+	objID := "sized-object"
+	fixture := testutil.NewStoreTestdata(t, filepath.Join("..", "..", "testdata"))
+	// content files aren't read when the object is cached
+	fixtureMan, err := fixture.GetObjectManifest(ctx, "ark:123/abc")
+	be.NilErr(t, err)
+	for _, info := range fixtureMan.Manifest {
+		be.Equal(t, -1, info.Size)
+	}
+	fixtureMan.Close()
+
+	tmp := testutil.NewStoreTempDir(t)
+	root := store.NewStorageRoot(tmp.ID(), tmp.FS(), tmp.Path(), nil, testutil.TestDB(t))
+	stage, err := ocfl.StageBytes(map[string][]byte{
+		"a.txt":     []byte("content"),
+		"empty.txt": {},
+	}, ocfl.SHA256)
+	be.NilErr(t, err)
+	sizes := map[string]int64{
+		stage.State.GetDigest("a.txt"):     7,
+		stage.State.GetDigest("empty.txt"): 0,
+	}
+	be.NilErr(t, root.Commit(ctx, objID, stage, sizes, ocflv1.WithMessage("v1")))
+	// sizes from the commit are cached, including zero
+	man, err := root.GetObjectManifest(ctx, objID)
+	be.NilErr(t, err)
+	be.Equal(t, 7, man.Manifest[stage.State.GetDigest("a.txt")].Size)
+	be.Equal(t, 0, man.Manifest[stage.State.GetDigest("empty.txt")].Size)
+	man.Close()
+
+	// cached sizes are kept when the object is copied
+	tmp = testutil.NewStoreTempDirID(t, "dst")
+	dst := store.NewStorageRoot(tmp.ID(), tmp.FS(), tmp.Path(), nil, testutil.TestDB(t))
+	be.NilErr(t, dst.CopyObject(ctx, root, objID))
+	man, err = dst.GetObjectManifest(ctx, objID)
+	be.NilErr(t, err)
+	be.Equal(t, 7, man.Manifest[stage.State.GetDigest("a.txt")].Size)
+	be.Equal(t, 0, man.Manifest[stage.State.GetDigest("empty.txt")].Size)
+	man.Close()
+}
@@ -0,0 +1,194 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"time"
+
+	"github.com/srerickson/chaparral"
+)
+
+// TagExtension is the name of the object extension directory where version
+// tags are mirrored if tag mirroring is enabled.
+const TagExtension = "chaparral-version-tags"
+
+// tagsFile is the name of the file in the object's TagExtension directory
+// with the object's version tags: a JSON object mapping tag names to version
+// numbers.
+const tagsFile = "tags.json"
+
+var (
+	// ErrTagsDisabled is returned by tag methods if the storage root isn't
+	// configured with TagPersistence.
+	ErrTagsDisabled = errors.New("version tags are not enabled for the storage root")
+
+	// ErrInvalidTagName is returned if a tag name is invalid. Tag names must
+	// begin with a letter or digit and only include letters, digits, '.', '_',
+	// and '-'. They may be up to 128 characters long.
+	ErrInvalidTagName = errors.New("invalid tag name")
+
+	tagNameRexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+)
+
+// TagPersistence stores version tags. GetVersionTag returns an error wrapping
+// fs.ErrNotExist if the tag doesn't exist. Deleting tags that don't exist is
+// not an error.
+type TagPersistence interface {
+	SetVersionTag(ctx context.Context, tag *chaparral.VersionTag) error
+	GetVersionTag(ctx context.Context, storeID string, objID string, name string) (*chaparral.VersionTag, error)
+	ListVersionTags(ctx context.Context, storeID string, objID string) ([]chaparral.VersionTag, error)
+	DeleteVersionTag(ctx context.Context, storeID string, objID string, name string) error
+	DeleteVersionTags(ctx context.Context, storeID string, objID string) error
+}
+
+// WithVersionTags enables version tags for the storage root. Tags are saved
+// with tags.
+func WithVersionTags(tags TagPersistence) Option {
+	return func(store *StorageRoot) {
+		store.tags = tags
+	}
+}
+
+// WithTagMirroring enables mirroring of version tags to a file in each
+// object's TagExtension directory. The file is rewritten whenever the object's
+// tags change. It is not part of any object version. Mirroring has no effect
+// unless version tags are enabled with WithVersionTags.
+func WithTagMirroring() Option {
+	return func(store *StorageRoot) {
+		store.mirrorTags = true
+	}
+}
+
+// ValidTagName returns true if name can be used as a version tag.
+func ValidTagName(name string) bool {
+	return tagNameRexp.MatchString(name)
+}
+
+// TagVersion sets the tag name on the object version. If version is 0, the
+// object's most recent version is tagged. If the tag already exists for the
+// object, it is moved to the version.
+func (store *StorageRoot) TagVersion(ctx context.Context, objectID string, name string, version int) (*chaparral.VersionTag, error) {
+	if store.tags == nil {
+		return nil, ErrTagsDisabled
+	}
+	if !ValidTagName(name) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTagName, name)
+	}
+	if err := store.Ready(ctx); err != nil {
+		return nil, err
+	}
+	unlock, err := store.locker.WriteLock(objectID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	obj, err := store.base.GetObject(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if version == 0 {
+		version = obj.Inventory.Head.Num()
+	}
+	if obj.Inventory.Version(version) == nil {
+		return nil, fmt.Errorf("version index %d: %w", version, fs.ErrNotExist)
+	}
+	tag := &chaparral.VersionTag{
+		ObjectRef: chaparral.ObjectRef{StorageRootID: store.id, ID: obj.Inventory.ID},
+		Name:      name,
+		Version:   version,
+		Created:   time.Now().UTC().Truncate(time.Microsecond),
+	}
+	if err := store.tags.SetVersionTag(ctx, tag); err != nil {
+		return nil, fmt.Errorf("saving version tag: %w", err)
+	}
+	if err := store.mirrorObjectTags(ctx, obj.Inventory.ID, obj.Path); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// GetVersionTag returns the object's version tag with the given name. It
+// returns an error wrapping fs.ErrNotExist if the tag doesn't exist.
+func (store *StorageRoot) GetVersionTag(ctx context.Context, objectID string, name string) (*chaparral.VersionTag, error) {
+	if store.tags == nil {
+		return nil, ErrTagsDisabled
+	}
+	return store.tags.GetVersionTag(ctx, store.id, objectID, name)
+}
+
+// ListVersionTags returns the object's version tags, sorted by name.
+func (store *StorageRoot) ListVersionTags(ctx context.Context, objectID string) ([]chaparral.VersionTag, error) {
+	if store.tags == nil {
+		return nil, ErrTagsDisabled
+	}
+	return store.tags.ListVersionTags(ctx, store.id, objectID)
+}
+
+// DeleteVersionTag removes the object's version tag with the given name. It
+// returns an error wrapping fs.ErrNotExist if the tag doesn't exist.
+func (store *StorageRoot) DeleteVersionTag(ctx context.Context, objectID string, name string) error {
+	if store.tags == nil {
+		return ErrTagsDisabled
+	}
+	if err := store.Ready(ctx); err != nil {
+		return err
+	}
+	unlock, err := store.locker.WriteLock(objectID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if _, err := store.tags.GetVersionTag(ctx, store.id, objectID, name); err != nil {
+		return err
+	}
+	if err := store.tags.DeleteVersionTag(ctx, store.id, objectID, name); err != nil {
+		return fmt.Errorf("deleting version tag: %w", err)
+	}
+	if !store.mirrorTags {
+		return nil
+	}
+	obj, err := store.base.GetObject(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	return store.mirrorObjectTags(ctx, obj.Inventory.ID, obj.Path)
+}
+
+// mirrorObjectTags writes the object's tags to the tags file in its
+// TagExtension directory. If the object doesn't have tags, the extension
+// directory is removed. It does nothing if tag mirroring isn't enabled. The
+// caller must hold the object's write lock.
+func (store *StorageRoot) mirrorObjectTags(ctx context.Context, objectID string, objPath string) error {
+	if !store.mirrorTags {
+		return nil
+	}
+	tags, err := store.tags.ListVersionTags(ctx, store.id, objectID)
+	if err != nil {
+		return err
+	}
+	extDir := path.Join(objPath, "extensions", TagExtension)
+	if len(tags) == 0 {
+		if err := store.fs.RemoveAll(ctx, extDir); err != nil {
+			return fmt.Errorf("removing version tags file: %w", err)
+		}
+		return nil
+	}
+	versions := make(map[string]int, len(tags))
+	for _, tag := range tags {
+		versions[tag.Name] = tag.Version
+	}
+	byts, err := json.MarshalIndent(versions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := store.fs.Write(ctx, path.Join(extDir, tagsFile), bytes.NewReader(byts)); err != nil {
+		return fmt.Errorf("writing version tags file: %w", err)
+	}
+	return nil
+}
@@ -15,15 +15,23 @@ type Manager struct {
 	dir       string
 	uploaders map[string]*Uploader
 	persist   Persistence
+	multipart MultipartBackend
+	quotas    QuotaFunc
 	mx        sync.Mutex
 }
 
-func NewManager(fsys ocfl.WriteFS, dir string, persist Persistence) *Manager {
+// Option is used to configure a Manager
+type Option func(*Manager)
+
+func NewManager(fsys ocfl.WriteFS, dir string, persist Persistence, opts ...Option) *Manager {
 	mgr := &Manager{
 		fs:      fsys,
 		dir:     dir,
 		persist: persist,
 	}
+	for _, opt := range opts {
+		opt(mgr)
+	}
 	return mgr
 }
 
@@ -73,12 +81,13 @@ func (mgr *Manager) GetUploader(ctx context.Context, uploaderID string) (*Upload
 			return nil, fmt.Errorf("%w: %v", ErrUploaderNotFound, err)
 		}
 		uploader = &Uploader{
-			id:      restored.ID,
-			config:  restored.Config,
-			created: restored.CreatedAt,
-			uploads: restored.Uploads,
-			mgr:     mgr,
-			refs:    1,
+			id:         restored.ID,
+			config:     restored.Config,
+			created:    restored.CreatedAt,
+			uploads:    restored.Uploads,
+			multiparts: restored.Multiparts,
+			mgr:        mgr,
+			refs:       1,
 		}
 		if mgr.uploaders == nil {
 			mgr.uploaders = map[string]*Uploader{}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"net/http"
+	"net/url"
 	"path"
 	"path/filepath"
 	"slices"
@@ -12,11 +14,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/carlmjohnson/be"
+	"github.com/google/uuid"
 	"github.com/srerickson/chaparral/internal/testutil"
 	"github.com/srerickson/chaparral/server/chapdb"
 	"github.com/srerickson/chaparral/server/uploader"
 	"github.com/srerickson/ocfl-go"
+	s3ocfl "github.com/srerickson/ocfl-go/backend/s3"
 )
 
 const (
@@ -108,6 +114,325 @@ func testManagerUploaders(t *testing.T, mgr *uploader.Manager, uploaderID string
 
 }
 
+func TestWriteVerify(t *testing.T) {
+	ctx := context.Background()
+	fsys, err := testutil.TempDirBackend(t).NewFS()
+	be.NilErr(t, err)
+	mgr := uploader.NewManager(fsys, "uploads", nil)
+	uploaderID, err := mgr.NewUploader(ctx, &uploader.Config{UserID: user, Algs: algs})
+	be.NilErr(t, err)
+	upper, err := mgr.GetUploader(ctx, uploaderID)
+	be.NilErr(t, err)
+	defer upper.Close(ctx)
+	expected, err := upper.Write(ctx, strings.NewReader("content"))
+	be.NilErr(t, err)
+	result, err := upper.WriteVerify(ctx, strings.NewReader("content"), func() (ocfl.DigestSet, error) {
+		return ocfl.DigestSet{ocfl.SHA256: strings.ToUpper(expected.Digests[ocfl.SHA256])}, nil
+	})
+	be.NilErr(t, err)
+	be.DeepEqual(t, expected.Digests, result.Digests)
+	// duplicate content isn't stored twice
+	be.Equal(t, expected.Name, result.Name)
+	for _, digests := range []ocfl.DigestSet{
+		{ocfl.SHA256: expected.Digests[ocfl.SHA256], ocfl.MD5: "bad"},
+		{ocfl.SHA1: "abc"},
+	} {
+		_, err = upper.WriteVerify(ctx, strings.NewReader("content"), func() (ocfl.DigestSet, error) {
+			return digests, nil
+		})
+		be.True(t, errors.Is(err, uploader.ErrDigestMismatch) || errors.Is(err, uploader.ErrDigestAlgorithm))
+	}
+	_, err = upper.WriteVerify(ctx, strings.NewReader("content"), func() (ocfl.DigestSet, error) {
+		return nil, errors.New("invalid trailer")
+	})
+	be.Nonzero(t, err)
+	// rejected uploads are removed
+	be.Equal(t, 1, len(upper.Uploads()))
+	root, dir := upper.Root()
+	entries, err := root.ReadDir(ctx, dir)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(entries))
+}
+
+func TestQuota(t *testing.T) {
+	ctx := context.Background()
+	quotas := func(_ context.Context, userID string) uploader.Quota {
+		if userID == user {
+			return uploader.Quota{MaxBytes: 10, MaxFiles: 2, MaxUploaderBytes: 8}
+		}
+		return uploader.Quota{}
+	}
+	for _, persist := range []bool{false, true} {
+		t.Run(fmt.Sprintf("persist=%v", persist), func(t *testing.T) {
+			fsys, err := testutil.TempDirBackend(t).NewFS()
+			be.NilErr(t, err)
+			var db uploader.Persistence
+			if persist {
+				db = testutil.TestDB(t)
+			}
+			mgr := uploader.NewManager(fsys, "uploads", db, uploader.WithQuotas(quotas))
+			newUploader := func(userID string) *uploader.Uploader {
+				id, err := mgr.NewUploader(ctx, &uploader.Config{UserID: userID, Algs: algs})
+				be.NilErr(t, err)
+				upper, err := mgr.GetUploader(ctx, id)
+				be.NilErr(t, err)
+				t.Cleanup(func() { upper.Close(ctx) })
+				return upper
+			}
+			upperA := newUploader(user)
+			_, err = upperA.Write(ctx, strings.NewReader("123456"))
+			be.NilErr(t, err)
+			// exceeds the uploader's limit
+			_, err = upperA.Write(ctx, strings.NewReader("abc"))
+			be.True(t, errors.Is(err, uploader.ErrQuotaExceeded))
+			// partial uploads are removed
+			root, dir := upperA.Root()
+			entries, err := root.ReadDir(ctx, dir)
+			be.NilErr(t, err)
+			be.Equal(t, 1, len(entries))
+			// exceeds the user's limit
+			upperB := newUploader(user)
+			_, err = upperB.Write(ctx, strings.NewReader("abcde"))
+			be.True(t, errors.Is(err, uploader.ErrQuotaExceeded))
+			_, err = upperB.Write(ctx, strings.NewReader("abcd"))
+			be.NilErr(t, err)
+			usage, err := mgr.UserUsage(ctx, user)
+			be.NilErr(t, err)
+			be.Equal(t, uploader.Usage{Bytes: 10, Files: 2}, usage)
+			be.Equal(t, uploader.Usage{Bytes: 4, Files: 1}, upperB.Usage())
+			// exceeds the user's file limit
+			_, err = upperB.Write(ctx, strings.NewReader(""))
+			be.True(t, errors.Is(err, uploader.ErrQuotaExceeded))
+			// other users aren't limited
+			_, err = newUploader("other").Write(ctx, strings.NewReader("0123456789abcdef"))
+			be.NilErr(t, err)
+			// deleting uploaders frees quota
+			be.NilErr(t, upperA.Delete(ctx))
+			_, err = upperB.Write(ctx, strings.NewReader("xyz"))
+			be.NilErr(t, err)
+		})
+	}
+}
+
+func TestMultipart(t *testing.T) {
+	ctx := context.Background()
+	fsys, err := testutil.TempDirBackend(t).NewFS()
+	be.NilErr(t, err)
+	fake := testutil.NewFakeMultipart(t, fsys)
+	db := testutil.TestDB(t)
+	mgr := uploader.NewManager(fsys, "uploads", db, uploader.WithMultipart(fake))
+	uploaderID, err := mgr.NewUploader(ctx, &uploader.Config{UserID: user, Algs: algs})
+	be.NilErr(t, err)
+	upper, err := mgr.GetUploader(ctx, uploaderID)
+	be.NilErr(t, err)
+	defer upper.Close(ctx)
+	be.True(t, upper.Multipart())
+	content := []string{"part one,", "part two"}
+	size := int64(len(strings.Join(content, "")))
+	expected, err := upper.Write(ctx, strings.NewReader(strings.Join(content, "")))
+	be.NilErr(t, err)
+
+	// upload parts to the fake's presigned URLs
+	putParts := func(t *testing.T, mpUp *uploader.MultipartUpload) []uploader.Part {
+		t.Helper()
+		parts := make([]uploader.Part, len(mpUp.PartURLs))
+		for i, u := range mpUp.PartURLs {
+			req, err := http.NewRequest(http.MethodPut, u, strings.NewReader(content[i]))
+			be.NilErr(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			be.NilErr(t, err)
+			resp.Body.Close()
+			be.Equal(t, http.StatusOK, resp.StatusCode)
+			parts[i] = uploader.Part{Number: int32(i + 1), ETag: resp.Header.Get("ETag")}
+		}
+		return parts
+	}
+
+	t.Run("complete", func(t *testing.T) {
+		mpUp, err := upper.NewMultipartUpload(ctx, size, len(content), time.Minute)
+		be.NilErr(t, err)
+		be.Equal(t, len(content), len(mpUp.PartURLs))
+		be.True(t, mpUp.Expires.After(time.Now()))
+		parts := putParts(t, mpUp)
+		// parts may be given in any order
+		slices.Reverse(parts)
+		result, err := upper.CompleteMultipartUpload(ctx, mpUp.Name, mpUp.UploadID, parts, expected.Digests)
+		be.NilErr(t, err)
+		be.Equal(t, expected.Size, result.Size)
+		be.DeepEqual(t, expected.Digests, result.Digests)
+		// the content was already uploaded, so the existing upload is
+		// returned and the new file is removed.
+		be.Equal(t, expected.Name, result.Name)
+		fsys, dir := upper.Root()
+		_, err = fsys.OpenFile(ctx, path.Join(dir, mpUp.Name))
+		be.True(t, errors.Is(err, fs.ErrNotExist))
+	})
+	t.Run("digest mismatch", func(t *testing.T) {
+		mpUp, err := upper.NewMultipartUpload(ctx, size, len(content), time.Minute)
+		be.NilErr(t, err)
+		parts := putParts(t, mpUp)
+		_, err = upper.CompleteMultipartUpload(ctx, mpUp.Name, mpUp.UploadID, parts, ocfl.DigestSet{
+			ocfl.SHA256: "bad",
+		})
+		be.True(t, errors.Is(err, uploader.ErrDigestMismatch))
+		fsys, dir := upper.Root()
+		_, err = fsys.OpenFile(ctx, path.Join(dir, mpUp.Name))
+		be.True(t, errors.Is(err, fs.ErrNotExist))
+	})
+	t.Run("size mismatch", func(t *testing.T) {
+		mpUp, err := upper.NewMultipartUpload(ctx, size-1, len(content), time.Minute)
+		be.NilErr(t, err)
+		parts := putParts(t, mpUp)
+		_, err = upper.CompleteMultipartUpload(ctx, mpUp.Name, mpUp.UploadID, parts, nil)
+		be.True(t, errors.Is(err, uploader.ErrUploadSize))
+		fsys, dir := upper.Root()
+		_, err = fsys.OpenFile(ctx, path.Join(dir, mpUp.Name))
+		be.True(t, errors.Is(err, fs.ErrNotExist))
+	})
+	t.Run("abort", func(t *testing.T) {
+		mpUp, err := upper.NewMultipartUpload(ctx, 1, 1, time.Minute)
+		be.NilErr(t, err)
+		be.Equal(t, 1, len(upper.PendingMultipartUploads()))
+		be.NilErr(t, upper.AbortMultipartUpload(ctx, mpUp.Name, mpUp.UploadID))
+		be.Equal(t, 0, fake.Len())
+		be.Equal(t, 0, len(upper.PendingMultipartUploads()))
+		// it can't be completed or aborted again
+		err = upper.AbortMultipartUpload(ctx, mpUp.Name, mpUp.UploadID)
+		be.True(t, errors.Is(err, uploader.ErrUploadName))
+		_, err = upper.CompleteMultipartUpload(ctx, mpUp.Name, mpUp.UploadID, []uploader.Part{{Number: 1}}, nil)
+		be.True(t, errors.Is(err, uploader.ErrUploadName))
+	})
+	t.Run("expired", func(t *testing.T) {
+		// part URLs that expired long enough ago are aborted when the next
+		// upload starts
+		_, err := upper.NewMultipartUpload(ctx, 1, 1, -uploader.MultipartCompleteWindow-time.Minute)
+		be.NilErr(t, err)
+		mpUp, err := upper.NewMultipartUpload(ctx, 1, 1, time.Minute)
+		be.NilErr(t, err)
+		be.Equal(t, 1, fake.Len())
+		pending := upper.PendingMultipartUploads()
+		be.Equal(t, 1, len(pending))
+		be.Equal(t, mpUp.Name, pending[0].Name)
+		be.NilErr(t, upper.AbortMultipartUpload(ctx, mpUp.Name, mpUp.UploadID))
+	})
+	t.Run("delete aborts pending", func(t *testing.T) {
+		id, err := mgr.NewUploader(ctx, &uploader.Config{UserID: user, Algs: algs})
+		be.NilErr(t, err)
+		upper, err := mgr.GetUploader(ctx, id)
+		be.NilErr(t, err)
+		mpUp, err := upper.NewMultipartUpload(ctx, size, len(content), time.Minute)
+		be.NilErr(t, err)
+		be.NilErr(t, upper.Close(ctx))
+		// pending uploads are restored from persistence
+		mgr2 := uploader.NewManager(fsys, "uploads", db, uploader.WithMultipart(fake))
+		restored, err := mgr2.GetUploader(ctx, id)
+		be.NilErr(t, err)
+		pending := restored.PendingMultipartUploads()
+		be.Equal(t, 1, len(pending))
+		be.Equal(t, mpUp.Name, pending[0].Name)
+		be.Equal(t, mpUp.UploadID, pending[0].UploadID)
+		be.Equal(t, size, pending[0].Size)
+		be.Equal(t, 1, fake.Len())
+		be.NilErr(t, restored.Delete(ctx))
+		be.Equal(t, 0, fake.Len())
+	})
+	t.Run("quota", func(t *testing.T) {
+		quotas := func(context.Context, string) uploader.Quota {
+			return uploader.Quota{MaxBytes: size + 1, MaxFiles: 1}
+		}
+		for _, persist := range []bool{false, true} {
+			t.Run(fmt.Sprintf("persist=%v", persist), func(t *testing.T) {
+				var db uploader.Persistence
+				if persist {
+					db = testutil.TestDB(t)
+				}
+				dir := fmt.Sprintf("uploads-quota-%v", persist)
+				mgr := uploader.NewManager(fsys, dir, db, uploader.WithMultipart(fake), uploader.WithQuotas(quotas))
+				id, err := mgr.NewUploader(ctx, &uploader.Config{UserID: user, Algs: algs})
+				be.NilErr(t, err)
+				upper, err := mgr.GetUploader(ctx, id)
+				be.NilErr(t, err)
+				defer upper.Close(ctx)
+				// the declared size is checked before the upload starts
+				_, err = upper.NewMultipartUpload(ctx, size+2, len(content), time.Minute)
+				be.True(t, errors.Is(err, uploader.ErrQuotaExceeded))
+				be.Equal(t, 0, fake.Len())
+				// pending uploads count against the quota
+				mpUp, err := upper.NewMultipartUpload(ctx, size, len(content), time.Minute)
+				be.NilErr(t, err)
+				usage, err := mgr.UserUsage(ctx, user)
+				be.NilErr(t, err)
+				be.Equal(t, uploader.Usage{Bytes: size, Files: 1}, usage)
+				_, err = upper.NewMultipartUpload(ctx, 1, 1, time.Minute)
+				be.True(t, errors.Is(err, uploader.ErrQuotaExceeded))
+				_, err = upper.Write(ctx, strings.NewReader("a"))
+				be.True(t, errors.Is(err, uploader.ErrQuotaExceeded))
+				// but not twice when they are completed
+				result, err := upper.CompleteMultipartUpload(ctx, mpUp.Name, mpUp.UploadID, putParts(t, mpUp), nil)
+				be.NilErr(t, err)
+				be.Equal(t, size, result.Size)
+				usage, err = mgr.UserUsage(ctx, user)
+				be.NilErr(t, err)
+				be.Equal(t, uploader.Usage{Bytes: size, Files: 1}, usage)
+				be.Equal(t, 0, fake.Len())
+			})
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := upper.NewMultipartUpload(ctx, 1, 0, time.Minute)
+		be.True(t, errors.Is(err, uploader.ErrPartCount))
+		_, err = upper.NewMultipartUpload(ctx, 1, uploader.MaxParts+1, time.Minute)
+		be.True(t, errors.Is(err, uploader.ErrPartCount))
+		_, err = upper.NewMultipartUpload(ctx, -1, 1, time.Minute)
+		be.True(t, errors.Is(err, uploader.ErrUploadSize))
+		parts := []uploader.Part{{Number: 1}}
+		_, err = upper.CompleteMultipartUpload(ctx, "../file", "id", parts, nil)
+		be.True(t, errors.Is(err, uploader.ErrUploadName))
+		_, err = upper.CompleteMultipartUpload(ctx, uuid.NewString(), "id", parts, ocfl.DigestSet{ocfl.SHA1: "abc"})
+		be.True(t, errors.Is(err, uploader.ErrDigestAlgorithm))
+		_, err = upper.CompleteMultipartUpload(ctx, uuid.NewString(), "id", parts, nil)
+		be.True(t, errors.Is(err, uploader.ErrUploadName))
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		mgr := uploader.NewManager(fsys, "uploads-2", nil)
+		id, err := mgr.NewUploader(ctx, &uploader.Config{Algs: algs})
+		be.NilErr(t, err)
+		upper, err := mgr.GetUploader(ctx, id)
+		be.NilErr(t, err)
+		defer upper.Close(ctx)
+		be.False(t, upper.Multipart())
+		_, err = upper.NewMultipartUpload(ctx, 1, 1, time.Minute)
+		be.True(t, errors.Is(err, uploader.ErrMultipartUnsupported))
+	})
+}
+
+func TestS3Multipart(t *testing.T) {
+	ctx := context.Background()
+	client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String("http://localhost:9000"),
+		UsePathStyle: true,
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "key", SecretAccessKey: "secret"}, nil
+		}),
+	})
+	mp, err := uploader.NewS3Multipart(&s3ocfl.BucketFS{S3: client, Bucket: "bucket"})
+	be.NilErr(t, err)
+	partURL, err := mp.PresignUploadPart(ctx, "uploads/file", "upload-id", 2, time.Minute)
+	be.NilErr(t, err)
+	u, err := url.Parse(partURL)
+	be.NilErr(t, err)
+	be.Equal(t, "/bucket/uploads/file", u.Path)
+	be.Equal(t, "upload-id", u.Query().Get("uploadId"))
+	be.Equal(t, "2", u.Query().Get("partNumber"))
+	be.Equal(t, "60", u.Query().Get("X-Amz-Expires"))
+
+	fsys, err := testutil.TempDirBackend(t).NewFS()
+	be.NilErr(t, err)
+	_, err = uploader.NewS3Multipart(fsys)
+	be.True(t, errors.Is(err, uploader.ErrMultipartUnsupported))
+}
+
 func beDeletedFiles(t *testing.T, upper *uploader.Uploader) {
 	ctx := context.Background()
 	fsys, dir := upper.Root()
@@ -0,0 +1,372 @@
+package uploader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srerickson/ocfl-go"
+)
+
+// MaxParts is the maximum number of parts in a multipart upload.
+const MaxParts = 10000
+
+// MultipartCompleteWindow is how long after its part URLs expire that a
+// multipart upload can be completed. Pending uploads older than that are
+// aborted when the uploader starts a new multipart upload.
+const MultipartCompleteWindow = time.Hour
+
+var (
+	ErrMultipartUnsupported = errors.New("uploader doesn't support multipart uploads")
+	ErrPartCount            = fmt.Errorf("multipart uploads must have between 1 and %d parts", MaxParts)
+	ErrUploadName           = errors.New("invalid multipart upload name")
+	ErrDigestMismatch       = errors.New("upload doesn't match the expected digest")
+	ErrUploadSize           = errors.New("upload doesn't match the declared size")
+)
+
+// MultipartBackend is implemented by storage backends that let clients upload
+// files directly, in parts, using presigned URLs. File names are relative to
+// the backend's FS.
+type MultipartBackend interface {
+	// NewMultipartUpload starts a multipart upload for the file and returns
+	// the upload's ID.
+	NewMultipartUpload(ctx context.Context, name string) (string, error)
+	// PresignUploadPart returns a URL for uploading the part number (starting
+	// at 1) with an HTTP PUT request.
+	PresignUploadPart(ctx context.Context, name string, uploadID string, number int32, expires time.Duration) (string, error)
+	// CompleteMultipartUpload assembles the uploaded parts into the file.
+	CompleteMultipartUpload(ctx context.Context, name string, uploadID string, parts []Part) error
+	// AbortMultipartUpload cancels the upload and removes uploaded parts.
+	AbortMultipartUpload(ctx context.Context, name string, uploadID string) error
+}
+
+// Part is an uploaded part of a multipart upload.
+type Part struct {
+	Number int32
+	ETag   string
+}
+
+// MultipartUpload is a multipart upload started with NewMultipartUpload.
+type MultipartUpload struct {
+	// file name relative to uploader's root
+	Name string
+	// the backend's id for the upload
+	UploadID string
+	// presigned URLs for uploading each part, in part number order
+	PartURLs []string
+	// when the part URLs expire
+	Expires time.Time
+}
+
+// PendingMultipart is a multipart upload that hasn't been completed or
+// aborted.
+type PendingMultipart struct {
+	// file name relative to uploader's root
+	Name string
+	// the backend's id for the upload
+	UploadID string
+	// the declared size of the file
+	Size int64
+	// when the part URLs expire
+	Expires time.Time
+}
+
+// WithMultipart enables multipart uploads using mp, which must use the same
+// FS as the manager.
+func WithMultipart(mp MultipartBackend) Option {
+	return func(mgr *Manager) {
+		mgr.multipart = mp
+	}
+}
+
+// Multipart returns true if the uploader supports multipart uploads.
+func (up *Uploader) Multipart() bool {
+	return up.mgr.multipart != nil
+}
+
+// NewMultipartUpload starts a multipart upload of a file with the given size
+// and number of parts to the uploader. The part URLs expire after expires. It
+// returns an error wrapping ErrQuotaExceeded if the user's quota doesn't allow
+// another file of the size. The upload is pending until it is completed or
+// aborted, and its size counts against the quota while it is pending; pending
+// uploads are aborted if the uploader is deleted.
+func (up *Uploader) NewMultipartUpload(ctx context.Context, size int64, parts int, expires time.Duration) (*MultipartUpload, error) {
+	mp := up.mgr.multipart
+	if mp == nil {
+		return nil, ErrMultipartUnsupported
+	}
+	if parts < 1 || parts > MaxParts {
+		return nil, ErrPartCount
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("%w: size must not be negative", ErrUploadSize)
+	}
+	if err := up.abortExpired(ctx); err != nil {
+		return nil, err
+	}
+	limit, err := up.quotaLimit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := limit.checkFile(); err != nil {
+		return nil, err
+	}
+	if err := limit.checkSize(size); err != nil {
+		return nil, err
+	}
+	name := uuid.NewString()
+	_, uproot := up.Root()
+	fullPath := path.Join(uproot, name)
+	uploadID, err := mp.NewMultipartUpload(ctx, fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("starting multipart upload: %w", err)
+	}
+	result := &MultipartUpload{
+		Name:     name,
+		UploadID: uploadID,
+		PartURLs: make([]string, parts),
+		Expires:  time.Now().Add(expires),
+	}
+	for i := range result.PartURLs {
+		result.PartURLs[i], err = mp.PresignUploadPart(ctx, fullPath, uploadID, int32(i+1), expires)
+		if err != nil {
+			err = fmt.Errorf("presigning part %d: %w", i+1, err)
+			if abortErr := mp.AbortMultipartUpload(ctx, fullPath, uploadID); abortErr != nil {
+				err = errors.Join(err, abortErr)
+			}
+			return nil, err
+		}
+	}
+	pending := PendingMultipart{
+		Name:     name,
+		UploadID: uploadID,
+		Size:     size,
+		Expires:  result.Expires,
+	}
+	if up.mgr.persist != nil {
+		if err := up.mgr.persist.CreateMultipartUpload(ctx, up.id, &pending); err != nil {
+			err = fmt.Errorf("persisting multipart upload for uploader %q: %w", up.id, err)
+			if abortErr := mp.AbortMultipartUpload(ctx, fullPath, uploadID); abortErr != nil {
+				err = errors.Join(err, abortErr)
+			}
+			return nil, err
+		}
+	}
+	up.mx.Lock()
+	defer up.mx.Unlock()
+	up.multiparts = append(up.multiparts, pending)
+	return result, nil
+}
+
+// CompleteMultipartUpload assembles the parts of the multipart upload and adds
+// the resulting file to the uploader. The file is read to compute its digests.
+// If digests is not empty, each entry must match the computed digest for the
+// algorithm; otherwise, the file is removed and an error wrapping
+// ErrDigestMismatch is returned. If the file's size isn't the size given to
+// NewMultipartUpload, it is removed and an error wrapping ErrUploadSize is
+// returned. As with Write, if the uploader already has an upload with the same
+// digests, the file is removed and the existing upload is returned. If the file
+// exceeds the user's quota, it is removed and an error wrapping
+// ErrQuotaExceeded is returned. The Upload returned must not be modified!
+func (up *Uploader) CompleteMultipartUpload(ctx context.Context, name string, uploadID string, parts []Part, digests ocfl.DigestSet) (*Upload, error) {
+	mp := up.mgr.multipart
+	if mp == nil {
+		return nil, ErrMultipartUnsupported
+	}
+	if _, err := uuid.Parse(name); err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUploadName, name)
+	}
+	if len(parts) < 1 || len(parts) > MaxParts {
+		return nil, ErrPartCount
+	}
+	for alg := range digests {
+		if !up.config.UsesAlg(alg) {
+			return nil, fmt.Errorf("%w: %q", ErrDigestAlgorithm, alg)
+		}
+	}
+	limit, err := up.quotaLimit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// claim the pending upload so it can't be completed twice
+	pending, err := up.takePending(name, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	// the pending upload was counted as usage when it started
+	limit = limit.release(pending.Size)
+	if err := limit.checkFile(); err != nil {
+		up.restorePending(pending)
+		return nil, err
+	}
+	parts = slices.Clone(parts)
+	slices.SortFunc(parts, func(a, b Part) int { return int(a.Number - b.Number) })
+	fsys, uproot := up.Root()
+	fullPath := path.Join(uproot, name)
+	if err := mp.CompleteMultipartUpload(ctx, fullPath, uploadID, parts); err != nil {
+		// the upload can be completed again or aborted
+		up.restorePending(pending)
+		return nil, fmt.Errorf("completing multipart upload: %w", err)
+	}
+	if err := up.deletePending(ctx, name); err != nil {
+		return nil, err
+	}
+	u, err := up.digestFile(ctx, fullPath)
+	if err == nil {
+		u.Name = name
+		if u.Size != pending.Size {
+			err = fmt.Errorf("%w: upload has %d bytes, not %d", ErrUploadSize, u.Size, pending.Size)
+		}
+	}
+	if err == nil {
+		err = limit.checkSize(u.Size)
+	}
+	if err == nil {
+		err = up.checkDigests(u, digests)
+	}
+	if err != nil {
+		if rmErr := fsys.Remove(ctx, fullPath); rmErr != nil {
+			err = errors.Join(err, rmErr)
+		}
+		return nil, err
+	}
+	up.mx.Lock()
+	defer up.mx.Unlock()
+	if existing := up.findUpload(u.Digests); existing != nil {
+		// discard duplicate content
+		if err := fsys.Remove(ctx, fullPath); err != nil {
+			return nil, fmt.Errorf("removing duplicate upload: %w", err)
+		}
+		return existing, nil
+	}
+	up.uploads = append(up.uploads, *u)
+	if up.mgr.persist != nil {
+		if err := up.mgr.persist.CreateUpload(ctx, up.id, u); err != nil {
+			return nil, fmt.Errorf("persisting upload for uploader %q: %w", up.id, err)
+		}
+	}
+	return u, nil
+}
+
+// AbortMultipartUpload cancels the multipart upload and removes any uploaded
+// parts.
+func (up *Uploader) AbortMultipartUpload(ctx context.Context, name string, uploadID string) error {
+	mp := up.mgr.multipart
+	if mp == nil {
+		return ErrMultipartUnsupported
+	}
+	if _, err := uuid.Parse(name); err != nil {
+		return fmt.Errorf("%w: %q", ErrUploadName, name)
+	}
+	if _, err := up.takePending(name, uploadID); err != nil {
+		return err
+	}
+	return up.abort(ctx, name, uploadID)
+}
+
+// PendingMultipartUploads returns the uploader's multipart uploads that
+// haven't been completed or aborted.
+func (up *Uploader) PendingMultipartUploads() []PendingMultipart {
+	up.mx.RLock()
+	defer up.mx.RUnlock()
+	return slices.Clone(up.multiparts)
+}
+
+// takePending removes the pending multipart upload with the name and upload
+// id from the uploader and returns it.
+func (up *Uploader) takePending(name string, uploadID string) (PendingMultipart, error) {
+	up.mx.Lock()
+	defer up.mx.Unlock()
+	idx := slices.IndexFunc(up.multiparts, func(p PendingMultipart) bool {
+		return p.Name == name && p.UploadID == uploadID
+	})
+	if idx < 0 {
+		return PendingMultipart{}, fmt.Errorf("%w: no pending multipart upload %q", ErrUploadName, name)
+	}
+	pending := up.multiparts[idx]
+	up.multiparts = slices.Delete(up.multiparts, idx, idx+1)
+	return pending, nil
+}
+
+// restorePending adds a pending multipart upload that was removed with
+// takePending back to the uploader.
+func (up *Uploader) restorePending(pending PendingMultipart) {
+	up.mx.Lock()
+	defer up.mx.Unlock()
+	up.multiparts = append(up.multiparts, pending)
+}
+
+// deletePending removes the persistent entry for a pending multipart upload.
+func (up *Uploader) deletePending(ctx context.Context, name string) error {
+	if up.mgr.persist == nil {
+		return nil
+	}
+	if err := up.mgr.persist.DeleteMultipartUpload(ctx, up.id, name); err != nil {
+		return fmt.Errorf("deleting multipart upload for uploader %q: %w", up.id, err)
+	}
+	return nil
+}
+
+// abort cancels a multipart upload that was removed from the uploader's
+// pending uploads.
+func (up *Uploader) abort(ctx context.Context, name string, uploadID string) error {
+	_, uproot := up.Root()
+	if err := up.mgr.multipart.AbortMultipartUpload(ctx, path.Join(uproot, name), uploadID); err != nil {
+		return fmt.Errorf("aborting multipart upload %q: %w", name, err)
+	}
+	return up.deletePending(ctx, name)
+}
+
+// abortExpired aborts pending multipart uploads whose part URLs expired more
+// than MultipartCompleteWindow ago.
+func (up *Uploader) abortExpired(ctx context.Context) error {
+	cutoff := time.Now().Add(-MultipartCompleteWindow)
+	up.mx.Lock()
+	var expired []PendingMultipart
+	up.multiparts = slices.DeleteFunc(up.multiparts, func(p PendingMultipart) bool {
+		if p.Expires.Before(cutoff) {
+			expired = append(expired, p)
+			return true
+		}
+		return false
+	})
+	up.mx.Unlock()
+	return up.abortAll(ctx, expired)
+}
+
+// abortAll aborts the multipart uploads, which were removed from the
+// uploader's pending uploads.
+func (up *Uploader) abortAll(ctx context.Context, pending []PendingMultipart) error {
+	var errs []error
+	for _, p := range pending {
+		if err := up.abort(ctx, p.Name, p.UploadID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// digestFile reads the file to compute its size and digests with the
+// uploader's algorithms.
+func (up *Uploader) digestFile(ctx context.Context, name string) (*Upload, error) {
+	writers, err := up.digesters()
+	if err != nil {
+		return nil, err
+	}
+	fsys, _ := up.Root()
+	f, err := fsys.OpenFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	size, err := io.Copy(io.MultiWriter(writers...), f)
+	if err != nil {
+		return nil, fmt.Errorf("reading upload: %w", err)
+	}
+	return up.newUpload(size, writers), nil
+}
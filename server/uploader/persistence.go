@@ -9,17 +9,26 @@ type Persistence interface {
 	CreateUploader(ctx context.Context, vals *PersistentUploader) error
 	CreateUpload(ctx context.Context, upID string, vals *Upload) error
 
+	// add a pending multipart upload to the uploader
+	CreateMultipartUpload(ctx context.Context, upID string, vals *PendingMultipart) error
+
+	// remove the pending multipart upload with the name from the uploader
+	DeleteMultipartUpload(ctx context.Context, upID string, name string) error
+
 	// list of all uploaderIDs
 	GetUploaderIDs(ctx context.Context) ([]string, error)
 
-	// GetUploader with all it's uploads
+	// GetUploader with all it's uploads and pending multipart uploads
 	GetUploader(ctx context.Context, id string) (*PersistentUploader, error)
 
-	// Delete the uploader and all its uploads
+	// Delete the uploader and all its uploads and pending multipart uploads
 	DeleteUploader(ctx context.Context, id string) error
 
 	// number of uploaders
 	CountUploaders(ctx context.Context) (int, error)
+
+	// total size and number of uploads in uploaders with the user id
+	GetUserUsage(ctx context.Context, userID string) (*Usage, error)
 }
 
 type PersistentUploader struct {
@@ -27,4 +36,6 @@ type PersistentUploader struct {
 	Config    Config
 	CreatedAt time.Time
 	Uploads   []Upload
+	// pending multipart uploads
+	Multiparts []PendingMultipart
 }
@@ -0,0 +1,218 @@
+package uploader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrQuotaExceeded is returned when an upload would exceed the user's quota.
+var ErrQuotaExceeded = errors.New("upload quota exceeded")
+
+// Quota limits the size and number of files uploaded by a user. Zero values
+// are unlimited.
+type Quota struct {
+	// maximum bytes in all of the user's uploaders
+	MaxBytes int64 `json:"max_bytes"`
+	// maximum files in all of the user's uploaders
+	MaxFiles int `json:"max_files"`
+	// maximum bytes in each of the user's uploaders
+	MaxUploaderBytes int64 `json:"max_uploader_bytes"`
+	// maximum files in each of the user's uploaders
+	MaxUploaderFiles int `json:"max_uploader_files"`
+}
+
+// Unlimited returns true if the quota doesn't have any limits.
+func (q Quota) Unlimited() bool {
+	return q == Quota{}
+}
+
+// Usage is the total size and number of uploaded files. Pending multipart
+// uploads are included using the size given when they were started.
+type Usage struct {
+	Bytes int64 `json:"bytes"`
+	Files int   `json:"files"`
+}
+
+// QuotaFunc returns the quota for the user id. The context is the context
+// for the request that adds files to one of the user's uploaders.
+type QuotaFunc func(ctx context.Context, userID string) Quota
+
+// WithQuotas enforces quotas returned by fn for uploads to uploaders.
+// Uploaders without a user id are not limited. Quotas are checked before each
+// upload: concurrent uploads by the same user may exceed the quota.
+func WithQuotas(fn QuotaFunc) Option {
+	return func(mgr *Manager) {
+		mgr.quotas = fn
+	}
+}
+
+// Quota returns the quota for the user id. It is unlimited if the manager
+// doesn't have quotas or if userID is empty.
+func (mgr *Manager) Quota(ctx context.Context, userID string) Quota {
+	if mgr.quotas == nil || userID == "" {
+		return Quota{}
+	}
+	return mgr.quotas(ctx, userID)
+}
+
+// UserUsage returns the total size and number of files in the uploaders
+// created by the user.
+func (mgr *Manager) UserUsage(ctx context.Context, userID string) (Usage, error) {
+	if mgr.persist != nil {
+		usage, err := mgr.persist.GetUserUsage(ctx, userID)
+		if err != nil {
+			return Usage{}, fmt.Errorf("getting usage for user %q: %w", userID, err)
+		}
+		return *usage, nil
+	}
+	// don't hold the manager's lock while waiting for uploader locks
+	mgr.mx.Lock()
+	var uppers []*Uploader
+	for _, upper := range mgr.uploaders {
+		if upper.config.UserID == userID {
+			uppers = append(uppers, upper)
+		}
+	}
+	mgr.mx.Unlock()
+	var usage Usage
+	for _, upper := range uppers {
+		upper.mx.RLock()
+		if !upper.deleting {
+			upperUsage := upper.usage()
+			usage.Bytes += upperUsage.Bytes
+			usage.Files += upperUsage.Files
+		}
+		upper.mx.RUnlock()
+	}
+	return usage, nil
+}
+
+// Usage returns the total size and number of files in the uploader.
+func (up *Uploader) Usage() Usage {
+	up.mx.RLock()
+	defer up.mx.RUnlock()
+	return up.usage()
+}
+
+// usage is Usage without locking.
+func (up *Uploader) usage() Usage {
+	usage := Usage{Files: len(up.uploads) + len(up.multiparts)}
+	for _, u := range up.uploads {
+		usage.Bytes += u.Size
+	}
+	for _, p := range up.multiparts {
+		usage.Bytes += p.Size
+	}
+	return usage
+}
+
+// quotaLimit is the remaining allowance for uploads to an uploader.
+type quotaLimit struct {
+	bytes int64 // remaining bytes; negative if unlimited
+	files int   // remaining files; negative if unlimited
+}
+
+// unlimited is the quotaLimit for uploaders without quotas.
+var unlimited = quotaLimit{bytes: -1, files: -1}
+
+// quotaLimit returns the remaining allowance for the uploader's user. It must
+// be called without holding the uploader's lock.
+func (up *Uploader) quotaLimit(ctx context.Context) (quotaLimit, error) {
+	quota := up.mgr.Quota(ctx, up.config.UserID)
+	if quota.Unlimited() {
+		return unlimited, nil
+	}
+	limit := unlimited
+	if quota.MaxBytes > 0 || quota.MaxFiles > 0 {
+		usage, err := up.mgr.UserUsage(ctx, up.config.UserID)
+		if err != nil {
+			return limit, err
+		}
+		limit = limit.restrict(quota.MaxBytes, quota.MaxFiles, usage)
+	}
+	if quota.MaxUploaderBytes > 0 || quota.MaxUploaderFiles > 0 {
+		limit = limit.restrict(quota.MaxUploaderBytes, quota.MaxUploaderFiles, up.Usage())
+	}
+	return limit, nil
+}
+
+// restrict returns a new quotaLimit that doesn't exceed maxBytes and maxFiles
+// (if they are non-zero) given current usage.
+func (l quotaLimit) restrict(maxBytes int64, maxFiles int, usage Usage) quotaLimit {
+	if maxBytes > 0 {
+		remain := max(maxBytes-usage.Bytes, 0)
+		if l.bytes < 0 || remain < l.bytes {
+			l.bytes = remain
+		}
+	}
+	if maxFiles > 0 {
+		remain := max(maxFiles-usage.Files, 0)
+		if l.files < 0 || remain < l.files {
+			l.files = remain
+		}
+	}
+	return l
+}
+
+// release returns a new quotaLimit that also allows a file of the given size.
+// It is used when a file that was counted as usage is replaced.
+func (l quotaLimit) release(size int64) quotaLimit {
+	if l.bytes >= 0 {
+		l.bytes += size
+	}
+	if l.files >= 0 {
+		l.files++
+	}
+	return l
+}
+
+// checkFile returns an error if the limit doesn't allow another file.
+func (l quotaLimit) checkFile() error {
+	if l.files == 0 {
+		return fmt.Errorf("%w: no more files are allowed", ErrQuotaExceeded)
+	}
+	return nil
+}
+
+// checkSize returns an error if the limit doesn't allow a file with the size.
+func (l quotaLimit) checkSize(size int64) error {
+	if l.bytes >= 0 && size > l.bytes {
+		return fmt.Errorf("%w: upload is larger than the remaining %d bytes", ErrQuotaExceeded, l.bytes)
+	}
+	return nil
+}
+
+// reader returns a reader that fails with ErrQuotaExceeded as soon as more
+// bytes are read from r than the limit allows.
+func (l quotaLimit) reader(r io.Reader) io.Reader {
+	if l.bytes < 0 {
+		return r
+	}
+	return &quotaReader{r: r, limit: l.bytes, remain: l.bytes}
+}
+
+type quotaReader struct {
+	r      io.Reader
+	limit  int64
+	remain int64
+	err    error
+}
+
+func (qr *quotaReader) Read(p []byte) (int, error) {
+	if qr.err != nil {
+		return 0, qr.err
+	}
+	// read one byte more than remains to detect overruns
+	if int64(len(p)) > qr.remain+1 {
+		p = p[:qr.remain+1]
+	}
+	n, err := qr.r.Read(p)
+	if int64(n) > qr.remain {
+		qr.err = fmt.Errorf("%w: upload is larger than the remaining %d bytes", ErrQuotaExceeded, qr.limit)
+		return 0, qr.err
+	}
+	qr.remain -= int64(n)
+	return n, err
+}
@@ -0,0 +1,85 @@
+package uploader
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/srerickson/ocfl-go"
+	s3ocfl "github.com/srerickson/ocfl-go/backend/s3"
+)
+
+// S3Multipart is a MultipartBackend for S3 buckets.
+type S3Multipart struct {
+	client *s3.Client
+	bucket string
+}
+
+var _ MultipartBackend = (*S3Multipart)(nil)
+
+// NewS3Multipart returns an S3Multipart for fsys. It returns
+// ErrMultipartUnsupported if fsys isn't an S3 bucket.
+func NewS3Multipart(fsys ocfl.FS) (*S3Multipart, error) {
+	bucketFS, ok := fsys.(*s3ocfl.BucketFS)
+	if !ok {
+		return nil, ErrMultipartUnsupported
+	}
+	client, ok := bucketFS.S3.(*s3.Client)
+	if !ok {
+		return nil, ErrMultipartUnsupported
+	}
+	return &S3Multipart{client: client, bucket: bucketFS.Bucket}, nil
+}
+
+func (mp *S3Multipart) NewMultipartUpload(ctx context.Context, name string) (string, error) {
+	out, err := mp.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(mp.bucket),
+		Key:    aws.String(name),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(out.UploadId), nil
+}
+
+func (mp *S3Multipart) PresignUploadPart(ctx context.Context, name string, uploadID string, number int32, expires time.Duration) (string, error) {
+	params := &s3.UploadPartInput{
+		Bucket:     aws.String(mp.bucket),
+		Key:        aws.String(name),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(number),
+	}
+	req, err := s3.NewPresignClient(mp.client).PresignUploadPart(ctx, params, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", err
+	}
+	return req.URL, nil
+}
+
+func (mp *S3Multipart) CompleteMultipartUpload(ctx context.Context, name string, uploadID string, parts []Part) error {
+	completed := make([]types.CompletedPart, len(parts))
+	for i, p := range parts {
+		completed[i] = types.CompletedPart{
+			PartNumber: aws.Int32(p.Number),
+			ETag:       aws.String(p.ETag),
+		}
+	}
+	_, err := mp.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(mp.bucket),
+		Key:             aws.String(name),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+	})
+	return err
+}
+
+func (mp *S3Multipart) AbortMultipartUpload(ctx context.Context, name string, uploadID string) error {
+	_, err := mp.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(mp.bucket),
+		Key:      aws.String(name),
+		UploadId: aws.String(uploadID),
+	})
+	return err
+}
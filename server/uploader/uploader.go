@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"maps"
 	"path"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,7 +33,8 @@ type Uploader struct {
 	created time.Time
 
 	// mutable
-	uploads []Upload
+	uploads    []Upload
+	multiparts []PendingMultipart
 
 	// sync state
 	deleting bool
@@ -126,6 +130,15 @@ func (up *Uploader) Delete(ctx context.Context) error {
 	if err := up.setDelete(); err != nil {
 		return err
 	}
+	if up.mgr.multipart != nil {
+		up.mx.Lock()
+		pending := up.multiparts
+		up.multiparts = nil
+		up.mx.Unlock()
+		if err := up.abortAll(ctx, pending); err != nil {
+			return fmt.Errorf("aborting multipart uploads for deleted uploader %q: %w", up.id, err)
+		}
+	}
 	fs, root := up.Root()
 	if err := fs.RemoveAll(ctx, root); err != nil {
 		return fmt.Errorf("removing files for deleted uploader %q: %w", up.id, err)
@@ -151,10 +164,102 @@ func (up *Uploader) setDelete() error {
 	return nil
 }
 
-// The Upload returned by write must not be modified!
+// Write writes r to a new file in the uploader. If the uploader already has
+// an upload with the same digests, the new file is discarded and the existing
+// upload is returned. The Upload returned by write must not be modified!
 func (up *Uploader) Write(ctx context.Context, r io.Reader) (*Upload, error) {
+	return up.WriteVerify(ctx, r, nil)
+}
+
+// WriteVerify is like Write, but the upload is checked against expected
+// digests returned by expected, which is called after r has been read. If
+// expected returns an error, or if any expected digest doesn't match, the file
+// is removed and the upload isn't added to the uploader. Mismatched digests
+// result in an error wrapping ErrDigestMismatch. If expected is nil, the
+// upload isn't checked. If the upload exceeds the user's quota, writing stops
+// as soon as the limit is reached and an error wrapping ErrQuotaExceeded is
+// returned.
+func (up *Uploader) WriteVerify(ctx context.Context, r io.Reader, expected func() (ocfl.DigestSet, error)) (*Upload, error) {
+	limit, err := up.quotaLimit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := limit.checkFile(); err != nil {
+		return nil, err
+	}
 	up.mx.Lock()
 	defer up.mx.Unlock()
+	writers, err := up.digesters()
+	if err != nil {
+		return nil, err
+	}
+	name := uuid.NewString()
+	fsys, uproot := up.Root()
+	fullPath := path.Join(uproot, name)
+	size, err := fsys.Write(ctx, fullPath, io.TeeReader(limit.reader(r), io.MultiWriter(writers...)))
+	if err != nil {
+		if errors.Is(err, ErrQuotaExceeded) {
+			// remove partially written file
+			if rmErr := fsys.Remove(ctx, fullPath); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+				err = errors.Join(err, rmErr)
+			}
+		}
+		return nil, err
+	}
+	u := up.newUpload(size, writers)
+	u.Name = name
+	if expected != nil {
+		digests, err := expected()
+		if err == nil {
+			err = up.checkDigests(u, digests)
+		}
+		if err != nil {
+			if rmErr := fsys.Remove(ctx, fullPath); rmErr != nil {
+				err = errors.Join(err, rmErr)
+			}
+			return nil, err
+		}
+	}
+	if existing := up.findUpload(u.Digests); existing != nil {
+		// discard duplicate content
+		if err := fsys.Remove(ctx, fullPath); err != nil {
+			return nil, fmt.Errorf("removing duplicate upload: %w", err)
+		}
+		return existing, nil
+	}
+	up.uploads = append(up.uploads, *u)
+	if up.mgr.persist != nil {
+		if err := up.mgr.persist.CreateUpload(ctx, up.id, u); err != nil {
+			return nil, fmt.Errorf("persisting upload for uploader %q: %w", up.id, err)
+		}
+	}
+
+	return u, nil
+}
+
+// HasContent returns true if the uploader has an upload with the digest,
+// computed with alg.
+func (up *Uploader) HasContent(alg string, digest string) bool {
+	up.mx.RLock()
+	defer up.mx.RUnlock()
+	return slices.ContainsFunc(up.uploads, func(u Upload) bool {
+		return u.Digests[alg] != "" && strings.EqualFold(u.Digests[alg], digest)
+	})
+}
+
+// findUpload returns a copy of the existing upload with the same digests, or
+// nil if there isn't one. The caller must hold the uploader's lock.
+func (up *Uploader) findUpload(digests ocfl.DigestSet) *Upload {
+	for _, existing := range up.uploads {
+		if len(existing.Digests) > 0 && maps.Equal(existing.Digests, digests) {
+			return &existing
+		}
+	}
+	return nil
+}
+
+// digesters returns new digesters for each of the uploader's algorithms.
+func (up *Uploader) digesters() ([]io.Writer, error) {
 	writers := make([]io.Writer, len(up.config.Algs))
 	for i, alg := range up.config.Algs {
 		digester := ocfl.NewDigester(alg)
@@ -164,29 +269,34 @@ func (up *Uploader) Write(ctx context.Context, r io.Reader) (*Upload, error) {
 		}
 		writers[i] = digester
 	}
-	name := uuid.NewString()
-	fsys, uproot := up.Root()
-	fullPath := path.Join(uproot, name)
-	size, err := fsys.Write(ctx, fullPath, io.TeeReader(r, io.MultiWriter(writers...)))
-	if err != nil {
-		return nil, err
+	return writers, nil
+}
+
+// checkDigests returns an error if any of the expected digests don't match
+// the upload's digests or if they include algorithms the uploader doesn't use.
+func (up *Uploader) checkDigests(u *Upload, expected ocfl.DigestSet) error {
+	for alg, digest := range expected {
+		if !up.config.UsesAlg(alg) {
+			return fmt.Errorf("%w: %q", ErrDigestAlgorithm, alg)
+		}
+		if !strings.EqualFold(u.Digests[alg], digest) {
+			return fmt.Errorf("%w: %s %q", ErrDigestMismatch, alg, digest)
+		}
 	}
-	u := Upload{
-		Name:    name,
+	return nil
+}
+
+// newUpload returns an Upload with the size and the digests from writers,
+// which were created with digesters.
+func (up *Uploader) newUpload(size int64, writers []io.Writer) *Upload {
+	u := &Upload{
 		Size:    size,
 		Digests: make(map[string]string, len(up.config.Algs)),
 	}
 	for i, alg := range up.config.Algs {
 		u.Digests[alg] = writers[i].(ocfl.Digester).String()
 	}
-	up.uploads = append(up.uploads, u)
-	if up.mgr.persist != nil {
-		if err := up.mgr.persist.CreateUpload(ctx, up.id, &u); err != nil {
-			return nil, fmt.Errorf("persisting upload for uploader %q: %w", up.id, err)
-		}
-	}
-
-	return &u, nil
+	return u
 }
 
 type Config struct {
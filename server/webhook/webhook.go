@@ -0,0 +1,435 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srerickson/chaparral/server/eventlog"
+)
+
+// metrics are published with expvar
+var metrics = expvar.NewMap("chaparral_webhooks")
+
+// Delivery statuses
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+)
+
+// Headers set on webhook requests
+const (
+	HeaderDelivery  = "Chaparral-Webhook-Delivery"
+	HeaderEvent     = "Chaparral-Webhook-Event"
+	HeaderTimestamp = "Chaparral-Webhook-Timestamp"
+	HeaderSignature = "Chaparral-Webhook-Signature"
+)
+
+// ErrSignature is returned by Verify if a request's signature is missing or
+// invalid.
+var ErrSignature = errors.New("invalid webhook signature")
+
+// Endpoint is a URL that receives events.
+type Endpoint struct {
+	// ID identifies the endpoint in the delivery log.
+	ID  string
+	URL string
+	// Secret is used to sign requests to the endpoint.
+	Secret string
+	// Events is the list of event types sent to the endpoint. If empty, all
+	// events are sent.
+	Events []string
+}
+
+// Accepts returns true if events of the type are sent to the endpoint.
+func (e Endpoint) Accepts(eventType string) bool {
+	return len(e.Events) == 0 || slices.Contains(e.Events, eventType)
+}
+
+// Delivery is an event sent (or to be sent) to an endpoint. The event, encoded
+// as JSON, is the body of the webhook request.
+type Delivery struct {
+	ID         string
+	EndpointID string
+	Event      eventlog.Event
+	// Status is StatusPending, StatusDelivered, or StatusFailed.
+	Status   string
+	Attempts int
+	// time of the next delivery attempt for pending deliveries
+	NextAttempt time.Time
+	// HTTP status code from the last attempt; zero if the request failed.
+	LastStatus int
+	// error from the last attempt
+	LastError string
+	Created   time.Time
+	Updated   time.Time
+}
+
+// Persistence is the outbox used to store deliveries.
+type Persistence interface {
+	// CreateDeliveries adds new deliveries.
+	CreateDeliveries(ctx context.Context, deliveries []Delivery) error
+
+	// ClaimPendingDeliveries returns up to limit pending deliveries with a
+	// next attempt at or before the given time, claiming the oldest first.
+	// The next attempt of the returned deliveries is set to until, so they
+	// aren't claimed again (by any notifier) before then.
+	ClaimPendingDeliveries(ctx context.Context, before time.Time, until time.Time, limit int) ([]Delivery, error)
+
+	// UpdateDelivery saves the status, attempts, next attempt, last status,
+	// last error, and updated time for the delivery.
+	UpdateDelivery(ctx context.Context, delivery *Delivery) error
+
+	// ListDeliveries returns deliveries, most recently created first. If
+	// endpointID is empty, deliveries for all endpoints are returned.
+	ListDeliveries(ctx context.Context, endpointID string, limit int, offset int) ([]Delivery, error)
+}
+
+// Config is used to configure a Notifier
+type Config struct {
+	Endpoints []Endpoint
+	// MaxAttempts is the number of times a delivery is attempted before it
+	// fails. The default is 10.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. The delay doubles with
+	// each attempt, up to MaxBackoff. The defaults are 30 seconds and 1 hour.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// PollInterval is how often Run checks for deliveries that are due. The
+	// default is 10 seconds.
+	PollInterval time.Duration
+	// Timeout is the maximum duration of a delivery attempt. The default is
+	// 10 seconds.
+	Timeout time.Duration
+	// Concurrency is the maximum number of deliveries attempted at the same
+	// time, so a slow endpoint doesn't hold up the others. The default is 4.
+	Concurrency int
+	// Client is used to send requests. The default is http.DefaultClient.
+	Client *http.Client
+	// Logger is used to log delivery failures.
+	Logger *slog.Logger
+}
+
+const (
+	defaultMaxAttempts  = 10
+	defaultMinBackoff   = 30 * time.Second
+	defaultMaxBackoff   = time.Hour
+	defaultPollInterval = 10 * time.Second
+	defaultTimeout      = 10 * time.Second
+	defaultConcurrency  = 4
+	deliveryBatch       = 100
+	maxErrorLength      = 1024
+)
+
+// Notifier saves events to an outbox and delivers them to endpoints. Events
+// are delivered at least once: receivers should use the event ID to ignore
+// duplicates.
+type Notifier struct {
+	persist Persistence
+	conf    Config
+	wake    chan struct{}
+}
+
+func NewNotifier(persist Persistence, conf Config) *Notifier {
+	if conf.MaxAttempts < 1 {
+		conf.MaxAttempts = defaultMaxAttempts
+	}
+	if conf.MinBackoff <= 0 {
+		conf.MinBackoff = defaultMinBackoff
+	}
+	if conf.MaxBackoff < conf.MinBackoff {
+		conf.MaxBackoff = max(defaultMaxBackoff, conf.MinBackoff)
+	}
+	if conf.PollInterval <= 0 {
+		conf.PollInterval = defaultPollInterval
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = defaultTimeout
+	}
+	if conf.Concurrency < 1 {
+		conf.Concurrency = defaultConcurrency
+	}
+	if conf.Client == nil {
+		conf.Client = http.DefaultClient
+	}
+	if conf.Logger == nil {
+		conf.Logger = slog.Default()
+	}
+	return &Notifier{
+		persist: persist,
+		conf:    conf,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Notify saves a delivery of the event for each endpoint that accepts it. If
+// the event's ID or Created time aren't set, they are generated.
+func (n *Notifier) Notify(ctx context.Context, event eventlog.Event) error {
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.Created.IsZero() {
+		event.Created = time.Now()
+	}
+	event.Created = event.Created.UTC()
+	var deliveries []Delivery
+	for _, e := range n.conf.Endpoints {
+		if !e.Accepts(event.Type) {
+			continue
+		}
+		deliveries = append(deliveries, Delivery{
+			ID:          uuid.NewString(),
+			EndpointID:  e.ID,
+			Event:       event,
+			Status:      StatusPending,
+			NextAttempt: event.Created,
+			Created:     event.Created,
+			Updated:     event.Created,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := n.persist.CreateDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("saving webhook deliveries for %s event: %w", event.Type, err)
+	}
+	metrics.Add("events", 1)
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run delivers pending events until ctx is canceled. Deliveries are attempted
+// as soon as they are created and every poll interval after that.
+func (n *Notifier) Run(ctx context.Context) error {
+	ticker := time.NewTicker(n.conf.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := n.DeliverPending(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			n.conf.Logger.Error("delivering webhooks", "err", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-n.wake:
+		}
+	}
+}
+
+// DeliverPending attempts all deliveries that are due, up to the configured
+// concurrency at a time. Failed attempts are retried with exponential backoff
+// until the maximum attempts is reached. Deliveries are claimed in batches
+// before they are attempted, so notifiers that share persistence don't send
+// the same delivery at the same time. Claimed deliveries that aren't attempted
+// (because ctx is canceled) are retried when the claim expires.
+func (n *Notifier) DeliverPending(ctx context.Context) error {
+	for {
+		now := time.Now().UTC()
+		pending, err := n.persist.ClaimPendingDeliveries(ctx, now, now.Add(n.claimTimeout()), deliveryBatch)
+		if err != nil {
+			return fmt.Errorf("getting pending webhook deliveries: %w", err)
+		}
+		if err := n.deliverAll(ctx, pending); err != nil {
+			return err
+		}
+		if len(pending) < deliveryBatch {
+			return nil
+		}
+	}
+}
+
+// deliverAll attempts the deliveries concurrently and waits for all of them to
+// finish. It returns the first error.
+func (n *Notifier) deliverAll(ctx context.Context, deliveries []Delivery) error {
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		sem      = make(chan struct{}, n.conf.Concurrency)
+	)
+loop:
+	for i := range deliveries {
+		// don't start more deliveries after ctx is canceled
+		if ctx.Err() != nil {
+			errOnce.Do(func() { firstErr = ctx.Err() })
+			break
+		}
+		select {
+		case <-ctx.Done():
+			errOnce.Do(func() { firstErr = ctx.Err() })
+			break loop
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(d *Delivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := n.deliver(ctx, d); err != nil {
+				errOnce.Do(func() { firstErr = err })
+			}
+		}(&deliveries[i])
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// Deliveries returns the delivery log, most recent first. If endpointID is
+// empty, deliveries for all endpoints are returned.
+func (n *Notifier) Deliveries(ctx context.Context, endpointID string, limit int, offset int) ([]Delivery, error) {
+	return n.persist.ListDeliveries(ctx, endpointID, limit, offset)
+}
+
+// deliver attempts to send the delivery and saves the result.
+func (n *Notifier) deliver(ctx context.Context, d *Delivery) error {
+	logger := n.conf.Logger.With("webhook_endpoint", d.EndpointID, "webhook_delivery", d.ID)
+	idx := slices.IndexFunc(n.conf.Endpoints, func(e Endpoint) bool { return e.ID == d.EndpointID })
+	var err error
+	d.Attempts++
+	d.LastStatus = 0
+	switch {
+	case idx < 0:
+		err = errors.New("the endpoint is no longer configured")
+		d.Attempts = n.conf.MaxAttempts
+	default:
+		sendCtx, cancel := context.WithTimeout(ctx, n.conf.Timeout)
+		d.LastStatus, err = n.send(sendCtx, n.conf.Endpoints[idx], d)
+		cancel()
+	}
+	if ctx.Err() != nil {
+		// the attempt was interrupted: try again later
+		return ctx.Err()
+	}
+	now := time.Now().UTC()
+	d.Updated = now
+	switch {
+	case err == nil:
+		d.Status = StatusDelivered
+		d.LastError = ""
+		metrics.Add("delivered", 1)
+	case d.Attempts >= n.conf.MaxAttempts:
+		d.Status = StatusFailed
+		d.LastError = truncate(err.Error(), maxErrorLength)
+		metrics.Add("failed", 1)
+		logger.Error("webhook delivery failed", "attempts", d.Attempts, "err", err.Error())
+	default:
+		d.NextAttempt = now.Add(n.backoff(d.Attempts))
+		d.LastError = truncate(err.Error(), maxErrorLength)
+		metrics.Add("retries", 1)
+		logger.Warn("webhook delivery will be retried", "attempts", d.Attempts, "err", err.Error())
+	}
+	if err := n.persist.UpdateDelivery(ctx, d); err != nil {
+		return fmt.Errorf("saving webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// send makes the webhook request. It returns the response status and an
+// error if the status isn't 2xx.
+func (n *Notifier) send(ctx context.Context, endpoint Endpoint, d *Delivery) (int, error) {
+	body, err := json.Marshal(d.Event)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDelivery, d.ID)
+	req.Header.Set(HeaderEvent, d.Event.Type)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret, timestamp, body))
+	resp, err := n.conf.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// claimTimeout returns how long a batch of deliveries is claimed: long enough
+// to attempt all of them, with some to spare.
+func (n *Notifier) claimTimeout() time.Duration {
+	rounds := (deliveryBatch + n.conf.Concurrency - 1) / n.conf.Concurrency
+	return time.Duration(rounds+1) * n.conf.Timeout
+}
+
+// backoff returns the delay before the next attempt.
+func (n *Notifier) backoff(attempts int) time.Duration {
+	delay := n.conf.MinBackoff
+	for i := 1; i < attempts && delay < n.conf.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, n.conf.MaxBackoff)
+}
+
+// Sign returns the signature for a request body sent at the timestamp (Unix
+// seconds): "sha256=" followed by the hex-encoded HMAC-SHA256 of the
+// timestamp, a period, and the body, using the endpoint's secret as the key.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a webhook request with the given headers
+// and body. If maxAge is greater than zero, requests with timestamps older
+// than maxAge are rejected. Receivers use Verify to authenticate requests.
+func Verify(secret string, header http.Header, body []byte, maxAge time.Duration) error {
+	timestamp := header.Get(HeaderTimestamp)
+	sig := header.Get(HeaderSignature)
+	if timestamp == "" || sig == "" {
+		return fmt.Errorf("%w: missing signature headers", ErrSignature)
+	}
+	if maxAge > 0 {
+		secs, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: invalid timestamp", ErrSignature)
+		}
+		if time.Since(time.Unix(secs, 0)) > maxAge {
+			return fmt.Errorf("%w: the request is too old", ErrSignature)
+		}
+	}
+	if !hmac.Equal([]byte(sig), []byte(Sign(secret, timestamp, body))) {
+		return ErrSignature
+	}
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "")
+}
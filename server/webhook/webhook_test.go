@@ -0,0 +1,228 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/carlmjohnson/be"
+	"github.com/srerickson/chaparral/internal/testutil"
+	"github.com/srerickson/chaparral/server/eventlog"
+	"github.com/srerickson/chaparral/server/webhook"
+)
+
+// receiver is a webhook endpoint that records events with valid signatures.
+// Requests fail while fail is greater than zero.
+type receiver struct {
+	secret string
+	mx     sync.Mutex
+	events []eventlog.Event
+	fail   int
+}
+
+func (rcv *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err := webhook.Verify(rcv.secret, r.Header, body, time.Minute); err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	rcv.mx.Lock()
+	defer rcv.mx.Unlock()
+	if rcv.fail > 0 {
+		rcv.fail--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	var event eventlog.Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	rcv.events = append(rcv.events, event)
+}
+
+func (rcv *receiver) received() []eventlog.Event {
+	rcv.mx.Lock()
+	defer rcv.mx.Unlock()
+	return append([]eventlog.Event(nil), rcv.events...)
+}
+
+func TestNotifier(t *testing.T) {
+	ctx := context.Background()
+	rcv := &receiver{secret: "secret", fail: 1}
+	srv := httptest.NewServer(rcv)
+	defer srv.Close()
+	notifier := webhook.NewNotifier(testutil.TestDB(t), webhook.Config{
+		Endpoints: []webhook.Endpoint{
+			{ID: "all", URL: srv.URL, Secret: "secret"},
+			{ID: "wrong-secret", URL: srv.URL, Secret: "wrong", Events: []string{eventlog.ObjectDeleted}},
+			{ID: "uploaders", URL: srv.URL, Secret: "secret", Events: []string{eventlog.UploaderCreated}},
+		},
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+	})
+	committed := eventlog.Event{
+		Type:          eventlog.ObjectCommitted,
+		StorageRootID: "store-id",
+		ObjectID:      "object-1",
+		UserID:        "user-1",
+	}
+	be.NilErr(t, notifier.Notify(ctx, committed))
+	deliveries, err := notifier.Deliveries(ctx, "", 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(deliveries))
+	be.Equal(t, "all", deliveries[0].EndpointID)
+	be.Equal(t, webhook.StatusPending, deliveries[0].Status)
+	be.Nonzero(t, deliveries[0].Event.ID)
+
+	// the first attempt fails and is retried
+	be.NilErr(t, notifier.DeliverPending(ctx))
+	be.Equal(t, 0, len(rcv.received()))
+	deliveries, err = notifier.Deliveries(ctx, "all", 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, webhook.StatusPending, deliveries[0].Status)
+	be.Equal(t, 1, deliveries[0].Attempts)
+	be.Equal(t, http.StatusServiceUnavailable, deliveries[0].LastStatus)
+	be.Nonzero(t, deliveries[0].LastError)
+	time.Sleep(5 * time.Millisecond)
+	be.NilErr(t, notifier.DeliverPending(ctx))
+	events := rcv.received()
+	be.Equal(t, 1, len(events))
+	be.Equal(t, deliveries[0].Event.ID, events[0].ID)
+	be.Equal(t, committed.ObjectID, events[0].ObjectID)
+	be.Equal(t, committed.UserID, events[0].UserID)
+	deliveries, err = notifier.Deliveries(ctx, "all", 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, webhook.StatusDelivered, deliveries[0].Status)
+	be.Equal(t, 2, deliveries[0].Attempts)
+	be.Equal(t, http.StatusOK, deliveries[0].LastStatus)
+	be.Zero(t, deliveries[0].LastError)
+
+	// requests with the wrong signature fail after max attempts
+	be.NilErr(t, notifier.Notify(ctx, eventlog.Event{Type: eventlog.ObjectDeleted, ObjectID: "object-1"}))
+	be.NilErr(t, notifier.DeliverPending(ctx))
+	time.Sleep(5 * time.Millisecond)
+	be.NilErr(t, notifier.DeliverPending(ctx))
+	deliveries, err = notifier.Deliveries(ctx, "wrong-secret", 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 1, len(deliveries))
+	be.Equal(t, webhook.StatusFailed, deliveries[0].Status)
+	be.Equal(t, 2, deliveries[0].Attempts)
+	be.Equal(t, http.StatusUnauthorized, deliveries[0].LastStatus)
+	events = rcv.received()
+	be.Equal(t, 2, len(events))
+	be.Equal(t, eventlog.ObjectDeleted, events[1].Type)
+
+	// Run delivers new events
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- notifier.Run(runCtx) }()
+	be.NilErr(t, notifier.Notify(ctx, eventlog.Event{Type: eventlog.UploaderCreated, UploaderID: "uploader-1"}))
+	for i := 0; i < 100 && len(rcv.received()) < 4; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	be.True(t, errors.Is(<-done, context.Canceled))
+	events = rcv.received()
+	be.Equal(t, 4, len(events))
+	be.Equal(t, "uploader-1", events[2].UploaderID)
+	be.Equal(t, events[2].ID, events[3].ID)
+}
+
+func TestNotifierSlowEndpoint(t *testing.T) {
+	ctx := context.Background()
+	rcv := &receiver{secret: "secret"}
+	srv := httptest.NewServer(rcv)
+	defer srv.Close()
+	unblock := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-unblock:
+		case <-r.Context().Done():
+		}
+	}))
+	defer slow.Close()
+	defer close(unblock)
+	notifier := webhook.NewNotifier(testutil.TestDB(t), webhook.Config{
+		Endpoints: []webhook.Endpoint{
+			{ID: "slow", URL: slow.URL, Secret: "secret"},
+			{ID: "fast", URL: srv.URL, Secret: "secret"},
+		},
+		Timeout:     50 * time.Millisecond,
+		Concurrency: 2,
+	})
+	for i := 0; i < 3; i++ {
+		be.NilErr(t, notifier.Notify(ctx, eventlog.Event{Type: eventlog.ObjectDeleted, ObjectID: "object-1"}))
+	}
+	start := time.Now()
+	be.NilErr(t, notifier.DeliverPending(ctx))
+	be.True(t, time.Since(start) < 5*time.Second)
+	// the slow endpoint doesn't block deliveries to the fast one
+	be.Equal(t, 3, len(rcv.received()))
+	deliveries, err := notifier.Deliveries(ctx, "slow", 10, 0)
+	be.NilErr(t, err)
+	be.Equal(t, 3, len(deliveries))
+	for _, d := range deliveries {
+		be.Equal(t, webhook.StatusPending, d.Status)
+		be.Equal(t, 1, d.Attempts)
+		be.Nonzero(t, d.LastError)
+	}
+}
+
+func TestNotifierShared(t *testing.T) {
+	ctx := context.Background()
+	rcv := &receiver{secret: "secret"}
+	srv := httptest.NewServer(rcv)
+	defer srv.Close()
+	db := testutil.TestDB(t)
+	conf := webhook.Config{
+		Endpoints: []webhook.Endpoint{{ID: "all", URL: srv.URL, Secret: "secret"}},
+	}
+	notifiers := []*webhook.Notifier{
+		webhook.NewNotifier(db, conf),
+		webhook.NewNotifier(db, conf),
+	}
+	for i := 0; i < 20; i++ {
+		be.NilErr(t, notifiers[0].Notify(ctx, eventlog.Event{Type: eventlog.ObjectDeleted, ObjectID: "object-1"}))
+	}
+	// notifiers that share persistence don't send the same deliveries
+	var wg sync.WaitGroup
+	errs := make([]error, len(notifiers))
+	for i, n := range notifiers {
+		wg.Add(1)
+		go func(i int, n *webhook.Notifier) {
+			defer wg.Done()
+			errs[i] = n.DeliverPending(ctx)
+		}(i, n)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		be.NilErr(t, err)
+	}
+	be.Equal(t, 20, len(rcv.received()))
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"event-1"}`)
+	timestamp := "1700000000"
+	header := http.Header{}
+	header.Set(webhook.HeaderTimestamp, timestamp)
+	header.Set(webhook.HeaderSignature, webhook.Sign("secret", timestamp, body))
+	be.NilErr(t, webhook.Verify("secret", header, body, 0))
+	be.True(t, errors.Is(webhook.Verify("other", header, body, 0), webhook.ErrSignature))
+	be.True(t, errors.Is(webhook.Verify("secret", header, []byte(`{}`), 0), webhook.ErrSignature))
+	// too old
+	be.True(t, errors.Is(webhook.Verify("secret", header, body, time.Minute), webhook.ErrSignature))
+	// missing headers
+	be.True(t, errors.Is(webhook.Verify("secret", http.Header{}, body, 0), webhook.ErrSignature))
+}